@@ -5,16 +5,31 @@ import (
 	"errors"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/coffeebeats/gdenv/internal/osutil"
+	"github.com/coffeebeats/gdenv/pkg/catalog"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
 	"github.com/coffeebeats/gdenv/pkg/godot/platform"
 	"github.com/coffeebeats/gdenv/pkg/install"
 	"github.com/coffeebeats/gdenv/pkg/pin"
 	"github.com/coffeebeats/gdenv/pkg/store"
 )
 
+const (
+	// envAutoInstall is an environment variable which, when set to a truthy
+	// value, causes a missing pinned version to be installed before it's run.
+	envAutoInstall = "GDENV_AUTO_INSTALL"
+
+	// recordTimeout is the maximum duration to wait while recording that a
+	// version of Godot was used (see 'markUsed' and 'registerPin').
+	recordTimeout = 2 * time.Second
+)
+
 var (
 	ErrMissingPin   = errors.New("no version selected; try setting a version pin with 'gdenv pin'")
-	ErrNotInstalled = errors.New("pinned version not installed; try installing with 'gdenv install'")
+	ErrNotInstalled = errors.New("pinned version not installed; try installing with 'gdenv install' or set '" + envAutoInstall + "=1'")
 )
 
 func main() {
@@ -58,18 +73,139 @@ func execute(ctx context.Context) error {
 		return err
 	}
 
-	binary, err := install.Which(ctx, storePath, p, wd)
+	binary, err := locate(ctx, storePath, p, wd)
+	if err != nil {
+		return err
+	}
+
+	return osutil.Run(binary, os.Args[1:]...)
+}
+
+/* ---------------------------- Function: locate ---------------------------- */
+
+// locate returns the path to the cached version of Godot specified by a local
+// or global pin, installing it first if auto-installation is enabled.
+func locate(
+	ctx context.Context,
+	storePath string,
+	p platform.Platform,
+	wd string,
+) (string, error) {
+	// Ensure the store can be read. Stores with an older layout are readable
+	// as-is, so they're only migrated if a version needs to be installed.
+	if err := store.CheckLayout(storePath); err != nil {
+		return "", err
+	}
+
+	ex, err := install.WhichExecutable(ctx, storePath, p, wd)
+	if errors.Is(err, install.ErrNotInstalled) && isAutoInstall() {
+		ex, err = installPinned(ctx, storePath, p, wd)
+	}
+
 	if err != nil {
 		if errors.Is(err, pin.ErrMissingPin) {
-			return ErrMissingPin
+			return "", ErrMissingPin
 		}
 
 		if errors.Is(err, install.ErrNotInstalled) {
-			return ErrNotInstalled
+			return "", ErrNotInstalled
 		}
 
-		return err
+		return "", err
+	}
+
+	binary, err := store.Executable(storePath, ex)
+	if err != nil {
+		return "", err
+	}
+
+	markUsed(ctx, storePath, ex)
+	registerPin(ctx, storePath, wd)
+
+	return binary, nil
+}
+
+/* --------------------------- Function: markUsed --------------------------- */
+
+// markUsed records that the executable is being used. This is best-effort;
+// failing to record usage (e.g. due to a read-only store) shouldn't prevent
+// Godot from running.
+func markUsed(ctx context.Context, storePath string, ex executable.Executable) {
+	ctx, cancel := context.WithTimeout(ctx, recordTimeout)
+	defer cancel()
+
+	store.MarkUsed(ctx, storePath, ex) //nolint:errcheck
+}
+
+/* -------------------------- Function: registerPin ------------------------- */
+
+// registerPin records the location of the pin file which selected the version
+// of Godot being run, so that 'gdenv' knows which projects depend on it. Like
+// 'markUsed', this is best-effort. Global pins aren't registered.
+func registerPin(ctx context.Context, storePath, wd string) {
+	ctx, cancel := context.WithTimeout(ctx, recordTimeout)
+	defer cancel()
+
+	path, err := pin.Locate(ctx, storePath, wd)
+	if err != nil || path == storePath {
+		return
+	}
+
+	store.RegisterPin(ctx, storePath, path) //nolint:errcheck
+}
+
+/* ------------------------- Function: isAutoInstall ------------------------ */
+
+// isAutoInstall returns whether missing pinned versions should be installed.
+func isAutoInstall() bool {
+	isAutoInstall, err := strconv.ParseBool(os.Getenv(envAutoInstall))
+
+	return err == nil && isAutoInstall
+}
+
+/* ------------------------- Function: installPinned ------------------------ */
+
+// installPinned installs the version of Godot pinned at the specified path and
+// returns its executable. If the pin is a version constraint, then the newest
+// available release which satisfies it is installed.
+//
+// NOTE: Installation holds a lock on the executable's store entry, so
+// concurrent invocations will wait for the first to finish and then reuse the
+// executable.
+func installPinned(
+	ctx context.Context,
+	storePath string,
+	p platform.Platform,
+	wd string,
+) (executable.Executable, error) {
+	// Installing modifies the store, so ensure it's initialized first.
+	if err := store.Touch(ctx, storePath); err != nil {
+		return executable.Executable{}, err
+	}
+
+	c, err := pin.ConstraintAt(ctx, storePath, wd)
+	if err != nil {
+		return executable.Executable{}, err
+	}
+
+	v, ok := c.Exact()
+	if !ok {
+		releases, err := catalog.Load(ctx, storePath, catalog.DefaultTTL)
+		if err != nil {
+			return executable.Executable{}, err
+		}
+
+		v, err = releases.Select(c)
+		if err != nil {
+			return executable.Executable{}, err
+		}
+	}
+
+	ex := executable.New(v, p)
+
+	if err := install.Executable(ctx, storePath, ex, false); err != nil {
+		return executable.Executable{}, err
 	}
 
-	return run(binary, os.Args[1:]...)
+	return ex, nil
 }
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* ------------------------------ Test: locate ------------------------------ */
+
+func TestLocate(t *testing.T) {
+	const binary = ".gdenv/editor/v4.2-stable/linux.x86_64/Godot_v4.2-stable_linux.x86_64"
+
+	tests := []struct {
+		name  string
+		files []fstest.Writer
+
+		want string
+		err  error
+	}{
+		{
+			name: "original layout with the pinned version is used as-is",
+			files: []fstest.Writer{
+				fstest.File{Path: "project/.godot-version", Contents: "4.2"},
+				fstest.File{Path: binary},
+			},
+
+			want: binary,
+		},
+		{
+			name: "original layout without the pinned version returns an error",
+			files: []fstest.Writer{
+				fstest.File{Path: "project/.godot-version", Contents: "4.3"},
+				fstest.File{Path: binary},
+			},
+
+			err: ErrNotInstalled,
+		},
+		{
+			name: "newer layout returns an error",
+			files: []fstest.Writer{
+				fstest.File{Path: "project/.godot-version", Contents: "4.2"},
+				fstest.File{Path: binary},
+				fstest.File{Path: ".gdenv/layout.v999"},
+			},
+
+			err: store.ErrUnexpectedLayout,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			storePath := filepath.Join(tmp, ".gdenv")
+
+			t.Setenv("GDENV_HOME", storePath)
+			t.Setenv("GDENV_PATH", "")
+			t.Setenv(envAutoInstall, "")
+
+			// Given: The specified files exist on the file system.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The pinned version is located from within the project.
+			p := platform.Platform{OS: platform.Linux, Arch: platform.Amd64}
+			got, err := locate(context.Background(), storePath, p, filepath.Join(tmp, "project"))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected executable is returned.
+			if want := filepath.Join(tmp, tc.want); tc.want != "" && got != want {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+
+			// Then: The store isn't migrated.
+			fstest.Absent{Path: ".gdenv/layout.v1"}.Assert(t, tmp)
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/pkg/bundle"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+var (
+	ErrBundleMissingFile      = errors.New("missing bundle 'FILE'")
+	ErrBundleUsageAllAndVer   = errors.New("cannot specify both '-a/--all' and 'VERSION'")
+	ErrBundleUsageMissingVer  = errors.New("missing 'VERSION' (or '-a/--all')")
+	ErrBundleVersionNotFound  = errors.New("version not installed")
+	ErrBundleUsageTooManyArgs = errors.New("too many arguments")
+)
+
+// A 'urfave/cli' command to move installed versions of Godot between stores.
+func NewBundle() *cli.Command {
+	return &cli.Command{
+		Name:     "bundle",
+		Category: "Install",
+
+		Usage:     "export installed versions of Godot into a portable bundle or import them from one",
+		UsageText: "gdenv bundle <export|import> [OPTIONS] FILE",
+
+		Subcommands: []*cli.Command{
+			newBundleExport(),
+			newBundleImport(),
+		},
+	}
+}
+
+/* ---------------------- Function: newBundleExport ------------------------- */
+
+// newBundleExport creates a 'urfave/cli' command to export installed versions
+// of Godot into a bundle.
+func newBundleExport() *cli.Command {
+	return &cli.Command{
+		Name: "export",
+
+		Usage: "write the installed executables (for all platforms) of each 'VERSION', along with " +
+			"their checksums and metadata, to the bundle 'FILE'",
+		UsageText: "gdenv bundle export [OPTIONS] FILE [VERSION...]",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+
+			&cli.BoolFlag{
+				Name:    "all",
+				Aliases: []string{"a"},
+				Usage:   "export all installed executable and source code versions (cannot be used with 'VERSION')",
+			},
+			&cli.BoolFlag{
+				Name:    "source",
+				Aliases: []string{"s", "src"},
+				Usage:   "export source code instead of executables",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			out := c.Args().First()
+			if out == "" {
+				return UsageError{ctx: c, err: ErrBundleMissingFile}
+			}
+
+			versions := c.Args().Tail()
+
+			switch all := c.Bool("all"); {
+			case all && len(versions) > 0:
+				return UsageError{ctx: c, err: ErrBundleUsageAllAndVer}
+			case !all && len(versions) == 0:
+				return UsageError{ctx: c, err: ErrBundleUsageMissingVer}
+			}
+
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			var artifacts []artifact.Artifact
+
+			switch src := c.Bool("source"); {
+			case c.Bool("all"):
+				artifacts, err = listInstalled(c.Context, storePath, src)
+			default:
+				artifacts, err = resolveBundleTargets(c, storePath, versions, src)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			out, err = filepath.Abs(out)
+			if err != nil {
+				return err
+			}
+
+			log.Infof("exporting %d artifact(s) to bundle: %s", len(artifacts), out)
+
+			if err := bundle.Export(c.Context, storePath, artifacts, out); err != nil {
+				return err
+			}
+
+			for _, a := range artifacts {
+				label, err := describeArtifact(a)
+				if err != nil {
+					return err
+				}
+
+				log.Infof("exported version: %s", label)
+			}
+
+			return nil
+		},
+	}
+}
+
+/* ---------------------- Function: newBundleImport ------------------------- */
+
+// newBundleImport creates a 'urfave/cli' command to add the contents of a
+// bundle to the store.
+func newBundleImport() *cli.Command {
+	return &cli.Command{
+		Name: "import",
+
+		Usage: "verify the checksums of the versions in the bundle 'FILE' and add them to the store; " +
+			"versions which are already installed are skipped",
+		UsageText: "gdenv bundle import [OPTIONS] FILE",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+		},
+
+		Action: func(c *cli.Context) error {
+			if !c.Args().Present() {
+				return UsageError{ctx: c, err: ErrBundleMissingFile}
+			}
+
+			if c.NArg() > 1 {
+				return UsageError{ctx: c, err: ErrBundleUsageTooManyArgs}
+			}
+
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			log.Infof("importing bundle: %s", c.Args().First())
+
+			imported, skipped, err := bundle.Import(c.Context, storePath, c.Args().First())
+
+			for _, a := range skipped {
+				label, err := describeArtifact(a)
+				if err != nil {
+					return err
+				}
+
+				log.Infof("skipping version; already installed: %s", label)
+			}
+
+			for _, a := range imported {
+				label, err := describeArtifact(a)
+				if err != nil {
+					return err
+				}
+
+				log.Infof("imported version: %s", label)
+			}
+
+			return err
+		},
+	}
+}
+
+/* --------------------- Function: resolveBundleTargets --------------------- */
+
+// resolveBundleTargets returns the installed artifacts to export for each of
+// the specified versions. If 'src' is set, then the version's source code is
+// exported; otherwise its executables for all installed platforms are.
+func resolveBundleTargets(
+	c *cli.Context,
+	storePath string,
+	versions []string,
+	src bool,
+) ([]artifact.Artifact, error) {
+	out := make([]artifact.Artifact, 0, len(versions))
+
+	for _, arg := range versions {
+		v, err := version.Parse(arg)
+		if err != nil {
+			return nil, UsageError{ctx: c, err: err}
+		}
+
+		found, err := installedArtifactsOf(c.Context, storePath, v, src)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(found) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrBundleVersionNotFound, v)
+		}
+
+		out = append(out, found...)
+	}
+
+	return out, nil
+}
+
+/* -------------------- Function: installedArtifactsOf ---------------------- */
+
+// installedArtifactsOf returns the installed executables (for any platform) or
+// source code of the specified version.
+func installedArtifactsOf(
+	ctx context.Context,
+	storePath string,
+	v version.Version,
+	src bool,
+) ([]artifact.Artifact, error) {
+	if src {
+		s := source.New(v)
+
+		ok, err := store.Has(storePath, s)
+		if err != nil || !ok {
+			return nil, err
+		}
+
+		return []artifact.Artifact{s}, nil
+	}
+
+	ee, err := store.Executables(ctx, storePath)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]artifact.Artifact, 0)
+
+	for _, ex := range ee {
+		if ex.Artifact.Version() == v {
+			out = append(out, ex.Artifact)
+		}
+	}
+
+	return out, nil
+}
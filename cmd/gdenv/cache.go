@@ -0,0 +1,138 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/pkg/download"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+// A 'urfave/cli' command to manage the cache of downloaded archives.
+func NewCache() *cli.Command {
+	return &cli.Command{
+		Name:     "cache",
+		Category: "Install",
+
+		Usage: "manage the cache of downloaded archives used to reinstall versions of Godot " +
+			"without downloading them again (enabled by setting '" + download.EnvCacheSize + "')",
+		UsageText: "gdenv cache <ls|clear> [OPTIONS]",
+
+		Subcommands: []*cli.Command{
+			newCacheClear(),
+			newCacheLs(),
+		},
+	}
+}
+
+/* ------------------------- Function: newCacheClear ------------------------ */
+
+// newCacheClear creates a 'urfave/cli' command to remove all cached archives.
+func newCacheClear() *cli.Command {
+	return &cli.Command{
+		Name: "clear",
+
+		Usage:     "remove all downloaded archives from the cache",
+		UsageText: "gdenv cache clear [OPTIONS]",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+		},
+
+		Action: func(c *cli.Context) error {
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			entries, err := store.CacheEntries(storePath)
+			if err != nil {
+				return err
+			}
+
+			if err := store.ClearCache(c.Context, storePath); err != nil {
+				return err
+			}
+
+			var total int64
+			for _, e := range entries {
+				total += e.Size
+			}
+
+			log.Infof("removed %d cached archive(s) (%s)", len(entries), formatBytes(total))
+
+			return nil
+		},
+	}
+}
+
+/* -------------------------- Function: newCacheLs -------------------------- */
+
+// newCacheLs creates a 'urfave/cli' command to print the cached archives.
+func newCacheLs() *cli.Command {
+	return &cli.Command{
+		Name:    "ls",
+		Aliases: []string{"list"},
+
+		Usage:     "print the cached archives, from most to least recently used",
+		UsageText: "gdenv cache ls [OPTIONS]",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+		},
+
+		Action: func(c *cli.Context) error {
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			maxSize, err := download.CacheSize()
+			if err != nil {
+				return err
+			}
+
+			if maxSize == 0 {
+				log.Warnf("download cache is disabled; set '%s' to enable it (e.g. '2GB')", download.EnvCacheSize)
+			}
+
+			entries, err := store.CacheEntries(storePath)
+			if err != nil {
+				return err
+			}
+
+			if len(entries) == 0 {
+				return nil
+			}
+
+			var total int64
+			for _, e := range entries {
+				total += e.Size
+			}
+
+			switch usage := formatBytes(total); maxSize {
+			case 0:
+				log.Printf("Cached archives (%s):", usage)
+			default:
+				log.Printf("Cached archives (%s of %s):", usage, formatBytes(maxSize))
+			}
+
+			for _, e := range entries {
+				log.Printf(
+					"  %s (%s; last used %s)",
+					e.Name,
+					formatBytes(e.Size),
+					e.LastUsed.Local().Format(time.DateTime),
+				)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/pkg/du"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* ----------------------------- Function: NewDu ---------------------------- */
+
+// A 'urfave/cli' command to print the disk usage of installed versions of
+// Godot.
+func NewDu() *cli.Command {
+	return &cli.Command{
+		Name:     "du",
+		Category: "Utilities",
+
+		Usage: "print the disk space used by each installed version of Godot, grouped by " +
+			"artifact kind and sorted from largest to smallest",
+		UsageText: "gdenv du [OPTIONS]",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "print the report as JSON (sizes are in bytes)",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			r, err := du.Scan(c.Context, storePath)
+			if err != nil {
+				return err
+			}
+
+			if c.Bool("json") {
+				bb, err := json.MarshalIndent(r, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				_, err = fmt.Fprintln(c.App.Writer, string(bb))
+
+				return err
+			}
+
+			printUsage(storePath, r)
+
+			return nil
+		},
+	}
+}
+
+/* -------------------------- Function: printUsage -------------------------- */
+
+// printUsage prints a human-readable disk usage report.
+func printUsage(storePath string, r du.Report) {
+	if len(r.Executables) > 0 {
+		log.Printf(
+			"Executable versions (%s; %s mono, %s standard):",
+			formatBytes(r.Totals.Executables),
+			formatBytes(r.Totals.Mono),
+			formatBytes(r.Totals.Standard),
+		)
+
+		for _, e := range r.Executables {
+			log.Printf("  %9s  %s (%s)%s", formatBytes(e.Size), e.Version, e.Platform, formatUsageLayer(storePath, e))
+		}
+
+		log.Print("")
+	}
+
+	if len(r.Sources) > 0 {
+		log.Printf("Source code versions (%s):", formatBytes(r.Totals.Sources))
+
+		for _, e := range r.Sources {
+			log.Printf("  %9s  %s%s", formatBytes(e.Size), e.Version, formatUsageLayer(storePath, e))
+		}
+
+		log.Print("")
+	}
+
+	log.Printf("Total: %s (%s)", formatBytes(r.Totals.All), storePath)
+}
+
+/* ----------------------- Function: formatUsageLayer ----------------------- */
+
+// formatUsageLayer returns a label naming the store in which the entry's
+// artifact is installed. This is empty unless multiple stores are searched
+// (see 'store.Layers').
+func formatUsageLayer(storePath string, e du.Entry) string {
+	layers, err := store.Layers()
+	if err != nil || len(layers) <= 1 {
+		return ""
+	}
+
+	return formatStore(storePath, e.Store)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/internal/osutil"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+var (
+	ErrExecUsageTooManyArgs    = errors.New("too many arguments before '--'")
+	ErrExecUsageVersionAndPath = errors.New("cannot specify both 'VERSION' and '-p/--path'")
+)
+
+// argsSeparator separates 'gdenv' arguments from those passed to Godot.
+const argsSeparator = "--"
+
+// A 'urfave/cli' command to run a specific version of Godot without pinning it.
+func NewExec() *cli.Command {
+	return &cli.Command{
+		Name:     "exec",
+		Category: "Utilities",
+
+		Aliases: []string{"run"},
+
+		Usage: "run a specific version of Godot (installing it if needed); " +
+			"if 'VERSION' is omitted then the version is resolved using '-p' or '$PWD'",
+		UsageText: "gdenv exec [OPTIONS] [VERSION] [-- ARGS...]",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+			newOfflineFlag(),
+
+			&cli.StringFlag{
+				Name:    "path",
+				Aliases: []string{"p"},
+				Usage:   "resolve the pinned 'VERSION' at 'PATH'",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			versionArg, args, err := splitExecArgs(c.Args().Slice(), c.IsSet("path"))
+			if err != nil {
+				return UsageError{ctx: c, err: err}
+			}
+
+			if versionArg != "" && c.IsSet("path") {
+				return UsageError{ctx: c, err: ErrExecUsageVersionAndPath}
+			}
+
+			v, err := resolveVersion(c, versionArg, resolveRelease)
+			if err != nil {
+				return err
+			}
+
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			binary, err := executablePath(c.Context, storePath, v)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("running executable: %s", binary)
+
+			return osutil.Run(binary, args...)
+		},
+	}
+}
+
+/* ------------------------- Function: splitExecArgs ------------------------ */
+
+// splitExecArgs separates the optional 'VERSION' argument from the arguments
+// which should be passed to Godot. All arguments after 'argsSeparator' are
+// passed to Godot; at most one argument, the 'VERSION', may precede it. If the
+// separator is omitted, then the first argument is only considered a 'VERSION'
+// if '-p' was not specified (see 'hasPath') and it's a valid version.
+//
+// NOTE: The flag parser drops a leading separator (e.g. 'exec -- ARGS'), so
+// such arguments are never mistaken for a 'VERSION' unless they're valid.
+func splitExecArgs(args []string, hasPath bool) (string, []string, error) {
+	if i := slices.Index(args, argsSeparator); i >= 0 {
+		switch i {
+		case 0:
+			return "", args[1:], nil
+		case 1:
+			return args[0], args[i+1:], nil
+		default:
+			return "", nil, fmt.Errorf("%w: %s", ErrExecUsageTooManyArgs, strings.Join(args[1:i], " "))
+		}
+	}
+
+	if len(args) == 0 || hasPath {
+		return "", args, nil
+	}
+
+	if _, err := version.ParseConstraint(args[0]); err != nil {
+		return "", args, nil
+	}
+
+	return args[0], args[1:], nil
+}
+
+/* ------------------------ Function: executablePath ------------------------ */
+
+// executablePath returns the path to the cached Godot executable for the host
+// platform, installing it first if it's missing. The executable is recorded as
+// having been used.
+func executablePath(ctx context.Context, storePath string, v version.Version) (string, error) {
+	p, err := platform.Detect()
+	if err != nil {
+		return "", err
+	}
+
+	ex := executable.New(v, p)
+
+	ok, err := store.Has(storePath, ex)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		if err := installExecutable(ctx, storePath, v, false); err != nil {
+			return "", err
+		}
+	}
+
+	if err := store.MarkUsed(ctx, storePath, ex); err != nil {
+		log.Debugf("failed to record use of version: %s: %s", v, err)
+	}
+
+	return store.Executable(storePath, ex)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+/* --------------------------- Test: splitExecArgs -------------------------- */
+
+func TestSplitExecArgs(t *testing.T) {
+	tests := []struct {
+		args    string
+		hasPath bool
+
+		version string
+		want    []string
+		err     error
+	}{
+		{args: "", want: []string{}},
+		{args: "4.2", version: "4.2", want: []string{}},
+		{args: "4.2 --headless", version: "4.2", want: []string{"--headless"}},
+		{args: "--headless", want: []string{"--headless"}},
+		{args: "project.godot", want: []string{"project.godot"}},
+		{args: "4.2", hasPath: true, want: []string{"4.2"}},
+		{args: "-- 4.2", want: []string{"4.2"}},
+		{args: "4.2 --", version: "4.2", want: []string{}},
+		{args: "4.2 -- --headless", version: "4.2", want: []string{"--headless"}},
+		{args: "4.2 foo -- --headless", err: ErrExecUsageTooManyArgs},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.args, func(t *testing.T) {
+			// When: The arguments are split.
+			version, got, err := splitExecArgs(strings.Fields(tc.args), tc.hasPath)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected 'VERSION' is returned.
+			if version != tc.version {
+				t.Errorf("output: got %v, want %v", version, tc.version)
+			}
+
+			// Then: The expected arguments are passed to Godot.
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
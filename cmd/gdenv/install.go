@@ -9,7 +9,10 @@ import (
 	"github.com/charmbracelet/log"
 	"github.com/urfave/cli/v2"
 
+	"github.com/coffeebeats/gdenv/pkg/catalog"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
 	"github.com/coffeebeats/gdenv/pkg/godot/platform"
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
 	"github.com/coffeebeats/gdenv/pkg/install"
@@ -20,6 +23,8 @@ import (
 var (
 	ErrInstallUsageGlobalAndPath   = errors.New("cannot specify both '-g/--global' and '-p/--path'")
 	ErrInstallUsageGlobalAndSource = errors.New("cannot specify both '-g/--global' and '-s/--source'")
+	ErrInstallUsageGlobalAndTpl    = errors.New("cannot specify both '-g/--global' and '-t/--templates'")
+	ErrInstallUsageSourceAndTpl    = errors.New("cannot specify both '-s/--source' and '-t/--templates'")
 )
 
 // A 'urfave/cli' command to download and cache a specific version of Godot.
@@ -36,6 +41,8 @@ func NewInstall() *cli.Command { //nolint:funlen
 
 		Flags: []cli.Flag{
 			newVerboseFlag(),
+			newOfflineFlag(),
+			newPrintResolvedFlag(),
 
 			&cli.BoolFlag{
 				Name:    "force",
@@ -57,6 +64,11 @@ func NewInstall() *cli.Command { //nolint:funlen
 				Aliases: []string{"s", "src"},
 				Usage:   "install source code instead of an executable (cannot be used with '-g')",
 			},
+			&cli.BoolFlag{
+				Name:    "templates",
+				Aliases: []string{"t"},
+				Usage:   "install export templates instead of an executable (cannot be used with '-g' or '-s')",
+			},
 		},
 
 		Action: func(c *cli.Context) error {
@@ -69,12 +81,24 @@ func NewInstall() *cli.Command { //nolint:funlen
 				return UsageError{ctx: c, err: ErrInstallUsageGlobalAndSource}
 			}
 
-			v, err := resolveVersionFromInput(c)
+			if c.IsSet("global") && c.IsSet("templates") {
+				return UsageError{ctx: c, err: ErrInstallUsageGlobalAndTpl}
+			}
+
+			if c.IsSet("source") && c.IsSet("templates") {
+				return UsageError{ctx: c, err: ErrInstallUsageSourceAndTpl}
+			}
+
+			v, err := resolveVersionFromInput(c, resolveRelease)
 			if err != nil {
 				return err
 			}
 
-			storePath, err := touchStore()
+			if err := printResolved(c, v); err != nil {
+				return err
+			}
+
+			storePath, err := touchStore(c.Context)
 			if err != nil {
 				return err
 			}
@@ -82,18 +106,45 @@ func NewInstall() *cli.Command { //nolint:funlen
 			log.Debugf("using store at path: %s", storePath)
 
 			if c.Bool("source") {
-				return install.Source(c.Context, storePath, v, c.Bool("force"))
+				ctx, stop := withProgress[source.Archive](c.Context)
+				defer stop()
+
+				return install.Source(ctx, storePath, v, c.Bool("force"))
+			}
+
+			if c.Bool("templates") {
+				return installTemplates(c.Context, storePath, v, c.Bool("force"))
+			}
+
+			m, err := resolveManifest(c, storePath)
+			if err != nil {
+				return err
+			}
+
+			p, err := platform.Detect()
+			if err != nil {
+				return err
+			}
+
+			if !m.Allows(p.OS) {
+				return fmt.Errorf("%w: %s", pin.ErrPlatformNotAllowed, p.OS)
 			}
 
 			if err := installExecutable(c.Context, storePath, v, c.Bool("force")); err != nil {
 				return err
 			}
 
+			if m.Templates {
+				if err := installTemplates(c.Context, storePath, v, c.Bool("force")); err != nil {
+					return err
+				}
+			}
+
 			if !c.Bool("global") {
 				return nil
 			}
 
-			return writePin(storePath, storePath, v)
+			return writePin(c.Context, storePath, storePath, v)
 		},
 	}
 }
@@ -116,6 +167,9 @@ func installExecutable(
 	// Define the target 'Executable'.
 	ex := executable.New(v, p)
 
+	ctx, stop := withProgress[executable.Archive](ctx)
+	defer stop()
+
 	if err := install.Executable(ctx, storePath, ex, force); err != nil {
 		return err
 	}
@@ -123,6 +177,28 @@ func installExecutable(
 	return nil
 }
 
+/* ----------------------- Function: installTemplates ----------------------- */
+
+// Installs the specified export templates version to the store and the editor
+// data directory, but only if needed.
+func installTemplates(
+	ctx context.Context,
+	storePath string,
+	v version.Version,
+	force bool,
+) error {
+	// Define the host 'Platform'.
+	p, err := platform.Detect()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := withProgress[templates.Archive](ctx)
+	defer stop()
+
+	return install.Templates(ctx, storePath, p, v, force)
+}
+
 /* -------------------- Function: resolveVersionFromInput ------------------- */
 
 // Parses command arguments and environment variables and reads pin files to
@@ -131,7 +207,9 @@ func installExecutable(
 // There are four distinct situations which need to be handled. These are listed
 // below along with their desired resolution:
 //  1. An explicit version is passed in (e.g. `install <version>`)
-//     > The explicitly specified version is returned.
+//     > The explicitly specified version is returned. If it's a version
+//     > selector (e.g. `latest` or `4.3-latest`), then it's resolved using
+//     > 'resolve'.
 //  2. No version is passed, but the '-g' flag is used (e.g. `install -g`)
 //     > The globally pinned version is returned.
 //  3. No version is passed, but the '-p' flag is used (e.g. `install --path <path>`)
@@ -140,53 +218,184 @@ func installExecutable(
 //     > The pinned version is resolved _in the current working directory_.
 //
 // For 3. and 4., both of which require pin resolution, the standard resolution
-// strategy of checking for a local pin and then a global is used.
-func resolveVersionFromInput(c *cli.Context) (version.Version, error) {
-	versionArg := c.Args().First()
-
-	v, err := version.Parse(versionArg)
-	if err != nil && versionArg != "" {
-		return version.Version{}, UsageError{ctx: c, err: err}
-	}
+// strategy of checking for a local pin and then a global is used. In all cases,
+// a pinned version constraint is resolved using 'resolve' (e.g. to the newest
+// available release for commands which install Godot).
+func resolveVersionFromInput(c *cli.Context, resolve versionResolver) (version.Version, error) {
+	return resolveVersion(c, c.Args().First(), resolve)
+}
 
-	if err == nil {
-		return v, nil
-	}
+/* ------------------------ Function: resolveVersion ------------------------ */
 
+// resolveVersion determines the correct version of Godot to use, preferring the
+// explicitly specified 'versionArg' (if set). See 'resolveVersionFromInput'.
+func resolveVersion(c *cli.Context, versionArg string, resolve versionResolver) (version.Version, error) {
 	storePath, err := store.Path()
 	if err != nil {
 		return version.Version{}, err
 	}
 
+	if versionArg != "" {
+		return resolveVersionArg(c, storePath, versionArg, resolve)
+	}
+
+	var pinned version.Constraint
+
 	// If '-g' is passed then _only_ the globally-pinned version should be
 	// returned. Prior validation should have already ensured '-p' was not
 	// simultaneously set.
 	if c.IsSet("global") && c.Bool("global") {
-		return pin.Read(storePath)
+		pinned, err = pin.ReadConstraint(storePath)
+		if err != nil {
+			return version.Version{}, err
+		}
+	} else {
+		// NOTE: 'filepath.Clean' will replace '' with '.', handling cases 3.
+		// and 4. simultaneously.
+		path := filepath.Clean(c.String("path"))
+
+		pinned, err = pin.ConstraintAt(c.Context, storePath, path)
+		if err != nil {
+			// Return an error that communicates the root problem and hides any
+			// attempted global pin resolution.
+			if errors.Is(err, pin.ErrMissingPath) || errors.Is(err, pin.ErrMissingPin) {
+				return version.Version{}, fmt.Errorf("%w: %s", pin.ErrMissingPin, path)
+			}
+
+			return version.Version{}, err
+		}
 	}
 
-	// NOTE: 'filepath.Clean' will replace '' with '.', handling cases 3. and 4.
-	// simultaneously.
-	path := filepath.Clean(c.String("path"))
+	if v, ok := pinned.Exact(); ok {
+		return v, nil
+	}
 
-	v, err = pin.VersionAt(c.Context, storePath, path) // Update 'v' value.
+	v, err := resolve(c.Context, storePath, pinned)
 	if err != nil {
-		// Return an error that communicates the root problem and hides any
-		// attempted global pin resolution.
-		if errors.Is(err, pin.ErrMissingPath) || errors.Is(err, pin.ErrMissingPin) {
-			return version.Version{}, fmt.Errorf("%w: %s", pin.ErrMissingPin, path)
-		}
+		return version.Version{}, err
+	}
+
+	log.Infof("resolved pinned version '%s' to: %s", pinned, v)
+
+	return v, nil
+}
+
+/* ------------------------ Function: resolveManifest ----------------------- */
+
+// resolveManifest reads the 'gdenv.toml' manifest which applies when the
+// pinned version is resolved from '-p' or '$PWD' (see 'pin.ManifestAt'). An
+// empty 'pin.Manifest' is returned if there isn't one or if 'VERSION' or '-g'
+// was specified.
+func resolveManifest(c *cli.Context, storePath string) (pin.Manifest, error) {
+	var m pin.Manifest
+
+	if c.Args().Present() || c.Bool("global") {
+		return m, nil
+	}
+
+	m, err := pin.ManifestAt(c.Context, storePath, filepath.Clean(c.String("path")))
+	if err != nil && !errors.Is(err, pin.ErrMissingManifest) {
+		return m, err
+	}
+
+	return m, nil
+}
 
+/* ----------------------- Function: resolveVersionArg ---------------------- */
+
+// resolveVersionArg parses the 'VERSION' argument 'input', which is either an
+// exact version or a version selector (e.g. 'latest', 'latest-beta', or
+// '4.3-latest'; see 'version.Constraint'). Exact versions are returned as-is,
+// while version selectors are resolved using 'resolve'.
+func resolveVersionArg(
+	c *cli.Context,
+	storePath, input string,
+	resolve versionResolver,
+) (version.Version, error) {
+	constraint, err := version.ParseConstraint(input)
+	if err != nil {
+		return version.Version{}, UsageError{ctx: c, err: err}
+	}
+
+	if v, ok := constraint.Exact(); ok {
+		return v, nil
+	}
+
+	v, err := resolve(c.Context, storePath, constraint)
+	if err != nil {
 		return version.Version{}, err
 	}
 
+	log.Infof("resolved '%s' to version: %s", input, v)
+
+	return v, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Type: versionResolver                            */
+/* -------------------------------------------------------------------------- */
+
+// versionResolver selects the exact version which satisfies a version selector.
+type versionResolver func(ctx context.Context, storePath string, c version.Constraint) (version.Version, error)
+
+/* ------------------------- Function: resolveRelease ----------------------- */
+
+// resolveRelease is a 'versionResolver' which selects the newest available
+// release of Godot (see 'catalog.Load').
+func resolveRelease(ctx context.Context, storePath string, c version.Constraint) (version.Version, error) {
+	releases, err := catalog.Load(ctx, storePath, catalog.DefaultTTL)
+	if err != nil {
+		return version.Version{}, err
+	}
+
+	return releases.Select(c)
+}
+
+/* -------------------- Function: resolveInstalledExecutable ---------------- */
+
+// resolveInstalledExecutable is a 'versionResolver' which selects the newest
+// executable version installed for the host platform.
+func resolveInstalledExecutable(
+	ctx context.Context,
+	storePath string,
+	c version.Constraint,
+) (version.Version, error) {
+	// Define the host 'Platform'.
+	p, err := platform.Detect()
+	if err != nil {
+		return version.Version{}, err
+	}
+
+	return install.ResolveInstalled(ctx, storePath, p, c)
+}
+
+/* ---------------------- Function: resolveInstalledSource ------------------ */
+
+// resolveInstalledSource is a 'versionResolver' which selects the newest
+// installed source code version.
+func resolveInstalledSource(ctx context.Context, storePath string, c version.Constraint) (version.Version, error) {
+	sources, err := store.Sources(ctx, storePath)
+	if err != nil {
+		return version.Version{}, err
+	}
+
+	versions := make([]version.Version, 0, len(sources))
+	for _, src := range sources {
+		versions = append(versions, src.Artifact.Version())
+	}
+
+	v, ok := c.Select(versions)
+	if !ok {
+		return version.Version{}, fmt.Errorf("%w: %s", install.ErrNotInstalled, c)
+	}
+
 	return v, nil
 }
 
 /* -------------------------- Function: touchStore -------------------------- */
 
 // touchStore determines the store path and ensures it has the expected layout.
-func touchStore() (string, error) {
+func touchStore(ctx context.Context) (string, error) {
 	// Determine the store path.
 	storePath, err := store.Path()
 	if err != nil {
@@ -194,7 +403,7 @@ func touchStore() (string, error) {
 	}
 
 	// Ensure the store exists.
-	if err := store.Touch(storePath); err != nil {
+	if err := store.Touch(ctx, storePath); err != nil {
 		return "", err
 	}
 
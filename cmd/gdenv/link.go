@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/install"
+)
+
+var (
+	ErrLinkUsageMissingArgs = errors.New("missing 'VERSION' and/or 'PATH'")
+	ErrLinkUsageTooManyArgs = errors.New("too many arguments")
+)
+
+// A 'urfave/cli' command to add a locally-built Godot editor to the store.
+func NewLink() *cli.Command {
+	return &cli.Command{
+		Name:     "link",
+		Category: "Install",
+
+		Usage: "add a locally-built Godot editor to the store as a custom version; " +
+			"'VERSION' must include build metadata (e.g. '4.3-stable+fork.1')",
+		UsageText: "gdenv link [OPTIONS] <VERSION> <PATH>",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "forcibly overwrite an existing cache entry",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			if c.NArg() < 2 { //nolint:mnd
+				return UsageError{ctx: c, err: ErrLinkUsageMissingArgs}
+			}
+
+			if c.NArg() > 2 { //nolint:mnd
+				return UsageError{ctx: c, err: ErrLinkUsageTooManyArgs}
+			}
+
+			v, err := version.Parse(c.Args().First())
+			if err != nil {
+				return UsageError{ctx: c, err: err}
+			}
+
+			if !v.IsCustom() {
+				return UsageError{ctx: c, err: fmt.Errorf("%w: %s", install.ErrNotCustom, v)}
+			}
+
+			// Define the host 'Platform'.
+			p, err := platform.Detect()
+			if err != nil {
+				return err
+			}
+
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			return install.Link(c.Context, storePath, executable.New(v, p), c.Args().Get(1), c.Bool("force"))
+		},
+	}
+}
@@ -3,11 +3,14 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/charmbracelet/log"
 	"github.com/urfave/cli/v2"
 
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
 	"github.com/coffeebeats/gdenv/pkg/godot/platform"
 	"github.com/coffeebeats/gdenv/pkg/pin"
@@ -25,7 +28,7 @@ func NewLs() *cli.Command {
 		Aliases: []string{"list"},
 
 		Usage:     "print the path and version of all of the installed versions of Godot",
-		UsageText: "gdenv ls",
+		UsageText: "gdenv ls [OPTIONS]",
 
 		Flags: []cli.Flag{
 			newVerboseFlag(),
@@ -35,6 +38,11 @@ func NewLs() *cli.Command {
 				Aliases: []string{"a"},
 				Usage:   "list executable and source code versions",
 			},
+			&cli.BoolFlag{
+				Name:    "long",
+				Aliases: []string{"l"},
+				Usage:   "include install time, last-used time, size, and download source",
+			},
 			&cli.BoolFlag{
 				Name:    "source",
 				Aliases: []string{"s", "src"},
@@ -43,14 +51,14 @@ func NewLs() *cli.Command {
 		},
 
 		Action: func(c *cli.Context) error {
-			storePath, err := touchStore()
+			storePath, err := touchStore(c.Context)
 			if err != nil {
 				return err
 			}
 
 			log.Debugf("using store at path: %s", storePath)
 
-			src, all := c.Bool("source"), c.Bool("all")
+			src, all, long := c.Bool("source"), c.Bool("all"), c.Bool("long")
 
 			if !src {
 				ok, err := printGlobalVersion(c.Context, storePath)
@@ -64,13 +72,13 @@ func NewLs() *cli.Command {
 			}
 
 			if src || all {
-				if err := printSources(c.Context, storePath); err != nil {
+				if err := printSources(c.Context, storePath, long); err != nil {
 					return err
 				}
 			}
 
 			if !src || all {
-				if err := printExecutables(c.Context, storePath); err != nil {
+				if err := printExecutables(c.Context, storePath, long); err != nil {
 					return err
 				}
 			}
@@ -88,7 +96,7 @@ func printGlobalVersion(ctx context.Context, storePath string) (bool, error) {
 		return false, err
 	}
 
-	v, err := pin.VersionAt(ctx, storePath, wd)
+	c, err := pin.ConstraintAt(ctx, storePath, wd)
 	if err != nil {
 		if !errors.Is(err, pin.ErrMissingPin) {
 			return false, err
@@ -97,6 +105,18 @@ func printGlobalVersion(ctx context.Context, storePath string) (bool, error) {
 		return false, nil
 	}
 
+	v, err := pin.Resolve(ctx, storePath, c)
+	if err != nil {
+		if !errors.Is(err, pin.ErrNoMatch) {
+			return false, err
+		}
+
+		log.Printf("🌎 System default version: %s", c)
+		log.Warn("no installed version matches system default version")
+
+		return true, nil
+	}
+
 	// Define the host 'Platform'.
 	p, err := platform.Detect()
 	if err != nil {
@@ -124,7 +144,7 @@ func printGlobalVersion(ctx context.Context, storePath string) (bool, error) {
 
 /* ----------------------- Function: PrintExecutables ----------------------- */
 
-func printExecutables(ctx context.Context, storePath string) error {
+func printExecutables(ctx context.Context, storePath string, long bool) error {
 	executables, err := store.Executables(ctx, storePath)
 	if err != nil {
 		return err
@@ -142,7 +162,18 @@ func printExecutables(ctx context.Context, storePath string) error {
 			return err
 		}
 
-		log.Printf("  %s (%s)", ex.Artifact.Version(), platformLabel)
+		layerLabel, err := formatLayer(storePath, ex.Artifact)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("  %s (%s)%s", ex.Artifact.Version(), platformLabel, layerLabel)
+
+		if long {
+			if err := printMetadata(storePath, ex.Artifact); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -150,7 +181,7 @@ func printExecutables(ctx context.Context, storePath string) error {
 
 /* ------------------------- Function: PrintSources ------------------------- */
 
-func printSources(ctx context.Context, storePath string) error {
+func printSources(ctx context.Context, storePath string, long bool) error {
 	sources, err := store.Sources(ctx, storePath)
 	if err != nil {
 		return err
@@ -163,7 +194,100 @@ func printSources(ctx context.Context, storePath string) error {
 	log.Printf("Installed source code versions (%s):", storePath)
 
 	for _, src := range sources {
-		log.Printf("  %s", src.Artifact.Inner.Version())
+		layerLabel, err := formatLayer(storePath, src.Artifact.Inner)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("  %s%s", src.Artifact.Inner.Version(), layerLabel)
+
+		if long {
+			if err := printMetadata(storePath, src.Artifact); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+/* -------------------------- Function: formatLayer ------------------------- */
+
+// formatLayer returns a label naming the store in which the artifact is
+// installed. This is empty unless multiple stores are searched (see
+// 'store.Layers').
+func formatLayer(storePath string, a artifact.Artifact) (string, error) {
+	layers, err := store.Layers()
+	if err != nil || len(layers) <= 1 {
+		return "", nil //nolint:nilerr
+	}
+
+	layer, err := store.Layer(storePath, a)
+	if err != nil {
+		return "", err
+	}
+
+	return formatStore(storePath, layer), nil
+}
+
+/* -------------------------- Function: formatStore ------------------------- */
+
+// formatStore returns a label naming the store at 'layer'. Stores other than
+// the one at 'storePath' are labeled as another store or, if they can't be
+// written to, as read-only.
+func formatStore(storePath, layer string) string {
+	switch {
+	case layer == storePath:
+		return fmt.Sprintf(" [%s]", layer)
+	case store.Writable(layer) != nil:
+		return fmt.Sprintf(" [%s; read-only]", layer)
+	default:
+		return fmt.Sprintf(" [%s; other store]", layer)
+	}
+}
+
+/* ------------------------- Function: printMetadata ------------------------ */
+
+// printMetadata prints the recorded metadata for an installed artifact.
+func printMetadata(storePath string, a artifact.Artifact) error {
+	m, err := store.MetadataOf(storePath, a)
+	if err != nil {
+		if !errors.Is(err, store.ErrMissingMetadata) {
+			return err
+		}
+
+		log.Print("    (no metadata recorded)")
+
+		return nil
+	}
+
+	installed := "unknown"
+	if !m.Installed.IsZero() {
+		installed = m.Installed.Local().Format(time.DateTime)
+	}
+
+	lastUsed := "never"
+	if !m.LastUsed.IsZero() {
+		lastUsed = m.LastUsed.Local().Format(time.DateTime)
+	}
+
+	log.Printf("    installed: %s", installed)
+	log.Printf("    last used: %s", lastUsed)
+
+	if m.DiskSize > 0 {
+		log.Printf("    size:      %s", formatBytes(m.DiskSize))
+	}
+
+	if m.Mirror != "" {
+		log.Printf("    mirror:    %s", m.Mirror)
+	}
+
+	if m.URL != "" {
+		log.Printf("    url:       %s", m.URL)
+	}
+
+	if m.Checksum != "" {
+		log.Printf("    archive:   %s (sha512: %s)", formatBytes(m.Size), m.Checksum)
 	}
 
 	return nil
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+)
+
+/* ---------------------------- Test: formatStore --------------------------- */
+
+func TestFormatStore(t *testing.T) {
+	tests := []struct {
+		name     string
+		layer    string
+		readOnly bool
+
+		want string
+	}{
+		{name: "store in use isn't labeled", layer: "home", want: " [%s]"},
+		{name: "writable store is labeled as another store", layer: "shared", want: " [%s; other store]"},
+		{name: "read-only store is labeled as read-only", layer: "shared", readOnly: true, want: " [%s; read-only]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.readOnly && os.Geteuid() == 0 {
+				t.Skip("file permissions aren't enforced for the root user")
+			}
+
+			tmp := t.TempDir()
+			layer := filepath.Join(tmp, tc.layer)
+
+			// Given: The store exists on the file system.
+			fstest.Dir{Path: tc.layer}.Write(t, tmp)
+
+			if tc.readOnly {
+				if err := os.Chmod(layer, 0o500); err != nil {
+					t.Fatalf("test setup: %v", err)
+				}
+
+				t.Cleanup(func() { os.Chmod(layer, 0o700) })
+			}
+
+			// When: The store is labeled.
+			got := formatStore(filepath.Join(tmp, "home"), layer)
+
+			// Then: The expected label is returned.
+			if want := fmt.Sprintf(tc.want, layer); got != want {
+				t.Errorf("output: got %q, want %q", got, want)
+			}
+		})
+	}
+}
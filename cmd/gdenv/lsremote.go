@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/pkg/catalog"
+)
+
+var ErrLsRemoteUsageChannel = errors.New("unrecognized release channel")
+
+// releaseChannels is the list of release channels which can be selected.
+//
+//nolint:gochecknoglobals
+var releaseChannels = []string{
+	catalog.ChannelStable,
+	catalog.ChannelRC,
+	catalog.ChannelBeta,
+	catalog.ChannelAlpha,
+	catalog.ChannelDev,
+}
+
+/* ------------------------- Function: NewLsRemote -------------------------- */
+
+// A 'urfave/cli' command to print available versions of Godot.
+func NewLsRemote() *cli.Command {
+	return &cli.Command{
+		Name:     "ls-remote",
+		Category: "Utilities",
+
+		Usage:     "print all of the versions of Godot available to install",
+		UsageText: "gdenv ls-remote [OPTIONS] [VERSION]",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+			newOfflineFlag(),
+
+			&cli.StringFlag{
+				Name:    "channel",
+				Aliases: []string{"c"},
+				Usage:   "only list releases from the specified `CHANNEL` (one of: " + strings.Join(releaseChannels, ", ") + ")",
+			},
+			&cli.BoolFlag{
+				Name:    "mono",
+				Aliases: []string{"m"},
+				Usage:   "list 'mono' (i.e. C#) versions",
+			},
+			&cli.BoolFlag{
+				Name:    "refresh",
+				Aliases: []string{"r"},
+				Usage:   "ignore the cached list of releases and fetch the latest",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			channel := strings.ToLower(c.String("channel"))
+			if channel != "" && !slices.Contains(releaseChannels, channel) {
+				return UsageError{ctx: c, err: fmt.Errorf("%w: %s", ErrLsRemoteUsageChannel, channel)}
+			}
+
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			ttl := catalog.DefaultTTL
+			if c.Bool("refresh") {
+				ttl = 0
+			}
+
+			releases, err := catalog.Load(c.Context, storePath, ttl)
+			if err != nil {
+				return err
+			}
+
+			versions, err := releases.Versions(catalog.Filter{
+				Version: c.Args().First(),
+				Channel: channel,
+				Mono:    c.Bool("mono"),
+			})
+			if err != nil {
+				return UsageError{ctx: c, err: err}
+			}
+
+			for _, v := range versions {
+				log.Print(v)
+			}
+
+			return nil
+		},
+	}
+}
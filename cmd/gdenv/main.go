@@ -3,13 +3,18 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"os"
+	"os/exec"
 	"os/signal"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/pkg/download"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
 )
 
 const (
@@ -44,6 +49,7 @@ func main() { //nolint:funlen
 		UseShortOptionHandling: true,
 
 		Flags: []cli.Flag{
+			newOfflineFlag(),
 			newVerboseFlag(),
 		},
 
@@ -51,17 +57,26 @@ func main() { //nolint:funlen
 			/* -------------------------------- Pin/Unpin ------------------------------- */
 
 			NewPin(),
+			NewPins(),
 			NewUnpin(),
 
 			/* ---------------------------- Install/Uninstall --------------------------- */
 
+			NewBundle(),
+			NewCache(),
 			NewInstall(),
+			NewLink(),
+			NewPrune(),
 			NewUninstall(),
 			NewVendor(),
+			NewVerify(),
 
 			/* --------------------------------- Utility -------------------------------- */
 
+			NewDu(),
+			NewExec(),
 			NewLs(),
+			NewLsRemote(),
 			NewWhich(),
 		},
 	}
@@ -94,6 +109,14 @@ func main() { //nolint:funlen
 	}
 
 	if err := app.RunContext(ctx, os.Args); err != nil {
+		// Propagate the exit code of a child process (see 'gdenv exec').
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+
+			return
+		}
+
 		var usageErr UsageError
 		if errors.As(err, &usageErr) {
 			usageErr.PrintUsage()
@@ -214,3 +237,58 @@ func newVerboseFlag() *cli.BoolFlag {
 		},
 	}
 }
+
+/* -------------------------------------------------------------------------- */
+/*                          Function: newOfflineFlag                          */
+/* -------------------------------------------------------------------------- */
+
+// newOfflineFlag creates a new standardized offline flag which prevents network
+// requests. Artifacts can then only be installed from local mirrors.
+func newOfflineFlag() *cli.BoolFlag {
+	return &cli.BoolFlag{
+		Name:               "offline",
+		Usage:              "disable network access; only install from local mirrors",
+		EnvVars:            []string{download.EnvOffline},
+		DisableDefaultText: true,
+
+		Action: func(_ *cli.Context, isOffline bool) error {
+			if !isOffline {
+				return nil
+			}
+
+			// NOTE: Offline mode is read from the environment by the 'download'
+			// package, so propagate the flag via the environment.
+			return os.Setenv(download.EnvOffline, "1")
+		},
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                       Function: newPrintResolvedFlag                       */
+/* -------------------------------------------------------------------------- */
+
+// newPrintResolvedFlag creates a new standardized flag which prints the exact
+// version a 'VERSION' argument resolved to (see 'printResolved').
+func newPrintResolvedFlag() *cli.BoolFlag {
+	return &cli.BoolFlag{
+		Name:               "print-resolved",
+		Usage:              "print the exact version that 'VERSION' resolved to",
+		DisableDefaultText: true,
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Function: printResolved                           */
+/* -------------------------------------------------------------------------- */
+
+// printResolved writes the resolved version to standard output if the
+// '--print-resolved' flag is set, so that scripts can capture it.
+func printResolved(c *cli.Context, v version.Version) error {
+	if !c.Bool("print-resolved") {
+		return nil
+	}
+
+	_, err := fmt.Fprintln(c.App.Writer, v)
+
+	return err
+}
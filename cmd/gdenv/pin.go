@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -10,11 +11,13 @@ import (
 
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
 	"github.com/coffeebeats/gdenv/pkg/pin"
+	"github.com/coffeebeats/gdenv/pkg/store"
 )
 
 var (
-	ErrPinUsageForceAndInstall = errors.New("cannot specify '-f/--force' without '-i/--install'")
-	ErrPinUsageGlobalAndPath   = errors.New("cannot specify both '-g/--global' and '-p/--path'")
+	ErrPinUsageForceAndInstall   = errors.New("cannot specify '-f/--force' without '-i/--install'")
+	ErrPinUsageGlobalAndPath     = errors.New("cannot specify both '-g/--global' and '-p/--path'")
+	ErrPinUsageVersionAndProject = errors.New("cannot specify both 'VERSION' and '--from-project'")
 )
 
 /* ---------------------------- Function: NewPin ---------------------------- */
@@ -25,11 +28,14 @@ func NewPin() *cli.Command { //nolint:funlen
 		Name:     "pin",
 		Category: "Pin",
 
-		Usage:     "set the Godot version globally or for a specific directory",
+		Usage: "set the Godot version globally or for a specific directory; with '--from-project' " +
+			"the version declared by the Godot project's 'project.godot' file is pinned",
 		UsageText: "gdenv pin [OPTIONS] <VERSION>",
 
 		Flags: []cli.Flag{
 			newVerboseFlag(),
+			newOfflineFlag(),
+			newPrintResolvedFlag(),
 
 			&cli.BoolFlag{
 				Name:    "global",
@@ -46,6 +52,11 @@ func NewPin() *cli.Command { //nolint:funlen
 				Aliases: []string{"f"},
 				Usage:   "forcibly overwrite an existing cache entry (only used with '-i')",
 			},
+			&cli.BoolFlag{
+				Name: "from-project",
+				Usage: "pin the newest release of the Godot version declared by 'project.godot' " +
+					"in '-p' or '$PWD' (or an ancestor directory) instead of 'VERSION'",
+			},
 			&cli.StringFlag{
 				Name:    "path",
 				Aliases: []string{"p"},
@@ -63,26 +74,43 @@ func NewPin() *cli.Command { //nolint:funlen
 				return UsageError{ctx: c, err: ErrPinUsageForceAndInstall}
 			}
 
-			// Validate arguments
-			v, err := version.Parse(c.Args().First())
-			if err != nil {
-				return UsageError{ctx: c, err: err}
+			if c.Bool("from-project") && c.Args().Present() {
+				return UsageError{ctx: c, err: ErrPinUsageVersionAndProject}
 			}
 
-			storePath, err := touchStore()
+			storePath, err := touchStore(c.Context)
 			if err != nil {
 				return err
 			}
 
 			log.Debugf("using store at path: %s", storePath)
 
+			// NOTE: Version selectors (e.g. 'latest') are resolved against the
+			// available releases so that the pin records a concrete version.
+			var v version.Version
+
+			switch {
+			case c.Bool("from-project"):
+				v, err = resolveProjectVersion(c, storePath)
+			default:
+				v, err = resolveVersionArg(c, storePath, c.Args().First(), resolveRelease)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if err := printResolved(c, v); err != nil {
+				return err
+			}
+
 			// Determine 'path' option
 			pinPath, err := resolvePath(c)
 			if err != nil {
 				return err
 			}
 
-			if err := writePin(storePath, pinPath, v); err != nil {
+			if err := writePin(c.Context, storePath, pinPath, v); err != nil {
 				return err
 			}
 
@@ -103,7 +131,16 @@ func resolvePath(c *cli.Context) (string, error) {
 	case c.IsSet("path"):
 		return filepath.Clean(c.String("path")), nil
 	case c.Bool("global"):
-		return touchStore()
+		storePath, err := touchStore(c.Context)
+		if err != nil {
+			return "", err
+		}
+
+		if err := store.Writable(storePath); err != nil {
+			return "", err
+		}
+
+		return storePath, nil
 	default:
 		p, err := os.Getwd()
 		if err != nil {
@@ -114,19 +151,53 @@ func resolvePath(c *cli.Context) (string, error) {
 	}
 }
 
+/* --------------------- Function: resolveProjectVersion -------------------- */
+
+// resolveProjectVersion resolves the Godot version declared by the project at
+// the '-p' path (or the current working directory) to the newest available
+// release (see 'pin.ConstraintFromProject').
+func resolveProjectVersion(c *cli.Context, storePath string) (version.Version, error) {
+	path := c.String("path")
+	if path == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return version.Version{}, err
+		}
+
+		path = wd
+	}
+
+	constraint, err := pin.ConstraintFromProject(c.Context, path)
+	if err != nil {
+		return version.Version{}, err
+	}
+
+	v, err := resolveRelease(c.Context, storePath, constraint)
+	if err != nil {
+		return version.Version{}, err
+	}
+
+	log.Infof("resolved project's Godot version to: %s", v)
+
+	return v, nil
+}
+
 /* --------------------------- Function: writePin --------------------------- */
 
-// Writes the specified version to a pin file.
-func writePin(storePath, pinPath string, v version.Version) error {
+// Writes the specified version to a pin file. Pinned directories other than the
+// store (i.e. the global pin) are recorded in the store's pin registry.
+func writePin(ctx context.Context, storePath, pinPath string, v version.Version) error {
 	if err := pin.Write(v, pinPath); err != nil {
 		return err
 	}
 
 	if pinPath == storePath {
 		log.Infof("set system default version: %s", v)
-	} else {
-		log.Infof("pinned '%s' to version: %s", pinPath, v)
+
+		return nil
 	}
 
-	return nil
+	log.Infof("pinned '%s' to version: %s", pinPath, v)
+
+	return store.RegisterPin(ctx, storePath, pinPath)
 }
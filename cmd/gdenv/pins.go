@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/charmbracelet/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/pin"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* ---------------------------- Function: NewPins --------------------------- */
+
+// A 'urfave/cli' command to list the directories in which a version is pinned.
+func NewPins() *cli.Command {
+	return &cli.Command{
+		Name:     "pins",
+		Category: "Pin",
+
+		Usage: "print the directories pinned with 'gdenv pin' or used with the 'godot' shim, " +
+			"along with the version each resolves to",
+		UsageText: "gdenv pins [OPTIONS]",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+
+			&cli.BoolFlag{
+				Name:    "clean",
+				Aliases: []string{"c"},
+				Usage:   "forget directories whose pin file no longer exists",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			paths, err := store.Pins(storePath)
+			if err != nil {
+				return err
+			}
+
+			if len(paths) == 0 {
+				log.Info("no pinned directories found")
+
+				return nil
+			}
+
+			// Define the host 'Platform'.
+			p, err := platform.Detect()
+			if err != nil {
+				return err
+			}
+
+			log.Printf("Pinned directories (%s):", storePath)
+
+			for _, path := range paths {
+				stale, err := printPin(c.Context, storePath, p, path)
+				if err != nil {
+					return err
+				}
+
+				if !stale || !c.Bool("clean") {
+					continue
+				}
+
+				if err := store.UnregisterPin(c.Context, storePath, path); err != nil {
+					return err
+				}
+
+				log.Debugf("forgot stale pinned directory: %s", path)
+			}
+
+			return nil
+		},
+	}
+}
+
+/* --------------------------- Function: printPin --------------------------- */
+
+// printPin prints the version pinned in the specified directory along with the
+// installed version it resolves to. Returns whether the pin file is missing.
+func printPin(ctx context.Context, storePath string, p platform.Platform, path string) (bool, error) {
+	c, err := pin.ReadConstraint(path)
+	if err != nil {
+		if !errors.Is(err, pin.ErrMissingPin) {
+			log.Printf("  %s: invalid pin (%s)", path, err)
+
+			return false, nil //nolint:nilerr
+		}
+
+		log.Printf("  %s: missing pin file (stale)", path)
+
+		return true, nil
+	}
+
+	v, err := pin.Resolve(ctx, storePath, c)
+	if err != nil {
+		if !errors.Is(err, pin.ErrNoMatch) {
+			return false, err
+		}
+
+		log.Printf("  %s: %s (no installed version matches)", path, c)
+
+		return false, nil
+	}
+
+	status := "installed"
+
+	ok, err := store.Has(storePath, executable.New(v, p))
+	if err != nil {
+		return false, err
+	}
+
+	if !ok {
+		status = "not installed"
+	}
+
+	if _, exact := c.Exact(); exact {
+		log.Printf("  %s: %s (%s)", path, v, status)
+	} else {
+		log.Printf("  %s: %s (resolves to %s; %s)", path, c, v, status)
+	}
+
+	return false, nil
+}
+
+/* ------------------------- Function: pinLocations ------------------------- */
+
+// pinLocations returns the global pin and all registered pinned directories,
+// mapped to the version each resolves to. Stale or invalid pins, as well as
+// version constraints which don't match an installed version, are omitted.
+func pinLocations(ctx context.Context, storePath string) (map[string]version.Version, error) {
+	paths, err := store.Pins(storePath)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]version.Version, len(paths)+1)
+
+	for _, path := range append(paths, storePath) {
+		c, err := pin.ReadConstraint(path)
+		if err != nil {
+			log.Debugf("skipping pin at path: %s: %v", path, err)
+
+			continue
+		}
+
+		v, err := pin.Resolve(ctx, storePath, c)
+		if err != nil {
+			if !errors.Is(err, pin.ErrNoMatch) {
+				return nil, err
+			}
+
+			continue
+		}
+
+		out[path] = v
+	}
+
+	return out, nil
+}
+
+/* -------------------------- Function: warnPinned -------------------------- */
+
+// warnPinned logs a warning for each of the pin locations (see 'pinLocations')
+// at which the specified version is still pinned.
+func warnPinned(storePath string, pins map[string]version.Version, v version.Version) {
+	for _, path := range slices.Sorted(maps.Keys(pins)) {
+		if pins[path] != v {
+			continue
+		}
+
+		if path == storePath {
+			log.Warnf("version %s is the system default version", v)
+		} else {
+			log.Warnf("version %s is pinned at path: %s", v, path)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/x/term"
+
+	"github.com/coffeebeats/gdenv/pkg/download"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/archive"
+	"github.com/coffeebeats/gdenv/pkg/progress"
+)
+
+const (
+	progressBarWidth       = 24
+	progressIntervalTTY    = 100 * time.Millisecond
+	progressIntervalNonTTY = 5 * time.Second
+)
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: withProgress                           */
+/* -------------------------------------------------------------------------- */
+
+// withProgress attaches progress reporters to the context for both downloading
+// and extracting artifacts of type 'T'. Progress is rendered to 'os.Stderr'
+// until the returned function is called. If 'os.Stderr' is not a terminal, then
+// progress is periodically logged instead.
+func withProgress[T artifact.Artifact](ctx context.Context) (context.Context, func()) {
+	pDownload, pExtract := new(progress.Progress), new(progress.Progress)
+
+	ctx = download.WithProgress[T](ctx, pDownload)
+	ctx = archive.WithProgress(ctx, pExtract)
+
+	r := &progressRenderer{ //nolint:exhaustruct
+		bars: []*progressBar{
+			{label: "downloading", progress: pDownload}, //nolint:exhaustruct
+			{label: "extracting", progress: pExtract},   //nolint:exhaustruct
+		},
+		out: os.Stderr,
+		tty: isTerminal(os.Stderr),
+	}
+
+	// Log lines must be interleaved with the rendered progress bar.
+	if r.tty {
+		log.SetOutput(r)
+	}
+
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		r.run(done)
+	}()
+
+	return ctx, func() {
+		close(done)
+		wg.Wait()
+
+		if r.tty {
+			log.SetOutput(os.Stderr)
+		}
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Struct: progressRenderer                          */
+/* -------------------------------------------------------------------------- */
+
+// progressRenderer draws a set of progress bars to a terminal. It also
+// implements 'io.Writer' so that log output can be written without clobbering
+// the current progress bar.
+type progressRenderer struct {
+	bars []*progressBar
+	out  *os.File
+	tty  bool
+
+	mu   sync.Mutex
+	line string // the currently-drawn line; only used if 'tty' is set.
+}
+
+/* ------------------------------- Method: run ------------------------------ */
+
+// run renders progress until the 'done' channel is closed.
+func (r *progressRenderer) run(done <-chan struct{}) {
+	interval := progressIntervalNonTTY
+	if r.tty {
+		interval = progressIntervalTTY
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			r.render(time.Now(), true)
+
+			return
+		case now := <-ticker.C:
+			r.render(now, false)
+		}
+	}
+}
+
+/* ----------------------------- Method: render ----------------------------- */
+
+// render draws the current state of all active progress bars. If 'final' is
+// set then any in-progress bar is completed.
+func (r *progressRenderer) render(now time.Time, final bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, b := range r.bars {
+		if b.done || b.progress.Total() == 0 {
+			continue
+		}
+
+		if b.started.IsZero() {
+			b.started = now
+		}
+
+		complete := b.progress.Current() >= b.progress.Total()
+		if complete || final {
+			b.done = true
+		}
+
+		switch {
+		case r.tty && b.done:
+			r.draw(b.format(now, true) + "\n")
+			r.line = ""
+		case r.tty:
+			r.line = b.format(now, true)
+			r.draw(r.line)
+		case b.done || now.Sub(b.logged) >= progressIntervalNonTTY:
+			b.logged = now
+			log.Info(b.format(now, false))
+		}
+	}
+}
+
+/* ------------------------------ Method: draw ------------------------------ */
+
+// draw clears the current line and writes the provided text in its place.
+//
+// NOTE: The caller must hold the lock.
+func (r *progressRenderer) draw(s string) {
+	fmt.Fprint(r.out, "\r\033[K"+s)
+}
+
+/* ------------------------------- Impl: Write ------------------------------ */
+
+// Write clears the current progress bar, writes 'p', and then redraws the
+// progress bar below the written content.
+func (r *progressRenderer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.line == "" {
+		return r.out.Write(p)
+	}
+
+	r.draw("")
+
+	n, err := r.out.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	r.draw(r.line)
+
+	return n, nil
+}
+
+/* ------------------------------- Impl: Read ------------------------------- */
+
+// Read delegates to the underlying file. This is required so that the logger
+// recognizes the renderer as a terminal (and thus retains colored output).
+func (r *progressRenderer) Read(p []byte) (int, error) {
+	return r.out.Read(p)
+}
+
+/* -------------------------------- Impl: Fd -------------------------------- */
+
+// Fd returns the file descriptor of the underlying file.
+func (r *progressRenderer) Fd() uintptr {
+	return r.out.Fd()
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Struct: progressBar                            */
+/* -------------------------------------------------------------------------- */
+
+// progressBar tracks the rendering state of a single 'progress.Progress'.
+type progressBar struct {
+	label    string
+	progress *progress.Progress
+
+	started, logged time.Time
+	done            bool
+}
+
+/* ----------------------------- Method: format ----------------------------- */
+
+// format returns a description of the progress, including the amount of bytes
+// transferred, percentage, throughput, and estimated time remaining. If 'bar'
+// is set then a graphical bar is included.
+func (b *progressBar) format(now time.Time, bar bool) string {
+	current, total := b.progress.Current(), b.progress.Total()
+	pct := min(b.progress.Percentage(), 1)
+
+	var out strings.Builder
+
+	out.WriteString(b.label)
+
+	if bar {
+		filled := int(math.Round(pct * progressBarWidth))
+
+		out.WriteString(" [")
+		out.WriteString(strings.Repeat("=", filled))
+		out.WriteString(strings.Repeat(" ", progressBarWidth-filled))
+		out.WriteString("]")
+	}
+
+	fmt.Fprintf(&out, " %.0f%% %s/%s", pct*100, formatBytes(int64(current)), formatBytes(int64(total))) //nolint:gosec,mnd
+
+	elapsed := now.Sub(b.started).Seconds()
+	if elapsed <= 0 || current == 0 {
+		return out.String()
+	}
+
+	rate := float64(current) / elapsed
+
+	fmt.Fprintf(&out, " %s/s", formatBytes(int64(rate)))
+
+	if current < total {
+		eta := time.Duration(float64(total-current) / rate * float64(time.Second))
+		fmt.Fprintf(&out, " ETA %s", eta.Round(time.Second))
+	}
+
+	return out.String()
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Function: formatBytes                           */
+/* -------------------------------------------------------------------------- */
+
+// formatBytes returns a human-readable representation of a number of bytes.
+func formatBytes(n int64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Function: isTerminal                            */
+/* -------------------------------------------------------------------------- */
+
+// isTerminal returns whether the provided file is a terminal. Other character
+// devices (e.g. '/dev/null') aren't terminals.
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(f.Fd())
+}
+
+// Compile-time verification that 'progressRenderer' implements 'io.Writer'.
+var _ io.Writer = (*progressRenderer)(nil)
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/pkg/progress"
+)
+
+/* --------------------------- Test: formatBytes ---------------------------- */
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{n: 0, want: "0 B"},
+		{n: 1023, want: "1023 B"},
+		{n: 1024, want: "1.0 KiB"},
+		{n: 1536, want: "1.5 KiB"},
+		{n: 1 << 20, want: "1.0 MiB"},
+		{n: 5 << 30, want: "5.0 GiB"},
+		{n: 1 << 40, want: "1.0 TiB"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			// When: The number of bytes is formatted.
+			got := formatBytes(tc.n)
+
+			// Then: The expected human-readable size is returned.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ------------------------ Test: progressBar.format ------------------------ */
+
+func TestProgressBarFormat(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		current uint64
+		elapsed time.Duration
+		bar     bool
+
+		want string
+	}{
+		{
+			name: "no progress omits throughput",
+			bar:  true,
+
+			want: "downloading [                        ] 0% 0 B/100 B",
+		},
+		{
+			name:    "partial progress includes throughput and time remaining",
+			current: 50,
+			elapsed: 10 * time.Second,
+			bar:     true,
+
+			want: "downloading [============            ] 50% 50 B/100 B 5 B/s ETA 10s",
+		},
+		{
+			name:    "completed progress omits time remaining",
+			current: 100,
+			elapsed: 10 * time.Second,
+			bar:     true,
+
+			want: "downloading [========================] 100% 100 B/100 B 10 B/s",
+		},
+		{
+			name:    "graphical bar is omitted when not requested",
+			current: 50,
+			elapsed: 10 * time.Second,
+
+			want: "downloading 50% 50 B/100 B 5 B/s ETA 10s",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: A progress bar with the specified progress.
+			b := newProgressBar(t, 100, tc.current)
+			b.started = now.Add(-tc.elapsed)
+
+			// When: The progress is formatted.
+			got := b.format(now, tc.bar)
+
+			// Then: The expected description is returned.
+			if got != tc.want {
+				t.Errorf("output: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ------------------- Test: progressRenderer.render (log) ------------------ */
+
+func TestProgressRendererRenderWithoutTerminal(t *testing.T) {
+	var buf bytes.Buffer
+
+	// Given: Log output is captured.
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	// Given: A renderer which doesn't write to a terminal.
+	b := newProgressBar(t, 100, 50)
+	r := &progressRenderer{bars: []*progressBar{b}, out: nil, tty: false, mu: sync.Mutex{}, line: ""}
+
+	start := time.Now()
+
+	// When: Progress is rendered for the first time.
+	r.render(start, false)
+
+	// Then: The progress is logged as a line without a graphical bar.
+	if got := buf.String(); !strings.Contains(got, "downloading 50% 50 B/100 B") || strings.Contains(got, "[") {
+		t.Errorf("output: got %q, want a log line without a progress bar", got)
+	}
+
+	buf.Reset()
+
+	// When: Progress is rendered again before the logging interval elapses.
+	r.render(start.Add(time.Second), false)
+
+	// Then: Nothing is logged.
+	if got := buf.String(); got != "" {
+		t.Errorf("output: got %q, want %q", got, "")
+	}
+
+	// When: The progress completes.
+	b.progress.Add(50)
+	r.render(start.Add(2*time.Second), false)
+
+	// Then: The completed progress is logged once.
+	if got := buf.String(); !strings.Contains(got, "downloading 100% 100 B/100 B") {
+		t.Errorf("output: got %q, want a completed log line", got)
+	}
+
+	buf.Reset()
+
+	// When: Progress is rendered after completing.
+	r.render(start.Add(time.Minute), true)
+
+	// Then: Nothing more is logged.
+	if got := buf.String(); got != "" {
+		t.Errorf("output: got %q, want %q", got, "")
+	}
+}
+
+/* ---------------------------- Test: isTerminal ---------------------------- */
+
+func TestIsTerminal(t *testing.T) {
+	// Given: A character device which isn't a terminal.
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	defer devNull.Close()
+
+	// Given: A regular file.
+	f, err := os.Create(filepath.Join(t.TempDir(), "file"))
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	defer f.Close()
+
+	for _, f := range []*os.File{devNull, f} {
+		// When: The file is checked.
+		got := isTerminal(f)
+
+		// Then: The file isn't considered a terminal.
+		if got {
+			t.Errorf("output: %s: got %v, want %v", f.Name(), got, false)
+		}
+	}
+}
+
+/* ------------------------ Function: newProgressBar ------------------------ */
+
+// newProgressBar returns a 'progressBar' labeled "downloading" which reports
+// the specified progress.
+func newProgressBar(t *testing.T, total, current uint64) *progressBar {
+	t.Helper()
+
+	p, err := progress.NewWithTotal(total)
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	p.Add(current)
+
+	return &progressBar{label: "downloading", progress: p, started: time.Time{}, logged: time.Time{}, done: false}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/internal/units"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/pin"
+	"github.com/coffeebeats/gdenv/pkg/prune"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+var (
+	ErrPruneUsageMissingPolicy = errors.New("missing removal policy; specify '-k/--keep', '--max-size', and/or '--unused-for'")
+	ErrPruneUsageNegativeKeep  = errors.New("'-k/--keep' must not be negative")
+)
+
+// A 'urfave/cli' command to remove unneeded versions of Godot from the store.
+func NewPrune() *cli.Command { //nolint:funlen
+	return &cli.Command{
+		Name:     "prune",
+		Category: "Install",
+
+		Usage: "remove installed versions of Godot which aren't pinned or recently used; " +
+			"at least one of '--keep', '--max-size', or '--unused-for' is required",
+		UsageText: "gdenv prune [OPTIONS]",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"n"},
+				Usage:   "print the versions which would be removed without removing them",
+			},
+			&cli.IntFlag{
+				Name:    "keep",
+				Aliases: []string{"k"},
+				Usage:   "keep the 'N' most recently used versions",
+			},
+			&cli.StringFlag{
+				Name:  "max-size",
+				Usage: "remove the least recently used versions until the store is at most 'SIZE' (e.g. '20GiB')",
+			},
+			&cli.StringSliceFlag{
+				Name:    "pin",
+				Aliases: []string{"p"},
+				Usage:   "keep the version pinned at 'PATH' (may be repeated; the global pin and registered pins are always kept)",
+			},
+			&cli.StringFlag{
+				Name:  "unused-for",
+				Usage: "remove versions which haven't been used for 'DURATION' (e.g. '30d' or '12h')",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			policy, err := parsePrunePolicy(c)
+			if err != nil {
+				return UsageError{ctx: c, err: err}
+			}
+
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			policy.Keep, err = pinnedVersions(c.Context, storePath, c.StringSlice("pin"))
+			if err != nil {
+				return err
+			}
+
+			candidates, err := pruneCandidates(c.Context, storePath)
+			if err != nil {
+				return err
+			}
+
+			remove := prune.Plan(candidates, policy, time.Now())
+			if len(remove) == 0 {
+				log.Info("no versions to remove")
+
+				return nil
+			}
+
+			return pruneArtifacts(c.Context, storePath, remove, c.Bool("dry-run"))
+		},
+	}
+}
+
+/* ------------------------ Function: parsePrunePolicy ---------------------- */
+
+// parsePrunePolicy parses the removal policy from the command's flags. At
+// least one policy flag must be set so that a bare 'gdenv prune' never removes
+// every unpinned version.
+func parsePrunePolicy(c *cli.Context) (prune.Policy, error) {
+	if !c.IsSet("keep") && !c.IsSet("max-size") && !c.IsSet("unused-for") {
+		return prune.Policy{}, ErrPruneUsageMissingPolicy
+	}
+
+	policy := prune.Policy{
+		Keep:       nil,
+		KeepRecent: c.Int("keep"),
+		MaxUnused:  0,
+		MaxSize:    0,
+	}
+
+	if policy.KeepRecent < 0 {
+		return prune.Policy{}, ErrPruneUsageNegativeKeep
+	}
+
+	if c.IsSet("unused-for") {
+		d, err := units.ParseDuration(c.String("unused-for"))
+		if err != nil {
+			return prune.Policy{}, err
+		}
+
+		policy.MaxUnused = d
+	}
+
+	if c.IsSet("max-size") {
+		size, err := units.ParseSize(c.String("max-size"))
+		if err != nil {
+			return prune.Policy{}, err
+		}
+
+		policy.MaxSize = size
+	}
+
+	return policy, nil
+}
+
+/* ------------------------- Function: pinnedVersions ----------------------- */
+
+// pinnedVersions returns the installed versions which are pinned globally, by
+// any registered project (see 'pinLocations'), or at any of the specified
+// paths. Version constraints are resolved to the installed version which would
+// be used.
+func pinnedVersions(ctx context.Context, storePath string, paths []string) ([]version.Version, error) {
+	pins, err := pinLocations(ctx, storePath)
+	if err != nil {
+		return nil, err
+	}
+
+	out := slices.Collect(maps.Values(pins))
+
+	for _, path := range paths {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := pin.VersionAt(ctx, storePath, path)
+		if err != nil {
+			if errors.Is(err, pin.ErrMissingPin) || errors.Is(err, pin.ErrNoMatch) {
+				log.Warnf("no installed version pinned at path: %s", path)
+
+				continue
+			}
+
+			return nil, err
+		}
+
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+/* ------------------------ Function: pruneCandidates ----------------------- */
+
+// pruneCandidates returns all executable and source code versions installed in
+// the store along with their sizes and last-used times. Versions installed in
+// other stores (see 'store.Layers') are excluded.
+func pruneCandidates(ctx context.Context, storePath string) ([]prune.Candidate, error) {
+	artifacts, err := listInstalled(ctx, storePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]prune.Candidate, 0, len(artifacts))
+
+	for _, a := range artifacts {
+		// Versions installed in other stores can't be removed.
+		if layer, err := store.Layer(storePath, a); err != nil || layer != storePath {
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		size, err := store.Size(ctx, storePath, a)
+		if err != nil {
+			return nil, err
+		}
+
+		var lastUsed time.Time
+
+		m, err := store.MetadataOf(storePath, a)
+		if err != nil && !errors.Is(err, store.ErrMissingMetadata) {
+			return nil, err
+		}
+
+		switch {
+		case !m.LastUsed.IsZero():
+			lastUsed = m.LastUsed
+		case !m.Installed.IsZero():
+			lastUsed = m.Installed
+		}
+
+		out = append(out, prune.Candidate{Artifact: a, Size: size, LastUsed: lastUsed})
+	}
+
+	return out, nil
+}
+
+/* ------------------------- Function: pruneArtifacts ----------------------- */
+
+// pruneArtifacts removes the specified artifacts from the store, reporting the
+// amount of space freed. If 'dryRun' is set, then nothing is removed.
+func pruneArtifacts(ctx context.Context, storePath string, remove []prune.Candidate, dryRun bool) error {
+	var freed int64
+
+	for _, c := range remove {
+		label, err := describeArtifact(c.Artifact)
+		if err != nil {
+			return err
+		}
+
+		lastUsed := "never"
+		if !c.LastUsed.IsZero() {
+			lastUsed = c.LastUsed.Local().Format(time.DateTime)
+		}
+
+		if dryRun {
+			log.Printf("would remove: %s (%s; last used: %s)", label, formatBytes(c.Size), lastUsed)
+		} else {
+			log.Infof("removing version: %s (%s; last used: %s)", label, formatBytes(c.Size), lastUsed)
+
+			if err := uninstallArtifact(ctx, storePath, c.Artifact); err != nil {
+				return err
+			}
+		}
+
+		freed += c.Size
+	}
+
+	summary := fmt.Sprintf("%d version(s) (%s)", len(remove), formatBytes(freed))
+
+	if dryRun {
+		log.Printf("would free: %s", summary)
+
+		return nil
+	}
+
+	log.Infof("freed: %s", summary)
+
+	return nil
+}
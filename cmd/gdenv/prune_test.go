@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/prune"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* ------------------------ Test: parsePrunePolicy -------------------------- */
+
+func TestParsePrunePolicy(t *testing.T) {
+	tests := []struct {
+		args string
+
+		want prune.Policy
+		err  error
+	}{
+		{args: "", err: ErrPruneUsageMissingPolicy},
+		{args: "--dry-run", err: ErrPruneUsageMissingPolicy},
+		{args: "--pin a", err: ErrPruneUsageMissingPolicy},
+		{args: "--keep -1", err: ErrPruneUsageNegativeKeep},
+		{args: "--keep 0", want: prune.Policy{Keep: nil, KeepRecent: 0, MaxUnused: 0, MaxSize: 0}},
+		{args: "--keep 2", want: prune.Policy{Keep: nil, KeepRecent: 2, MaxUnused: 0, MaxSize: 0}},
+		{args: "--max-size 1KiB", want: prune.Policy{Keep: nil, KeepRecent: 0, MaxUnused: 0, MaxSize: 1 << 10}},
+		{args: "--unused-for 12h", want: prune.Policy{Keep: nil, KeepRecent: 0, MaxUnused: 12 * time.Hour, MaxSize: 0}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.args, func(t *testing.T) {
+			// Given: The command's flags are parsed from the arguments.
+			set := flag.NewFlagSet("prune", flag.ContinueOnError)
+
+			for _, f := range NewPrune().Flags {
+				if err := f.Apply(set); err != nil {
+					t.Fatalf("test setup: %v", err)
+				}
+			}
+
+			if err := set.Parse(strings.Fields(tc.args)); err != nil {
+				t.Fatalf("test setup: %v", err)
+			}
+
+			// When: The removal policy is parsed.
+			got, err := parsePrunePolicy(cli.NewContext(nil, set, nil))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected policy is returned.
+			if got.KeepRecent != tc.want.KeepRecent || got.MaxUnused != tc.want.MaxUnused || got.MaxSize != tc.want.MaxSize {
+				t.Errorf("output: got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ------------------------- Test: pinnedVersions --------------------------- */
+
+func TestPinnedVersions(t *testing.T) {
+	installed := []fstest.Writer{
+		fstest.File{Path: ".gdenv/editor/v4.1-stable/linux.x86_64/Godot_v4.1-stable_linux.x86_64"},
+		fstest.File{Path: ".gdenv/editor/v4.2.1-stable/linux.x86_64/Godot_v4.2.1-stable_linux.x86_64"},
+		fstest.File{Path: ".gdenv/editor/v4.3-stable/linux.x86_64/Godot_v4.3-stable_linux.x86_64"},
+	}
+
+	tests := []struct {
+		name     string
+		files    []fstest.Writer
+		register []string
+		paths    []string
+
+		want []string
+	}{
+		{
+			name: "global pin is kept",
+			files: []fstest.Writer{
+				fstest.File{Path: ".gdenv/.godot-version", Contents: "4.1"},
+			},
+
+			want: []string{"4.1"},
+		},
+		{
+			name: "registered pins are kept",
+			files: []fstest.Writer{
+				fstest.File{Path: "a/.godot-version", Contents: "4.2.1"},
+				fstest.File{Path: "b/.godot-version", Contents: "~4.3"},
+			},
+			register: []string{"a", "b"},
+
+			want: []string{"4.2.1", "4.3"},
+		},
+		{
+			name: "specified paths are kept",
+			files: []fstest.Writer{
+				fstest.File{Path: ".gdenv/.godot-version", Contents: "4.1"},
+				fstest.File{Path: "a/.godot-version", Contents: "4.2.1"},
+			},
+			paths: []string{"a"},
+
+			want: []string{"4.1", "4.2.1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			storePath := filepath.Join(tmp, ".gdenv")
+
+			// Given: The specified executables are installed.
+			for _, f := range installed {
+				f.Write(t, tmp)
+			}
+
+			// Given: The specified pin files exist.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// Given: The specified pinned directories are registered.
+			for _, path := range tc.register {
+				if err := store.RegisterPin(context.Background(), storePath, filepath.Join(tmp, path)); err != nil {
+					t.Fatalf("test setup: %v", err)
+				}
+			}
+
+			paths := make([]string, 0, len(tc.paths))
+			for _, path := range tc.paths {
+				paths = append(paths, filepath.Join(tmp, path))
+			}
+
+			// When: The pinned versions are determined.
+			got, err := pinnedVersions(context.Background(), storePath, paths)
+
+			// Then: No error is returned.
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Then: The expected versions are kept.
+			want := make([]version.Version, 0, len(tc.want))
+			for _, s := range tc.want {
+				want = append(want, version.MustParse(s))
+			}
+
+			version.Sort(got)
+
+			if !slices.Equal(got, want) {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+		})
+	}
+}
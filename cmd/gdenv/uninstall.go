@@ -2,34 +2,86 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/log"
 	"github.com/urfave/cli/v2"
 
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
 	"github.com/coffeebeats/gdenv/pkg/godot/platform"
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/prune"
+	"github.com/coffeebeats/gdenv/pkg/selector"
 	"github.com/coffeebeats/gdenv/pkg/store"
 )
 
+var (
+	ErrUninstallOtherStore           = errors.New("cannot uninstall a version installed in another store")
+	ErrUninstallUsageChannel         = errors.New("unrecognized release channel")
+	ErrUninstallUsageMonoAndNoMono   = errors.New("cannot specify both '-m/--mono' and '--no-mono'")
+	ErrUninstallUsagePlatformAndSrc  = errors.New("cannot specify both '--platform' and '-s/--source'")
+	ErrUninstallUsageVersionAndMatch = errors.New("cannot specify 'VERSION' with '-a/--all' or version selectors")
+)
+
 // A 'urfave/cli' command to delete a cached version of Godot.
-func NewUninstall() *cli.Command {
+func NewUninstall() *cli.Command { //nolint:funlen
 	return &cli.Command{
 		Name:     "uninstall",
 		Category: "Install",
 
-		Usage:     "Remove the specified version of Godot from the 'gdenv' download cache",
+		Usage: "Remove the specified version of Godot from the 'gdenv' download cache; " +
+			"without 'VERSION' all installed versions matching the selectors are removed",
 		UsageText: "gdenv uninstall [OPTIONS] [VERSION]",
 
 		Flags: []cli.Flag{
 			newVerboseFlag(),
+			newPrintResolvedFlag(),
 
 			&cli.BoolFlag{
 				Name:    "all",
 				Aliases: []string{"a"},
 				Usage:   "uninstall all versions of Godot (ignores source code without '-s')",
 			},
+			&cli.StringSliceFlag{
+				Name:    "channel",
+				Aliases: []string{"c"},
+				Usage: "only uninstall versions from the specified `CHANNEL` (one of: " +
+					strings.Join(releaseChannels, ", ") + "; may be repeated)",
+			},
+			&cli.BoolFlag{
+				Name:    "dry-run",
+				Aliases: []string{"n"},
+				Usage:   "print the versions which would be removed without removing them",
+			},
+			&cli.BoolFlag{
+				Name:  "except-pinned",
+				Usage: "keep versions which are pinned globally or in a directory listed by 'gdenv pins'",
+			},
+			&cli.BoolFlag{
+				Name:    "mono",
+				Aliases: []string{"m"},
+				Usage:   "only uninstall 'mono' (i.e. C#) versions",
+			},
+			&cli.BoolFlag{
+				Name:  "no-mono",
+				Usage: "only uninstall non-'mono' versions",
+			},
+			&cli.StringSliceFlag{
+				Name:  "platform",
+				Usage: "only uninstall executables for the specified `PLATFORM` (e.g. 'linux.x86_64'; may be repeated)",
+			},
+			&cli.StringFlag{
+				Name:    "range",
+				Aliases: []string{"r"},
+				Usage: "only uninstall versions within `RANGE`, either a version prefix (e.g. '3' or '4.2') " +
+					"or a version range (e.g. '>=4.0 <4.2' or '~4.2')",
+			},
 			&cli.BoolFlag{
 				Name:    "source",
 				Aliases: []string{"s", "src"},
@@ -38,88 +90,303 @@ func NewUninstall() *cli.Command {
 		},
 
 		Action: func(c *cli.Context) error {
-			storePath, err := touchStore()
+			sel, err := parseUninstallSelector(c)
 			if err != nil {
-				return err
+				return UsageError{ctx: c, err: err}
 			}
 
-			log.Debugf("using store at path: %s", storePath)
+			src, dryRun, exceptPinned := c.Bool("source"), c.Bool("dry-run"), c.Bool("except-pinned")
 
-			src, all := c.Bool("source"), c.Bool("all")
+			// Uninstall all matching versions.
+			if c.Bool("all") || exceptPinned || !sel.IsZero() {
+				if c.Args().Present() {
+					return UsageError{ctx: c, err: ErrUninstallUsageVersionAndMatch}
+				}
 
-			// Uninstall all versions.
-			switch {
-			case src && all:
-				return uninstallAllSources(c.Context, storePath)
-			case !src && all:
-				return uninstallAllExecutables(c.Context, storePath)
+				storePath, err := touchStore(c.Context)
+				if err != nil {
+					return err
+				}
+
+				log.Debugf("using store at path: %s", storePath)
+
+				return uninstallSelected(c.Context, storePath, sel, src, exceptPinned, dryRun)
 			}
 
 			// Uninstall a specific version.
 
-			// Validate arguments
-			v, err := version.Parse(c.Args().First())
-			if err != nil && !c.Bool("all") {
-				return UsageError{ctx: c, err: err}
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
 			}
 
-			log.Infof("uninstalling version: %s", v)
+			log.Debugf("using store at path: %s", storePath)
+
+			resolve := resolveInstalledExecutable
+			if src {
+				resolve = resolveInstalledSource
+			}
+
+			v, err := resolveVersionArg(c, storePath, c.Args().First(), resolve)
+			if err != nil {
+				return err
+			}
+
+			if err := printResolved(c, v); err != nil {
+				return err
+			}
 
 			switch {
 			case src:
-				return store.Remove(storePath, source.New(v))
+				return uninstallVersion(c.Context, storePath, source.New(v), dryRun)
 			default:
-				return uninstallExecutable(storePath, v)
+				// Define the host 'Platform'.
+				p, err := platform.Detect()
+				if err != nil {
+					return err
+				}
+
+				return uninstallVersion(c.Context, storePath, executable.New(v, p), dryRun)
 			}
 		},
 	}
 }
 
-/* -------------------- Function: uninstallAllExecutables ------------------- */
+/* --------------------- Function: parseUninstallSelector ------------------- */
+
+// parseUninstallSelector parses the 'selector.Selector' specified by the
+// command's flags.
+func parseUninstallSelector(c *cli.Context) (selector.Selector, error) {
+	sel := selector.Selector{Version: nil, Channels: nil, Mono: nil, Platforms: nil}
+
+	if c.IsSet("range") {
+		constraint, err := selector.ParseVersion(c.String("range"))
+		if err != nil {
+			return selector.Selector{}, err
+		}
+
+		sel.Version = &constraint
+	}
+
+	for _, channel := range c.StringSlice("channel") {
+		channel = strings.ToLower(channel)
+		if !slices.Contains(releaseChannels, channel) {
+			return selector.Selector{}, fmt.Errorf("%w: %s", ErrUninstallUsageChannel, channel)
+		}
+
+		sel.Channels = append(sel.Channels, channel)
+	}
+
+	switch mono, noMono := c.Bool("mono"), c.Bool("no-mono"); {
+	case mono && noMono:
+		return selector.Selector{}, ErrUninstallUsageMonoAndNoMono
+	case mono || noMono:
+		sel.Mono = &mono
+	}
 
-func uninstallAllExecutables(ctx context.Context, storePath string) error {
-	ee, err := store.Executables(ctx, storePath)
+	if c.IsSet("platform") && c.Bool("source") {
+		return selector.Selector{}, ErrUninstallUsagePlatformAndSrc
+	}
+
+	for _, arg := range c.StringSlice("platform") {
+		p, err := platform.Parse(arg)
+		if err != nil {
+			return selector.Selector{}, err
+		}
+
+		sel.Platforms = append(sel.Platforms, p)
+	}
+
+	return sel, nil
+}
+
+/* ----------------------- Function: uninstallSelected ---------------------- */
+
+// uninstallSelected removes all installed executable (or, if 'src' is set,
+// source code) versions matching the selector. If 'exceptPinned' is set, then
+// pinned versions are kept. If 'dryRun' is set, then nothing is removed.
+func uninstallSelected(
+	ctx context.Context,
+	storePath string,
+	sel selector.Selector,
+	src, exceptPinned, dryRun bool,
+) error {
+	candidates, err := pruneCandidates(ctx, storePath)
 	if err != nil {
 		return err
 	}
 
-	if len(ee) == 0 {
+	var pinned []version.Version
+
+	if exceptPinned {
+		pins, err := pinLocations(ctx, storePath)
+		if err != nil {
+			return err
+		}
+
+		pinned = slices.Collect(maps.Values(pins))
+	}
+
+	total, selected := 0, make([]prune.Candidate, 0, len(candidates))
+
+	for _, c := range candidates {
+		if _, ok := c.Artifact.(source.Source); ok != src {
+			continue
+		}
+
+		total++
+
+		if !sel.Matches(c.Artifact) {
+			continue
+		}
+
+		if slices.Contains(pinned, c.Artifact.Version()) {
+			log.Debugf("keeping pinned version: %s", c.Artifact.Version())
+
+			continue
+		}
+
+		selected = append(selected, c)
+	}
+
+	if len(selected) == 0 {
+		log.Info("no installed versions match")
+
 		return nil
 	}
 
-	log.Info("removing all installed executable versions")
+	// Remove all versions of the artifact kind at once if all are selected.
+	if !dryRun && len(selected) == total && sel.IsZero() && !exceptPinned {
+		return uninstallAll(ctx, storePath, selected, src)
+	}
+
+	return pruneArtifacts(ctx, storePath, selected, dryRun)
+}
+
+/* -------------------------- Function: uninstallAll ------------------------ */
+
+// uninstallAll removes all installed executable (or, if 'src' is set, source
+// code) versions from the store, reporting the amount of space freed. Other
+// kinds of artifacts are kept.
+func uninstallAll(ctx context.Context, storePath string, remove []prune.Candidate, src bool) error {
+	pins, err := pinLocations(ctx, storePath)
+	if err != nil {
+		return err
+	}
+
+	var freed int64
+
+	for _, c := range remove {
+		label, err := describeArtifact(c.Artifact)
+		if err != nil {
+			return err
+		}
+
+		if !src {
+			warnPinned(storePath, pins, c.Artifact.Version())
+		}
+
+		log.Infof("removing version: %s (%s)", label, formatBytes(c.Size))
+
+		freed += c.Size
+	}
+
+	clearFn := store.ClearExecutables
+	if src {
+		clearFn = store.ClearSources
+	}
+
+	if err := clearStore(ctx, storePath, clearFn); err != nil {
+		return err
+	}
+
+	log.Infof("freed: %d version(s) (%s)", len(remove), formatBytes(freed))
 
-	return store.Clear(storePath)
+	return nil
 }
 
-/* ---------------------- Function: uninstallAllSources --------------------- */
+/* ------------------------ Function: uninstallVersion ---------------------- */
+
+// uninstallVersion removes the specified artifact from the store, warning if
+// it's a pinned executable version. If 'dryRun' is set, then nothing is
+// removed.
+func uninstallVersion(ctx context.Context, storePath string, a artifact.Artifact, dryRun bool) error {
+	label, err := describeArtifact(a)
+	if err != nil {
+		return err
+	}
+
+	ok, err := store.Has(storePath, a)
+	if err != nil {
+		return err
+	}
 
-func uninstallAllSources(ctx context.Context, storePath string) error {
-	ss, err := store.Sources(ctx, storePath)
+	layer, err := store.Layer(storePath, a)
 	if err != nil {
 		return err
 	}
 
-	if len(ss) == 0 {
+	if _, isExecutable := a.(executable.Executable); isExecutable && ok && layer == storePath {
+		pins, err := pinLocations(ctx, storePath)
+		if err != nil {
+			return err
+		}
+
+		warnPinned(storePath, pins, a.Version())
+	}
+
+	if dryRun {
+		if ok && layer == storePath {
+			log.Printf("would remove: %s", label)
+		}
+
 		return nil
 	}
 
-	log.Info("removing all installed source code versions")
+	log.Infof("uninstalling version: %s", label)
+
+	return uninstallArtifact(ctx, storePath, a)
+}
+
+/* ----------------------- Function: uninstallArtifact ---------------------- */
+
+// uninstallArtifact removes the artifact from the store while holding its lock.
+// Artifacts installed in other stores (see 'store.Layers') can't be removed.
+func uninstallArtifact(ctx context.Context, storePath string, a artifact.Artifact) (err error) {
+	layer, err := store.Layer(storePath, a)
+	if err != nil {
+		return err
+	}
+
+	if layer != storePath {
+		return fmt.Errorf("%w: %s", ErrUninstallOtherStore, layer)
+	}
+
+	unlock, err := store.LockArtifact(ctx, storePath, a)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
 
-	return store.Clear(storePath)
+	return store.Remove(storePath, a)
 }
 
-/* ---------------------- Function: uninstallExecutable --------------------- */
+/* --------------------------- Function: clearStore ------------------------- */
 
-func uninstallExecutable(storePath string, v version.Version) error {
-	// Define the host 'Platform'.
-	p, err := platform.Detect()
+// clearStore removes cached artifacts from the store using 'clearFn' (e.g.
+// 'store.ClearExecutables') while holding the store lock.
+func clearStore(ctx context.Context, storePath string, clearFn func(string) error) (err error) {
+	unlock, err := store.Lock(ctx, storePath)
 	if err != nil {
 		return err
 	}
 
-	// Define the target 'Executable'.
-	ex := executable.New(v, p)
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
 
-	return store.Remove(storePath, ex)
+	return clearFn(storePath)
 }
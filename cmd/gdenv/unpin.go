@@ -46,32 +46,35 @@ func NewUnpin() *cli.Command {
 				return err
 			}
 
-			// Exit early if the pin doesn't exist.
-			if _, err := pin.Read(pinPath); err != nil {
+			// Determine the store path.
+			storePath, err := store.Path()
+			if err != nil {
+				return err
+			}
+
+			// Exit early if the pin doesn't exist, forgetting any stale
+			// registration of the directory.
+			if _, err := pin.ReadConstraint(pinPath); err != nil {
 				if !errors.Is(err, pin.ErrMissingPin) {
 					return err
 				}
 
-				return nil
+				return store.UnregisterPin(c.Context, storePath, pinPath)
 			}
 
 			if err := pin.Remove(pinPath); err != nil {
 				return err
 			}
 
-			// Determine the store path.
-			storePath, err := store.Path()
-			if err != nil {
-				return err
-			}
-
 			if pinPath == storePath {
 				log.Info("unset system default version")
-			} else {
-				log.Infof("removed version pin from path: %s", pinPath)
+
+				return nil
 			}
 
-			return nil
+			log.Infof("removed version pin from path: %s", pinPath)
+
+			return store.UnregisterPin(c.Context, storePath, pinPath)
 		},
 	}
 }
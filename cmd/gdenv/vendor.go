@@ -4,6 +4,7 @@ import (
 	"github.com/charmbracelet/log"
 	"github.com/urfave/cli/v2"
 
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
 	"github.com/coffeebeats/gdenv/pkg/install"
 )
 
@@ -19,6 +20,7 @@ func NewVendor() *cli.Command {
 
 		Flags: []cli.Flag{
 			newVerboseFlag(),
+			newOfflineFlag(),
 
 			&cli.BoolFlag{
 				Name:    "force",
@@ -39,19 +41,22 @@ func NewVendor() *cli.Command {
 		},
 
 		Action: func(c *cli.Context) error {
-			v, err := resolveVersionFromInput(c)
+			v, err := resolveVersionFromInput(c, resolveRelease)
 			if err != nil {
 				return err
 			}
 
-			storePath, err := touchStore()
+			storePath, err := touchStore(c.Context)
 			if err != nil {
 				return err
 			}
 
 			log.Debugf("using store at path: %s", storePath)
 
-			return install.Vendor(c.Context, storePath, v, c.String("out"), c.Bool("force"))
+			ctx, stop := withProgress[source.Archive](c.Context)
+			defer stop()
+
+			return install.Vendor(ctx, storePath, v, c.String("out"), c.Bool("force"))
 		},
 	}
 }
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/charmbracelet/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/install"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+var (
+	ErrVerifyFailed           = errors.New("verification failed")
+	ErrVerifyUsageAllAndVer   = errors.New("cannot specify both '-a/--all' and 'VERSION'")
+	ErrVerifyVersionNotFound  = errors.New("version not installed")
+	errVerifyArtifactModified = errors.New("installed files differ from manifest")
+)
+
+// A 'urfave/cli' command to check installed versions of Godot for modification.
+func NewVerify() *cli.Command { //nolint:funlen
+	return &cli.Command{
+		Name:     "verify",
+		Category: "Install",
+
+		Usage: "check that the installed files of a version of Godot are unmodified; " +
+			"if 'VERSION' is omitted then the version is resolved using '$PWD'",
+		UsageText: "gdenv verify [OPTIONS] [VERSION]",
+
+		Flags: []cli.Flag{
+			newVerboseFlag(),
+			newOfflineFlag(),
+
+			&cli.BoolFlag{
+				Name:    "all",
+				Aliases: []string{"a"},
+				Usage:   "verify all installed executable and source code versions (cannot be used with 'VERSION')",
+			},
+			&cli.BoolFlag{
+				Name:    "repair",
+				Aliases: []string{"r"},
+				Usage:   "reinstall any version which fails verification",
+			},
+			&cli.BoolFlag{
+				Name:    "source",
+				Aliases: []string{"s", "src"},
+				Usage:   "verify source code versions",
+			},
+		},
+
+		Action: func(c *cli.Context) error {
+			if c.Bool("all") && c.Args().Present() {
+				return UsageError{ctx: c, err: ErrVerifyUsageAllAndVer}
+			}
+
+			storePath, err := touchStore(c.Context)
+			if err != nil {
+				return err
+			}
+
+			log.Debugf("using store at path: %s", storePath)
+
+			var artifacts []artifact.Artifact
+
+			switch src := c.Bool("source"); {
+			case c.Bool("all"):
+				artifacts, err = listInstalled(c.Context, storePath, src)
+			default:
+				artifacts, err = resolveVerifyTarget(c, src)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+
+			for _, a := range artifacts {
+				if err := verifyArtifact(c.Context, storePath, a, c.Bool("repair")); err != nil {
+					if !errors.Is(err, errVerifyArtifactModified) && !errors.Is(err, store.ErrMissingManifest) {
+						return err
+					}
+
+					failed++
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%w: %d of %d versions", ErrVerifyFailed, failed, len(artifacts))
+			}
+
+			return nil
+		},
+	}
+}
+
+/* ----------------------- Function: resolveVerifyTarget -------------------- */
+
+// resolveVerifyTarget returns the artifact to verify based on the 'VERSION'
+// argument (or the pinned version) and whether source code was requested.
+func resolveVerifyTarget(c *cli.Context, src bool) ([]artifact.Artifact, error) {
+	resolve := resolveInstalledExecutable
+	if src {
+		resolve = resolveInstalledSource
+	}
+
+	v, err := resolveVersionFromInput(c, resolve)
+	if err != nil {
+		return nil, err
+	}
+
+	if src {
+		return []artifact.Artifact{source.New(v)}, nil
+	}
+
+	// Define the host 'Platform'.
+	p, err := platform.Detect()
+	if err != nil {
+		return nil, err
+	}
+
+	return []artifact.Artifact{executable.New(v, p)}, nil
+}
+
+/* -------------------------- Function: listInstalled ----------------------- */
+
+// listInstalled returns all installed executables and source code versions. If
+// 'src' is set, then only source code versions are returned.
+func listInstalled(ctx context.Context, storePath string, src bool) ([]artifact.Artifact, error) {
+	out := make([]artifact.Artifact, 0)
+
+	if !src {
+		ee, err := store.Executables(ctx, storePath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ex := range ee {
+			out = append(out, ex.Artifact)
+		}
+	}
+
+	ss, err := store.Sources(ctx, storePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range ss {
+		out = append(out, s.Artifact.Inner)
+	}
+
+	return out, nil
+}
+
+/* ------------------------- Function: verifyArtifact ----------------------- */
+
+// verifyArtifact checks the installed files of the artifact against its store
+// manifest, reporting any differences. If 'repair' is set, then an artifact
+// which fails verification is reinstalled.
+func verifyArtifact(ctx context.Context, storePath string, a artifact.Artifact, repair bool) error {
+	label, err := describeArtifact(a)
+	if err != nil {
+		return err
+	}
+
+	drift, err := verifyWithLock(ctx, storePath, a)
+	if err != nil {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return fmt.Errorf("%w: %s", ErrVerifyVersionNotFound, label)
+		case errors.Is(err, store.ErrMissingManifest):
+			log.Warnf("cannot verify version; no manifest recorded: %s", label)
+		default:
+			return err
+		}
+	}
+
+	if err == nil && len(drift) == 0 {
+		log.Infof("verified version: %s", label)
+
+		return nil
+	}
+
+	for _, d := range drift {
+		log.Errorf("%s: %s", label, d)
+	}
+
+	if err == nil {
+		err = fmt.Errorf("%w: %s", errVerifyArtifactModified, label)
+	}
+
+	if !repair {
+		return err
+	}
+
+	layer, lerr := store.Layer(storePath, a)
+	if lerr != nil {
+		return errors.Join(err, lerr)
+	}
+
+	if layer != storePath {
+		log.Errorf("cannot repair version installed in another store: %s", layer)
+
+		return err
+	}
+
+	log.Infof("repairing version: %s", label)
+
+	switch a := a.(type) {
+	case executable.Executable:
+		ctx, stop := withProgress[executable.Archive](ctx)
+		defer stop()
+
+		return install.Executable(ctx, storePath, a, true)
+	case source.Source:
+		ctx, stop := withProgress[source.Archive](ctx)
+		defer stop()
+
+		return install.Source(ctx, storePath, a.Version(), true)
+	}
+
+	return err
+}
+
+/* ------------------------- Function: verifyWithLock ----------------------- */
+
+// verifyWithLock verifies the artifact's store entry while holding its lock.
+func verifyWithLock(ctx context.Context, storePath string, a artifact.Artifact) (drift []store.Drift, err error) {
+	unlock, err := store.LockArtifact(ctx, storePath, a)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	return store.Verify(ctx, storePath, a)
+}
+
+/* ------------------------ Function: describeArtifact ---------------------- */
+
+// describeArtifact returns a human-readable label for an installed artifact.
+func describeArtifact(a artifact.Artifact) (string, error) {
+	switch a := a.(type) {
+	case executable.Executable:
+		platformLabel, err := platform.Format(a.Platform(), a.Version())
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%s (%s)", a.Version(), platformLabel), nil
+	case source.Source:
+		return fmt.Sprintf("%s (source)", a.Version()), nil
+	}
+
+	return fmt.Sprintf("%T", a), nil
+}
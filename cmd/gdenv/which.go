@@ -1,21 +1,28 @@
 package main
 
 import (
+	"errors"
+
 	"github.com/charmbracelet/log"
 	"github.com/urfave/cli/v2"
 
 	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
 	"github.com/coffeebeats/gdenv/pkg/install"
+	"github.com/coffeebeats/gdenv/pkg/store"
 )
 
+var ErrWhichUsageVersionAndPath = errors.New("cannot specify both 'VERSION' and '-p/--path'")
+
 // A 'urfave/cli' command to print the path to the effective Godot binary.
 func NewWhich() *cli.Command {
 	return &cli.Command{
 		Name:     "which",
 		Category: "Utilities",
 
-		Usage:     "print the path to the Godot executable which would be used in the specified directory",
-		UsageText: "gdenv which [OPTIONS]",
+		Usage: "print the path to the Godot executable which would be used in the specified directory; " +
+			"if 'VERSION' is set then that version (or the newest installed version matching a selector) is used instead",
+		UsageText: "gdenv which [OPTIONS] [VERSION]",
 
 		Flags: []cli.Flag{
 			newVerboseFlag(),
@@ -28,6 +35,10 @@ func NewWhich() *cli.Command {
 		},
 
 		Action: func(c *cli.Context) error {
+			if c.Args().Present() && c.IsSet("path") {
+				return UsageError{ctx: c, err: ErrWhichUsageVersionAndPath}
+			}
+
 			// Determine 'path' option
 			pinPath, err := resolvePath(c)
 			if err != nil {
@@ -35,7 +46,7 @@ func NewWhich() *cli.Command {
 			}
 
 			// Determine the store path.
-			storePath, err := touchStore()
+			storePath, err := touchStore(c.Context)
 			if err != nil {
 				return err
 			}
@@ -48,7 +59,15 @@ func NewWhich() *cli.Command {
 				return err
 			}
 
-			path, err := install.Which(c.Context, storePath, p, pinPath)
+			var path string
+
+			switch {
+			case c.Args().Present():
+				path, err = whichVersion(c, storePath, p)
+			default:
+				path, err = install.Which(c.Context, storePath, p, pinPath)
+			}
+
 			if err != nil {
 				return err
 			}
@@ -61,3 +80,23 @@ func NewWhich() *cli.Command {
 		},
 	}
 }
+
+/* -------------------------- Function: whichVersion ------------------------ */
+
+// whichVersion returns the path to the cached Godot executable for the
+// 'VERSION' argument. A specific version (e.g. '4.2') is used exactly, while a
+// version selector (e.g. '4.2-latest' or 'latest') resolves to the newest
+// installed version matching it.
+func whichVersion(c *cli.Context, storePath string, p platform.Platform) (string, error) {
+	constraint, err := version.ParseConstraint(c.Args().First())
+	if err != nil {
+		return "", UsageError{ctx: c, err: err}
+	}
+
+	ex, err := install.ResolveExecutable(c.Context, storePath, p, constraint)
+	if err != nil {
+		return "", err
+	}
+
+	return store.Executable(storePath, ex)
+}
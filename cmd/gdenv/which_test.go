@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/install"
+)
+
+/* --------------------------- Test: whichVersion --------------------------- */
+
+func TestWhichVersion(t *testing.T) {
+	installed := []fstest.Writer{
+		fstest.File{Path: ".gdenv/editor/v4.2-stable/linux.x86_64/Godot_v4.2-stable_linux.x86_64"},
+		fstest.File{Path: ".gdenv/editor/v4.2.1-stable/linux.x86_64/Godot_v4.2.1-stable_linux.x86_64"},
+		fstest.File{Path: ".gdenv/editor/v4.2.1-beta1/linux.x86_64/Godot_v4.2.1-beta1_linux.x86_64"},
+		fstest.File{Path: ".gdenv/editor/v4.3-stable/linux.x86_64/Godot_v4.3-stable_linux.x86_64"},
+	}
+
+	tests := []struct {
+		input string
+
+		want string
+		err  error
+	}{
+		{input: "", err: version.ErrMissing},
+		{input: "4.1", err: install.ErrNotInstalled},
+		{input: "4.2", want: "4.2"},
+		{input: "4.2.0", want: "4.2"},
+		{input: "4.2-latest", want: "4.2.1"},
+		{input: "4.2.1", want: "4.2.1"},
+		{input: "4.2-stable", want: "4.2"},
+		{input: "4.2.1-beta1", want: "4.2.1-beta1"},
+		{input: "4", err: install.ErrNotInstalled},
+		{input: "4-latest", want: "4.3"},
+		{input: "latest", want: "4.3"},
+		{input: "~4.2", want: "4.2.1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			tmp := t.TempDir()
+			storePath := filepath.Join(tmp, ".gdenv")
+
+			t.Setenv("GDENV_PATH", "")
+			t.Setenv(version.EnvDefaultMono, "")
+
+			// Given: The specified executables are installed.
+			for _, f := range installed {
+				f.Write(t, tmp)
+			}
+
+			// When: The 'VERSION' argument is parsed and resolved.
+			var ex executable.Executable
+
+			c, err := version.ParseConstraint(tc.input)
+			if err == nil {
+				p := platform.Platform{OS: platform.Linux, Arch: platform.Amd64}
+				ex, err = install.ResolveExecutable(context.Background(), storePath, p, c)
+			}
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The specified version (or, for a selector, the newest
+			// installed version matching it) is used.
+			if err == nil && ex.Version() != version.MustParse(tc.want) {
+				t.Errorf("output: got %v, want %v", ex.Version(), tc.want)
+			}
+		})
+	}
+}
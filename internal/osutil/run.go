@@ -0,0 +1,22 @@
+//go:build !windows
+
+package osutil
+
+import (
+	"os"
+	"syscall"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                                Function: Run                               */
+/* -------------------------------------------------------------------------- */
+
+// Run replaces the current process with the specified binary. Because the
+// process is replaced, signals and the exit code are handled by the binary.
+func Run(binary string, args ...string) error {
+	return syscall.Exec( //nolint:gosec
+		binary,
+		append([]string{binary}, args...),
+		os.Environ(),
+	)
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package osutil
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                                Function: Run                               */
+/* -------------------------------------------------------------------------- */
+
+// Run executes the specified binary as a child process, connecting it to the
+// standard streams of the current process. A non-zero exit code is returned as
+// an '*exec.ExitError'.
+//
+// NOTE: Console interrupts are delivered to all processes attached to the
+// console, so the child receives them directly.
+func Run(binary string, args ...string) error {
+	cmd := exec.Command(binary, args...)
+
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+
+	return cmd.Run()
+}
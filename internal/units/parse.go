@@ -0,0 +1,102 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	hoursPerDay  = 24
+	hoursPerWeek = 7 * hoursPerDay
+)
+
+var (
+	ErrInvalidDuration = errors.New("invalid duration")
+	ErrInvalidSize     = errors.New("invalid size")
+)
+
+//nolint:gochecknoglobals
+var sizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1e3,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1e6,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1e9,
+	"gib": 1 << 30,
+	"t":   1 << 40,
+	"tb":  1e12,
+	"tib": 1 << 40,
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: ParseDuration                          */
+/* -------------------------------------------------------------------------- */
+
+// ParseDuration parses a duration string. In addition to the formats accepted
+// by 'time.ParseDuration', a whole number of days (e.g. '30d') or weeks (e.g.
+// '2w') is accepted.
+func ParseDuration(input string) (time.Duration, error) {
+	input = strings.TrimSpace(input)
+
+	for suffix, hours := range map[string]int{"d": hoursPerDay, "w": hoursPerWeek} {
+		n, ok := strings.CutSuffix(input, suffix)
+		if !ok {
+			continue
+		}
+
+		count, err := strconv.Atoi(n)
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidDuration, input)
+		}
+
+		return time.Duration(count*hours) * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(input)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidDuration, input)
+	}
+
+	return d, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: ParseSize                            */
+/* -------------------------------------------------------------------------- */
+
+// ParseSize parses a size in bytes with an optional unit suffix. Both decimal
+// (e.g. '500MB') and binary (e.g. '20GiB') units are accepted; a single letter
+// unit (e.g. '20G') is interpreted as a binary unit.
+func ParseSize(input string) (int64, error) {
+	input = strings.TrimSpace(input)
+
+	i := strings.IndexFunc(input, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(input)
+	}
+
+	number, unit := input[:i], strings.ToLower(strings.TrimSpace(input[i:]))
+
+	multiplier, ok := sizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("%w: unrecognized unit: %s", ErrInvalidSize, input)
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 || value*multiplier > math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidSize, input)
+	}
+
+	return int64(value * multiplier), nil
+}
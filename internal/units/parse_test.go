@@ -0,0 +1,98 @@
+package units
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+/* -------------------------- Test: ParseDuration --------------------------- */
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input string
+
+		want time.Duration
+		err  error
+	}{
+		// Invalid inputs
+		{input: "", err: ErrInvalidDuration},
+		{input: "abc", err: ErrInvalidDuration},
+		{input: "-1h", err: ErrInvalidDuration},
+		{input: "1.5d", err: ErrInvalidDuration},
+		{input: "-2w", err: ErrInvalidDuration},
+
+		// Valid inputs
+		{input: "0", want: 0},
+		{input: "12h", want: 12 * time.Hour},
+		{input: "90m", want: 90 * time.Minute},
+		{input: "30d", want: 30 * 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: " 1d ", want: 24 * time.Hour},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			// When: The input is parsed.
+			got, err := ParseDuration(tc.input)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected duration is returned.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ---------------------------- Test: ParseSize ----------------------------- */
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input string
+
+		want int64
+		err  error
+	}{
+		// Invalid inputs
+		{input: "", err: ErrInvalidSize},
+		{input: "GiB", err: ErrInvalidSize},
+		{input: "10XB", err: ErrInvalidSize},
+		{input: "-1G", err: ErrInvalidSize},
+		{input: "1.2.3", err: ErrInvalidSize},
+		{input: "10000000TiB", err: ErrInvalidSize},
+
+		// Valid inputs
+		{input: "0", want: 0},
+		{input: "100", want: 100},
+		{input: "100B", want: 100},
+		{input: "2k", want: 2048},
+		{input: "2KB", want: 2000},
+		{input: "1.5 MiB", want: 1572864},
+		{input: "20GiB", want: 20 << 30},
+		{input: "20G", want: 20 << 30},
+		{input: "3GB", want: 3e9},
+		{input: "1TB", want: 1e12},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			// When: The input is parsed.
+			got, err := ParseSize(tc.input)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected size is returned.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
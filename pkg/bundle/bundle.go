@@ -0,0 +1,158 @@
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/archive"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+const (
+	// formatVersion is the version of the bundle format written by 'Export'.
+	formatVersion = 1
+
+	// fileManifest is the name of the file, written at the root of a bundle,
+	// which describes the bundle's contents.
+	fileManifest = "bundle.json"
+
+	kindExecutable = "executable"
+	kindSource     = "source"
+)
+
+var (
+	ErrChecksumMismatch    = errors.New("checksum mismatch")
+	ErrInvalidBundle       = errors.New("invalid bundle")
+	ErrUnsupportedArtifact = errors.New("unsupported artifact")
+	ErrUnsupportedFile     = errors.New("unsupported file")
+)
+
+// Archive is the 'zip'-compressed archive in which a 'Bundle' is distributed.
+type Archive = archive.Zip[Bundle]
+
+/* -------------------------------------------------------------------------- */
+/*                               Struct: Bundle                               */
+/* -------------------------------------------------------------------------- */
+
+// Bundle is an 'Artifact' representing a portable collection of store entries.
+// A bundle allows installed artifacts to be copied to another store (e.g. on a
+// machine without network access) without re-downloading them.
+type Bundle struct{}
+
+// Compile-time verifications that 'Bundle' implements 'Artifact'.
+var _ artifact.Artifact = (*Bundle)(nil)
+
+/* ------------------------ Impl: archive.Archivable ------------------------ */
+
+// Allows 'Bundle' to be used by 'Archive' implementation.
+func (b Bundle) Archivable() {}
+
+/* ------------------------- Impl: artifact.Artifact ------------------------ */
+
+// Artifact "registers" 'Bundle' as an artifact.
+func (b Bundle) Artifact() {}
+
+/* -------------------------- Impl: artifact.Named -------------------------- */
+
+func (b Bundle) Name() string {
+	return "gdenv-bundle"
+}
+
+/* ------------------------ Impl: artifact.Versioned ------------------------ */
+
+// Version returns the zero 'Version'; a bundle may contain many versions.
+func (b Bundle) Version() version.Version {
+	return version.Version{}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: Manifest                              */
+/* -------------------------------------------------------------------------- */
+
+// Manifest describes the contents of a bundle.
+type Manifest struct {
+	// Format is the version of the bundle format.
+	Format int `json:"format"`
+	// Entries describes each store entry contained in the bundle.
+	Entries []Entry `json:"entries"`
+}
+
+// Entry describes a single store entry contained in a bundle.
+type Entry struct {
+	// Kind is the type of the artifact; either 'executable' or 'source'.
+	Kind string `json:"kind"`
+	// Version is the artifact's version.
+	Version string `json:"version"`
+	// Name is the name of the executable (e.g. 'Godot_v4.2.1-stable_linux.x86_64');
+	// empty for source code.
+	Name string `json:"name,omitempty"`
+	// Files describes each of the entry's files, including their checksums.
+	Files store.Manifest `json:"files"`
+	// Metadata is the entry's metadata as recorded by the exporting store.
+	Metadata store.Metadata `json:"metadata"`
+}
+
+/* ---------------------------- Function: newEntry -------------------------- */
+
+// newEntry creates an 'Entry' describing the specified artifact.
+func newEntry(a artifact.Artifact, files store.Manifest, m store.Metadata) (Entry, error) {
+	e := Entry{Kind: "", Version: "", Name: "", Files: files, Metadata: m}
+
+	switch a := a.(type) {
+	case executable.Executable:
+		e.Kind, e.Version, e.Name = kindExecutable, a.Version().String(), a.Name()
+	case source.Source:
+		e.Kind, e.Version = kindSource, a.Version().String()
+	case source.Archive:
+		e.Kind, e.Version = kindSource, a.Version().String()
+	default:
+		return Entry{}, fmt.Errorf("%w: %T", ErrUnsupportedArtifact, a)
+	}
+
+	return e, nil
+}
+
+/* ---------------------------- Method: artifact ---------------------------- */
+
+// artifact returns the 'Artifact' described by the bundle entry.
+func (e Entry) artifact() (artifact.Artifact, error) {
+	v, err := version.Parse(e.Version)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+
+	switch e.Kind {
+	case kindExecutable:
+		ex, err := executable.Parse(e.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+		}
+
+		if ex.Version() != v {
+			return nil, fmt.Errorf("%w: version mismatch: %s", ErrInvalidBundle, e.Name)
+		}
+
+		return ex, nil
+	case kindSource:
+		return source.New(v), nil
+	default:
+		return nil, fmt.Errorf("%w: unrecognized kind: %s", ErrInvalidBundle, e.Kind)
+	}
+}
+
+/* ------------------------------ Method: path ------------------------------ */
+
+// path returns the slash-separated directory within the bundle which contains
+// the entry's files.
+func (e Entry) path() string {
+	if e.Kind == kindExecutable {
+		return path.Join(e.Kind, e.Name)
+	}
+
+	return path.Join(e.Kind, e.Version)
+}
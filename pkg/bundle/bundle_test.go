@@ -0,0 +1,256 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* ----------------------------- Test: RoundTrip ---------------------------- */
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+
+	t.Setenv("GDENV_PATH", "")
+
+	src, dst := filepath.Join(tmp, "src"), filepath.Join(tmp, "dst")
+
+	ex := executable.MustParse("Godot_v4.0-stable_linux.x86_64")
+	s := source.New(ex.Version())
+
+	p := store.Provenance{Mirror: "GitHub", URL: "https://example.com", Checksum: "abc", Size: 1}
+
+	// Given: An executable and source code are installed in the source store.
+	for _, install := range []struct {
+		a        artifact.Artifact
+		name     string
+		contents string
+	}{
+		{a: ex, name: ex.Name(), contents: "godot"},
+		{a: s, name: source.Archive{Inner: s}.Name(), contents: "source"},
+	} {
+		fstest.File{Path: filepath.Join("files", install.name), Contents: install.contents}.Write(t, tmp)
+
+		local := artifact.Local[artifact.Artifact]{Artifact: install.a, Path: filepath.Join(tmp, "files", install.name)}
+		if err := store.Add(store.WithProvenance(ctx, p), src, local); err != nil {
+			t.Fatalf("test setup: %v", err)
+		}
+	}
+
+	// When: The artifacts are exported to a bundle.
+	out := filepath.Join(tmp, "bundle.zip")
+	if err := Export(ctx, src, []artifact.Artifact{ex, s}, out); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// When: The bundle is imported into an empty store.
+	imported, skipped, err := Import(ctx, dst, out)
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: All artifacts were imported.
+	if want := []artifact.Artifact{ex, s}; !reflect.DeepEqual(imported, want) || len(skipped) != 0 {
+		t.Errorf("output: got %v (skipped %v), want %v", imported, skipped, want)
+	}
+
+	// Then: The artifacts' files were added to the store.
+	for _, f := range []fstest.File{
+		{Path: filepath.Join("dst", "editor", "v4.0-stable", "linux.x86_64", ex.Name()), Contents: "godot"},
+		{Path: filepath.Join("dst", "src", "v4.0-stable", source.Archive{Inner: s}.Name()), Contents: "source"},
+	} {
+		f.Assert(t, tmp)
+	}
+
+	// Then: The artifacts' metadata was carried over.
+	for _, a := range []artifact.Artifact{ex, s} {
+		m, err := store.MetadataOf(dst, a)
+		if err != nil {
+			t.Fatalf("err: got %v, want %v", err, nil)
+		}
+
+		if m.Provenance != p {
+			t.Errorf("output: got %v, want %v", m.Provenance, p)
+		}
+
+		if drift, err := store.Verify(ctx, dst, a); err != nil || len(drift) != 0 {
+			t.Errorf("output: got %v (%v), want %v", drift, err, nil)
+		}
+	}
+
+	// When: The bundle is imported again.
+	imported, skipped, err = Import(ctx, dst, out)
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: All artifacts were skipped.
+	if want := []artifact.Artifact{ex, s}; !reflect.DeepEqual(skipped, want) || len(imported) != 0 {
+		t.Errorf("output: got %v (imported %v), want %v", skipped, imported, want)
+	}
+}
+
+/* ------------------------ Test: RoundTrip (symlinks) ---------------------- */
+
+func TestRoundTripWithSymlinks(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+
+	t.Setenv("GDENV_PATH", "")
+
+	src, dst := filepath.Join(tmp, "src"), filepath.Join(tmp, "dst")
+
+	ex := executable.MustParse("Godot_v4.0-stable_macos.universal")
+
+	// Given: An application bundle containing a symbolic link is installed.
+	fstest.File{Path: "files/Godot.app/Contents/MacOS/Godot", Contents: "godot"}.Write(t, tmp)
+
+	link := filepath.Join("Godot.app", "Contents", "Resources", "Godot")
+	fstest.Dir{Path: filepath.Join("files", filepath.Dir(link))}.Write(t, tmp)
+
+	if err := os.Symlink("../MacOS/Godot", filepath.Join(tmp, "files", link)); err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	local := artifact.Local[artifact.Artifact]{Artifact: ex, Path: filepath.Join(tmp, "files", "Godot.app")}
+	if err := store.Add(ctx, src, local); err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	// When: The executable is exported to a bundle and imported into an
+	// empty store.
+	out := filepath.Join(tmp, "bundle.zip")
+	if err := Export(ctx, src, []artifact.Artifact{ex}, out); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	if _, _, err := Import(ctx, dst, out); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: The symbolic link was recreated in the store.
+	pathEntry, err := store.Entry(dst, ex)
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	if got, err := os.Readlink(filepath.Join(pathEntry, link)); err != nil || got != "../MacOS/Godot" {
+		t.Errorf("output: got %v (%v), want %v", got, err, "../MacOS/Godot")
+	}
+
+	if drift, err := store.Verify(ctx, dst, ex); err != nil || len(drift) != 0 {
+		t.Errorf("output: got %v (%v), want %v", drift, err, nil)
+	}
+}
+
+/* ------------------------------- Test: Import ----------------------------- */
+
+func TestImport(t *testing.T) {
+	ex := executable.MustParse("Godot_v4.0-stable_linux.x86_64")
+
+	// NOTE: This is the SHA-512 checksum of the string "godot".
+	checksum := "6e096a822dcb43922a4479f5144a60255b7bdf3cceca1bcdb740773c5927efa5" +
+		"53adb0195634797d41d95c13dbded4a124a2e0d742560d2fd65a5bac52852767"
+
+	manifest := func(kind, contents string) fstest.File {
+		return fstest.File{
+			Path: fileManifest,
+			Contents: `{"format":1,"entries":[{"kind":"` + kind + `","version":"v4.0-stable",` +
+				`"name":"` + ex.Name() + `","files":{"files":[{"path":"` + ex.Name() + `",` +
+				`"size":5,"mode":420,"sha512":"` + contents + `"}]}}]}`,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		contents []fstest.Writer
+
+		want []fstest.Asserter
+		err  error
+	}{
+		{
+			name: "missing manifest returns an error",
+			contents: []fstest.Writer{
+				fstest.File{Path: "executable/" + ex.Name() + "/" + ex.Name(), Contents: "godot"},
+			},
+
+			err: ErrInvalidBundle,
+		},
+		{
+			name: "unsupported format returns an error",
+			contents: []fstest.Writer{
+				fstest.File{Path: fileManifest, Contents: `{"format":2}`},
+			},
+
+			err: ErrInvalidBundle,
+		},
+		{
+			name: "unrecognized kind returns an error",
+			contents: []fstest.Writer{
+				manifest("templates", checksum),
+			},
+
+			err: ErrInvalidBundle,
+		},
+		{
+			name: "modified file returns an error",
+			contents: []fstest.Writer{
+				manifest(kindExecutable, checksum),
+				fstest.File{Path: "executable/" + ex.Name() + "/" + ex.Name(), Contents: "GODOT"},
+			},
+
+			want: []fstest.Asserter{
+				fstest.Absent{Path: filepath.Join("store", "editor", "v4.0-stable")},
+			},
+			err: ErrChecksumMismatch,
+		},
+		{
+			name: "valid executable is imported",
+			contents: []fstest.Writer{
+				manifest(kindExecutable, checksum),
+				fstest.File{Path: "executable/" + ex.Name() + "/" + ex.Name(), Contents: "godot"},
+			},
+
+			want: []fstest.Asserter{
+				fstest.File{
+					Path:     filepath.Join("store", "editor", "v4.0-stable", "linux.x86_64", ex.Name()),
+					Contents: "godot",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			t.Setenv("GDENV_PATH", "")
+
+			// Given: A bundle with the specified contents.
+			fstest.Zip{Path: "bundle.zip", Contents: tc.contents}.Write(t, tmp)
+
+			// When: The bundle is imported.
+			_, _, err := Import(context.Background(), filepath.Join(tmp, "store"), filepath.Join(tmp, "bundle.zip"))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The store has the expected contents.
+			for _, f := range tc.want {
+				f.Assert(t, tmp)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package bundle
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/internal/ioutil"
+	"github.com/coffeebeats/gdenv/internal/osutil"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                              Function: Export                              */
+/* -------------------------------------------------------------------------- */
+
+// Export writes the specified installed artifacts, along with their checksums
+// and metadata, into a bundle at the path 'out'. Each artifact's files are
+// verified against the manifest recorded when it was installed (see
+// 'store.Verify') so that a modified store entry is never exported. Only
+// executables and source code are supported.
+//
+// NOTE: The bundle is written to a temporary file which is moved into place
+// once complete, so 'out' never contains a partially-written bundle.
+func Export(ctx context.Context, storePath string, artifacts []artifact.Artifact, out string) (err error) {
+	if storePath == "" {
+		return store.ErrMissingStore
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(out), filepath.Base(out)+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(f.Name()))
+		}
+	}()
+
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+
+	entries := make([]Entry, 0, len(artifacts))
+
+	for _, a := range artifacts {
+		e, err := exportArtifact(ctx, storePath, w, a)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, e)
+	}
+
+	bb, err := json.MarshalIndent(Manifest{Format: formatVersion, Entries: entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fm, err := w.Create(fileManifest)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fm.Write(bb); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(f.Name(), osutil.ModeUserRW); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), out)
+}
+
+/* ------------------------- Function: exportArtifact ----------------------- */
+
+// exportArtifact writes the files of a single installed artifact to the bundle
+// and returns the bundle entry describing them. The artifact's lock is held
+// while its files are verified and copied.
+func exportArtifact(ctx context.Context, storePath string, w *zip.Writer, a artifact.Artifact) (e Entry, err error) {
+	unlock, err := store.LockArtifact(ctx, storePath, a)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	pathEntry, err := store.Entry(storePath, a)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	files, err := store.ManifestOf(storePath, a)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	drift, err := store.VerifyDir(ctx, pathEntry, files)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if len(drift) > 0 {
+		return Entry{}, fmt.Errorf(
+			"%w: %s: %s; try running 'gdenv verify --repair'",
+			ErrChecksumMismatch,
+			a.Name(),
+			drift[0],
+		)
+	}
+
+	m, err := store.MetadataOf(storePath, a)
+	if err != nil && !errors.Is(err, store.ErrMissingMetadata) {
+		return Entry{}, err
+	}
+
+	e, err = newEntry(a, files, m)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	log.Debugf("exporting artifact: %s", pathEntry)
+
+	for _, f := range files.Files {
+		name := path.Join(e.path(), f.Path)
+
+		switch {
+		case f.Mode.IsRegular():
+			err = exportFile(ctx, w, pathEntry, name, f)
+		case f.Mode&fs.ModeSymlink != 0:
+			err = exportSymlink(w, name, f)
+		default:
+			err = fmt.Errorf("%w: %s: %s", ErrUnsupportedFile, f.Mode.Type(), f.Path)
+		}
+
+		if err != nil {
+			return Entry{}, err
+		}
+	}
+
+	return e, nil
+}
+
+/* --------------------------- Function: exportFile ------------------------- */
+
+// exportFile copies a single file of the store entry at 'pathEntry' into the
+// bundle at the slash-separated path 'name'.
+func exportFile(ctx context.Context, w *zip.Writer, pathEntry, name string, mf store.ManifestFile) error {
+	h := &zip.FileHeader{Name: name, Method: zip.Deflate} //nolint:exhaustruct
+	h.SetMode(mf.Mode)
+
+	dst, err := w.CreateHeader(h)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(filepath.Join(pathEntry, filepath.FromSlash(mf.Path)))
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	_, err = io.Copy(dst, ioutil.NewReaderWithContext(ctx, src.Read))
+
+	return err
+}
+
+/* ------------------------- Function: exportSymlink ------------------------ */
+
+// exportSymlink writes a symbolic link of a store entry (e.g. within a macOS
+// application bundle) into the bundle at the slash-separated path 'name'. Like
+// the 'zip' tool, the link's target is stored as the entry's contents.
+func exportSymlink(w *zip.Writer, name string, mf store.ManifestFile) error {
+	h := &zip.FileHeader{Name: name, Method: zip.Store} //nolint:exhaustruct
+	h.SetMode(mf.Mode)
+
+	dst, err := w.CreateHeader(h)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(dst, mf.Link)
+
+	return err
+}
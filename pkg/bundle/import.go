@@ -0,0 +1,165 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/archive"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                              Function: Import                              */
+/* -------------------------------------------------------------------------- */
+
+// Import adds the artifacts contained in the bundle at 'path' to the store.
+// Each entry's files are verified against the checksums recorded in the bundle
+// before being added (see 'store.Add'); artifacts which are already installed
+// are skipped. The imported and skipped artifacts are returned in bundle
+// order.
+func Import(
+	ctx context.Context,
+	storePath string,
+	path string,
+) (imported []artifact.Artifact, skipped []artifact.Artifact, err error) {
+	if storePath == "" {
+		return nil, nil, store.ErrMissingStore
+	}
+
+	tmp, err := os.MkdirTemp("", "gdenv-*")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer os.RemoveAll(tmp)
+
+	log.Debugf("using temporary directory: %s", tmp)
+
+	local := artifact.Local[Archive]{Artifact: Archive{Inner: Bundle{}}, Path: path}
+	if err := archive.Extract(ctx, local, tmp); err != nil {
+		return nil, nil, err
+	}
+
+	m, err := readManifest(tmp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, e := range m.Entries {
+		a, err := e.artifact()
+		if err != nil {
+			return imported, skipped, err
+		}
+
+		ok, err := importEntry(ctx, storePath, filepath.Join(tmp, filepath.FromSlash(e.path())), a, e)
+		if err != nil {
+			return imported, skipped, err
+		}
+
+		if !ok {
+			skipped = append(skipped, a)
+
+			continue
+		}
+
+		imported = append(imported, a)
+	}
+
+	return imported, skipped, nil
+}
+
+/* --------------------------- Function: importEntry ------------------------ */
+
+// importEntry verifies and adds the extracted bundle entry at 'pathEntry' to
+// the store while holding the artifact's lock. Returns whether the artifact was
+// added; 'false' means the artifact was already installed.
+func importEntry(
+	ctx context.Context,
+	storePath, pathEntry string,
+	a artifact.Artifact,
+	e Entry,
+) (ok bool, err error) {
+	unlock, err := store.LockArtifact(ctx, storePath, a)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	if ok, err := store.Has(storePath, a); err != nil || ok {
+		return false, err
+	}
+
+	drift, err := store.VerifyDir(ctx, pathEntry, e.Files)
+	if err != nil {
+		return false, err
+	}
+
+	for _, d := range drift {
+		// NOTE: Permission bits may differ based on the user's 'umask'; only
+		// the files' contents need to match.
+		if d.Kind != store.DriftMode {
+			return false, fmt.Errorf("%w: %s: %s", ErrChecksumMismatch, a.Name(), d)
+		}
+	}
+
+	entries, err := os.ReadDir(pathEntry)
+	if err != nil {
+		return false, err
+	}
+
+	artifacts := make([]artifact.Local[artifact.Artifact], 0, len(entries))
+	for _, entry := range entries {
+		artifacts = append(artifacts, artifact.Local[artifact.Artifact]{
+			Artifact: a,
+			Path:     filepath.Join(pathEntry, entry.Name()),
+		})
+	}
+
+	log.Debugf("importing artifact: %s", a.Name())
+
+	if err := store.Add(store.WithProvenance(ctx, e.Metadata.Provenance), storePath, artifacts...); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+/* -------------------------- Function: readManifest ------------------------ */
+
+// readManifest reads the manifest at the root of the extracted bundle 'root'.
+func readManifest(root string) (Manifest, error) {
+	bb, err := os.ReadFile(filepath.Join(root, fileManifest))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Manifest{}, fmt.Errorf("%w: missing %s", ErrInvalidBundle, fileManifest)
+		}
+
+		return Manifest{}, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(bb, &m); err != nil {
+		return Manifest{}, fmt.Errorf("%w: %s: %w", ErrInvalidBundle, fileManifest, err)
+	}
+
+	if m.Format != formatVersion {
+		return Manifest{}, fmt.Errorf(
+			"%w: unsupported format v%d (expected v%d); try upgrading 'gdenv'",
+			ErrInvalidBundle,
+			m.Format,
+			formatVersion,
+		)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,174 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/internal/client"
+	"github.com/coffeebeats/gdenv/internal/osutil"
+	"github.com/coffeebeats/gdenv/pkg/download"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+// DefaultTTL is the duration for which a cached 'Catalog' is considered fresh.
+const DefaultTTL = 24 * time.Hour
+
+var ErrMissingCache = errors.New("missing release cache")
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Load                               */
+/* -------------------------------------------------------------------------- */
+
+// Load returns the 'Catalog' of available Godot releases. A cached 'Catalog'
+// within the store is used if it's younger than 'ttl'; otherwise the releases
+// are fetched from GitHub and the cache is updated. Set 'ttl' to '0' to force a
+// refresh.
+//
+// In offline mode (see 'download.IsOffline'), the cached 'Catalog' is always
+// used, regardless of its age. An error is returned if a refresh is required.
+func Load(ctx context.Context, storePath string, ttl time.Duration) (Catalog, error) {
+	return load(ctx, storePath, ttl, func(ctx context.Context) (Catalog, error) {
+		if download.IsOffline() {
+			return Catalog{}, fmt.Errorf("%w: cannot fetch releases", download.ErrOffline)
+		}
+
+		return Fetch(ctx, client.New(), URLGitHubReleases)
+	})
+}
+
+/* ------------------------------ Function: load ---------------------------- */
+
+// load implements 'Load', but accepts the function used to fetch releases.
+func load(
+	ctx context.Context,
+	storePath string,
+	ttl time.Duration,
+	fetch func(context.Context) (Catalog, error),
+) (Catalog, error) {
+	path, err := store.Releases(storePath)
+	if err != nil {
+		return Catalog{}, err
+	}
+
+	cached, err := Read(path)
+	if err != nil && !errors.Is(err, ErrMissingCache) {
+		// A corrupt cache can simply be replaced.
+		log.Debugf("ignoring invalid release cache: %s", err)
+	}
+
+	if err == nil && ttl > 0 && download.IsOffline() {
+		log.Debugf("using cached releases in offline mode: %s", path)
+
+		return cached, nil
+	}
+
+	if err == nil && time.Since(cached.Updated) < ttl {
+		log.Debugf("using cached releases: %s", path)
+
+		return cached, nil
+	}
+
+	c, err := fetch(ctx)
+	if err != nil {
+		return Catalog{}, err
+	}
+
+	if err := store.Touch(ctx, storePath); err != nil {
+		return Catalog{}, err
+	}
+
+	if err := Write(c, path); err != nil {
+		return Catalog{}, err
+	}
+
+	return c, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Read                               */
+/* -------------------------------------------------------------------------- */
+
+// Read parses a cached 'Catalog' from the specified file.
+func Read(path string) (Catalog, error) {
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return Catalog{}, err
+		}
+
+		return Catalog{}, ErrMissingCache
+	}
+
+	var data cacheData
+	if err := json.Unmarshal(bb, &data); err != nil {
+		return Catalog{}, err
+	}
+
+	out := Catalog{
+		Releases: make([]Release, 0, len(data.Releases)),
+		Updated:  data.Updated,
+	}
+
+	for _, r := range data.Releases {
+		v, err := version.Parse(r.Version)
+		if err != nil {
+			return Catalog{}, err
+		}
+
+		out.Releases = append(out.Releases, Release{Version: v, Mono: r.Mono, Published: r.Published})
+	}
+
+	return out, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Write                              */
+/* -------------------------------------------------------------------------- */
+
+// Write caches the 'Catalog' in the specified file.
+func Write(c Catalog, path string) error {
+	data := cacheData{
+		Releases: make([]cacheRelease, 0, len(c.Releases)),
+		Updated:  c.Updated,
+	}
+
+	for _, r := range c.Releases {
+		data.Releases = append(data.Releases, cacheRelease{
+			Version:   r.Version.String(),
+			Mono:      r.Mono,
+			Published: r.Published,
+		})
+	}
+
+	bb, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, bb, osutil.ModeUserRW)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: cacheData                             */
+/* -------------------------------------------------------------------------- */
+
+// cacheData is the serialized format of a cached 'Catalog'.
+type cacheData struct {
+	Releases []cacheRelease `json:"releases"`
+	Updated  time.Time      `json:"updated"`
+}
+
+// cacheRelease is the serialized format of a cached 'Release'.
+type cacheRelease struct {
+	Version   string    `json:"version"`
+	Mono      bool      `json:"mono"`
+	Published time.Time `json:"published"`
+}
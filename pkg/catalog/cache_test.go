@@ -0,0 +1,152 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/download"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* ------------------------------- Test: load ------------------------------- */
+
+func TestLoad(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	stale := Catalog{
+		Releases: []Release{{Version: version.MustParse("4.2-stable"), Mono: true, Published: now}},
+		Updated:  now.Add(-2 * DefaultTTL),
+	}
+
+	fresh := Catalog{
+		Releases: []Release{{Version: version.MustParse("4.3-stable"), Mono: true, Published: now}},
+		Updated:  now,
+	}
+
+	errFetch := errors.New("fetch failed")
+
+	tests := []struct {
+		name    string
+		cached  *Catalog
+		files   []fstest.Writer
+		ttl     time.Duration
+		fetch   error
+		offline bool
+
+		want Catalog
+		err  error
+	}{
+		{
+			name: "missing cache fetches releases",
+			ttl:  DefaultTTL,
+			want: fresh,
+		},
+		{
+			name:  "invalid cache fetches releases",
+			ttl:   DefaultTTL,
+			files: []fstest.Writer{fstest.File{Path: "releases.json", Contents: "{"}},
+			want:  fresh,
+		},
+		{
+			name:   "fresh cache is used",
+			cached: &stale,
+			ttl:    3 * DefaultTTL,
+			want:   stale,
+		},
+		{
+			name:   "stale cache fetches releases",
+			cached: &stale,
+			ttl:    DefaultTTL,
+			want:   fresh,
+		},
+		{
+			name:   "zero ttl fetches releases",
+			cached: &fresh,
+			ttl:    0,
+			want:   fresh,
+		},
+		{
+			name:    "stale cache is used in offline mode",
+			cached:  &stale,
+			ttl:     DefaultTTL,
+			offline: true,
+			want:    stale,
+		},
+		{
+			name:    "zero ttl fetches releases in offline mode",
+			cached:  &stale,
+			ttl:     0,
+			offline: true,
+			want:    fresh,
+		},
+		{
+			name:   "fetch failure returns an error",
+			cached: &stale,
+			ttl:    DefaultTTL,
+			fetch:  errFetch,
+			err:    errFetch,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			storePath := t.TempDir()
+
+			// Given: Offline mode is set as specified.
+			t.Setenv(download.EnvOffline, strconv.FormatBool(tc.offline))
+
+			path, err := store.Releases(storePath)
+			if err != nil {
+				t.Fatalf("test setup: %v", err)
+			}
+
+			// Given: The specified files exist on the file system.
+			for _, f := range tc.files {
+				f.Write(t, storePath)
+			}
+
+			// Given: The specified catalog is cached.
+			if tc.cached != nil {
+				if err := Write(*tc.cached, path); err != nil {
+					t.Fatalf("test setup: %v", err)
+				}
+			}
+
+			// When: The catalog is loaded.
+			got, err := load(context.Background(), storePath, tc.ttl, func(context.Context) (Catalog, error) {
+				return fresh, tc.fetch
+			})
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			if tc.err != nil {
+				return
+			}
+
+			// Then: The expected catalog is returned.
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+
+			// Then: The returned catalog is cached.
+			cached, err := Read(filepath.Clean(path))
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			if !reflect.DeepEqual(cached, tc.want) {
+				t.Errorf("cache: got %v, want %v", cached, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+const (
+	ChannelAlpha  = "alpha"
+	ChannelBeta   = "beta"
+	ChannelDev    = "dev"
+	ChannelRC     = "rc"
+	ChannelStable = version.LabelStable
+)
+
+var (
+	ErrInvalidFilter = errors.New("invalid filter")
+	ErrNoMatch       = errors.New("no matching release")
+)
+
+/* -------------------------------------------------------------------------- */
+/*                               Struct: Release                              */
+/* -------------------------------------------------------------------------- */
+
+// Release describes a single published release of Godot.
+type Release struct {
+	// Version is the version of the release. This will never be a "mono"
+	// version; see 'Mono' instead.
+	Version version.Version
+
+	// Mono is whether the release includes "mono" (i.e. C#) editor builds.
+	Mono bool
+
+	// Published is the time at which the release was published.
+	Published time.Time
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Struct: Catalog                              */
+/* -------------------------------------------------------------------------- */
+
+// Catalog is a listing of all available Godot releases.
+type Catalog struct {
+	// Releases is the list of available releases.
+	Releases []Release
+
+	// Updated is the time at which the list of releases was fetched.
+	Updated time.Time
+}
+
+/* ---------------------------- Method: Versions ---------------------------- */
+
+// Versions returns the versions of all releases which satisfy the 'Filter'. The
+// versions are ordered from newest to oldest.
+func (c Catalog) Versions(f Filter) ([]version.Version, error) {
+	prefix, err := parsePrefix(f.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, 0, len(c.Releases))
+
+	for _, r := range c.Releases {
+		if f.Mono && !r.Mono {
+			continue
+		}
+
+		if !hasPrefix(r.Version, prefix) {
+			continue
+		}
+
+		if f.Channel != "" && r.Version.Channel() != f.Channel {
+			continue
+		}
+
+		releases = append(releases, r)
+	}
+
+	slices.SortStableFunc(releases, func(a, b Release) int {
+		if cmp := b.Version.Compare(a.Version); cmp != 0 {
+			return cmp
+		}
+
+		return b.Published.Compare(a.Published)
+	})
+
+	out := make([]version.Version, 0, len(releases))
+
+	for _, r := range releases {
+		v := r.Version
+
+		if f.Mono {
+			v, err = version.Parse(r.Version.Normal() + version.SeparatorPreReleaseVersion + r.Version.Label() + "_" + version.Mono)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+/* ----------------------------- Method: Select ----------------------------- */
+
+// Select returns the newest released 'Version' which satisfies the provided
+// 'version.Constraint'. If the constraint requires a "mono" version label,
+// then only releases with "mono" builds are considered.
+func (c Catalog) Select(constraint version.Constraint) (version.Version, error) {
+	versions, err := c.Versions(Filter{ //nolint:exhaustruct
+		Mono: strings.HasSuffix(constraint.Label(), "_"+version.Mono),
+	})
+	if err != nil {
+		return version.Version{}, err
+	}
+
+	v, ok := constraint.Select(versions)
+	if !ok {
+		return version.Version{}, fmt.Errorf("%w: %s", ErrNoMatch, constraint)
+	}
+
+	return v, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Struct: Filter                               */
+/* -------------------------------------------------------------------------- */
+
+// Filter describes which releases to include when listing the 'Catalog'.
+type Filter struct {
+	// Version is a (possibly partial) version prefix, e.g. '4' or '4.3'. An
+	// empty value matches all versions.
+	Version string
+
+	// Channel is the release channel, e.g. 'stable' or 'beta' (see the
+	// 'Channel*' constants). An empty value matches all channels.
+	Channel string
+
+	// Mono is whether to list "mono" variants of releases. Only releases with
+	// "mono" builds will be included.
+	Mono bool
+}
+
+/* --------------------------- Function: hasPrefix -------------------------- */
+
+// hasPrefix returns whether the "normal version" of 'v' begins with the
+// specified components.
+func hasPrefix(v version.Version, prefix []int) bool {
+	for i, n := range []int{v.Major(), v.Minor(), v.Patch()}[:len(prefix)] {
+		if prefix[i] != n {
+			return false
+		}
+	}
+
+	return true
+}
+
+/* ------------------------- Function: parsePrefix -------------------------- */
+
+// parsePrefix parses a (possibly partial) "normal version" into its components.
+func parsePrefix(input string) ([]int, error) {
+	input = strings.TrimPrefix(strings.TrimSpace(input), version.Prefix)
+	if input == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(input, ".")
+	if len(parts) > 3 { //nolint:mnd
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidFilter, input)
+	}
+
+	out := make([]int, len(parts))
+
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidFilter, input)
+		}
+
+		out[i] = int(n)
+	}
+
+	return out, nil
+}
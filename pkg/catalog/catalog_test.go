@@ -0,0 +1,163 @@
+package catalog
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* ---------------------------- Test: Versions ---------------------------- */
+
+func TestCatalogVersions(t *testing.T) {
+	now := time.Now()
+
+	catalog := Catalog{
+		Releases: []Release{
+			{Version: version.MustParse("3.6-stable"), Mono: true, Published: now.Add(-3 * time.Hour)},
+			{Version: version.MustParse("4.2.2-stable"), Mono: true, Published: now.Add(-4 * time.Hour)},
+			{Version: version.MustParse("4.3-beta2"), Mono: true, Published: now.Add(-2 * time.Hour)},
+			{Version: version.MustParse("4.3-rc1"), Mono: false, Published: now.Add(-1 * time.Hour)},
+			{Version: version.MustParse("4.3-stable"), Mono: true, Published: now},
+			{Version: version.MustParse("4.4-dev1"), Mono: false, Published: now},
+		},
+		Updated: now,
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+
+		want []version.Version
+		err  error
+	}{
+		// Invalid inputs
+		{
+			name:   "invalid version prefix returns an error",
+			filter: Filter{Version: "4.x"},
+
+			err: ErrInvalidFilter,
+		},
+		{
+			name:   "too many version components returns an error",
+			filter: Filter{Version: "4.3.0.1"},
+
+			err: ErrInvalidFilter,
+		},
+
+		// Valid inputs
+		{
+			name:   "empty filter returns all versions from newest to oldest",
+			filter: Filter{},
+
+			want: []version.Version{
+				version.MustParse("4.4-dev1"),
+				version.MustParse("4.3-stable"),
+				version.MustParse("4.3-rc1"),
+				version.MustParse("4.3-beta2"),
+				version.MustParse("4.2.2-stable"),
+				version.MustParse("3.6-stable"),
+			},
+		},
+		{
+			name:   "major version filter returns matching versions",
+			filter: Filter{Version: "v3"},
+
+			want: []version.Version{version.MustParse("3.6-stable")},
+		},
+		{
+			name:   "minor version filter returns matching versions",
+			filter: Filter{Version: "4.2"},
+
+			want: []version.Version{version.MustParse("4.2.2-stable")},
+		},
+		{
+			name:   "channel filter returns matching versions",
+			filter: Filter{Version: "4", Channel: ChannelStable},
+
+			want: []version.Version{
+				version.MustParse("4.3-stable"),
+				version.MustParse("4.2.2-stable"),
+			},
+		},
+		{
+			name:   "mono filter returns mono variants of matching versions",
+			filter: Filter{Version: "4.3", Mono: true},
+
+			want: []version.Version{
+				version.MustParse("4.3-stable_mono"),
+				version.MustParse("4.3-beta2_mono"),
+			},
+		},
+		{
+			name:   "mono channel filter ignores the mono suffix",
+			filter: Filter{Version: "4.3", Channel: ChannelBeta, Mono: true},
+
+			want: []version.Version{version.MustParse("4.3-beta2_mono")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: The catalog versions are listed using the filter.
+			got, err := catalog.Versions(tc.filter)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected versions are returned.
+			if tc.err == nil && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ----------------------------- Test: Select ----------------------------- */
+
+func TestCatalogSelect(t *testing.T) {
+	catalog := Catalog{
+		Releases: []Release{
+			{Version: version.MustParse("4.2.1-stable"), Mono: true},
+			{Version: version.MustParse("4.2.2-stable"), Mono: false},
+			{Version: version.MustParse("4.3-beta2"), Mono: true},
+			{Version: version.MustParse("4.3-stable"), Mono: true},
+		},
+	}
+
+	tests := []struct {
+		constraint string
+
+		want version.Version
+		err  error
+	}{
+		{constraint: "~4.4", err: ErrNoMatch},
+		{constraint: "4.2.2-stable_mono", err: ErrNoMatch},
+
+		{constraint: "~4.2", want: version.MustParse("4.2.2-stable")},
+		{constraint: "~4.2-stable_mono", want: version.MustParse("4.2.1-stable_mono")},
+		{constraint: "^4.2", want: version.MustParse("4.3-stable")},
+		{constraint: ">=4.3-beta2", want: version.MustParse("4.3-beta2")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.constraint, func(t *testing.T) {
+			// When: The newest release matching the constraint is selected.
+			got, err := catalog.Select(version.MustParseConstraint(tc.constraint))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected version is returned.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
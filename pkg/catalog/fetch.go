@@ -0,0 +1,144 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/internal/client"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+const (
+	// URLGitHubReleases is the GitHub API endpoint listing the releases of the
+	// 'godotengine/godot-builds' repository.
+	URLGitHubReleases = "https://api.github.com/repos/godotengine/godot-builds/releases"
+
+	gitHubPageSize = 100
+	gitHubPagesMax = 50 // guard against a misbehaving server
+
+	assetMonoMarker = "_" + version.Mono + "_"
+)
+
+var ErrTooManyPages = errors.New("too many pages")
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Fetch                              */
+/* -------------------------------------------------------------------------- */
+
+// Fetch downloads the list of available Godot releases from the GitHub API
+// endpoint at 'urlBase' (see 'URLGitHubReleases'). Releases whose tags cannot
+// be parsed as a 'version.Version' are skipped.
+func Fetch(ctx context.Context, c *client.Client, urlBase string) (Catalog, error) {
+	u, err := client.ParseURL(urlBase)
+	if err != nil {
+		return Catalog{}, err
+	}
+
+	out := Catalog{Releases: nil, Updated: time.Now()}
+
+	for page := 1; ; page++ {
+		if page > gitHubPagesMax {
+			return Catalog{}, ErrTooManyPages
+		}
+
+		releases, err := fetchPage(ctx, c, *u, page)
+		if err != nil {
+			return Catalog{}, err
+		}
+
+		for _, r := range releases {
+			release, ok := r.toRelease()
+			if !ok {
+				log.Debugf("skipping unrecognized release: %s", r.TagName)
+
+				continue
+			}
+
+			out.Releases = append(out.Releases, release)
+		}
+
+		if len(releases) < gitHubPageSize {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+/* -------------------------- Function: fetchPage --------------------------- */
+
+// fetchPage downloads and parses a single page of GitHub releases.
+func fetchPage(ctx context.Context, c *client.Client, u url.URL, page int) ([]gitHubRelease, error) {
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(gitHubPageSize))
+
+	u.RawQuery = query.Encode()
+
+	log.Debugf("fetching releases: %s", u.String())
+
+	var buf bytes.Buffer
+	if err := c.Download(ctx, &u, &buf); err != nil {
+		return nil, err
+	}
+
+	var releases []gitHubRelease
+	if err := json.Unmarshal(buf.Bytes(), &releases); err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Struct: gitHubRelease                           */
+/* -------------------------------------------------------------------------- */
+
+// gitHubRelease is the subset of a GitHub API release object used by 'gdenv'.
+type gitHubRelease struct {
+	TagName     string        `json:"tag_name"`
+	Draft       bool          `json:"draft"`
+	PublishedAt time.Time     `json:"published_at"`
+	Assets      []gitHubAsset `json:"assets"`
+}
+
+// gitHubAsset is the subset of a GitHub API release asset object used by
+// 'gdenv'.
+type gitHubAsset struct {
+	Name string `json:"name"`
+}
+
+/* --------------------------- Method: toRelease ---------------------------- */
+
+// toRelease converts the GitHub release into a 'Release', returning 'false' if
+// the release does not describe a Godot version.
+func (r gitHubRelease) toRelease() (Release, bool) {
+	if r.Draft {
+		return Release{}, false
+	}
+
+	v, err := version.Parse(r.TagName)
+	if err != nil || v.IsMono() {
+		return Release{}, false
+	}
+
+	mono := false
+
+	for _, a := range r.Assets {
+		if strings.Contains(a.Name, assetMonoMarker) {
+			mono = true
+
+			break
+		}
+	}
+
+	return Release{Version: v, Mono: mono, Published: r.PublishedAt}, true
+}
@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/coffeebeats/gdenv/internal/client"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* ------------------------------ Test: Fetch ------------------------------ */
+
+func TestFetch(t *testing.T) {
+	published := time.Date(2024, time.August, 15, 0, 0, 0, 0, time.UTC)
+
+	// Given: More releases than fit on a single page.
+	pages := make([][]gitHubRelease, 2)
+	for i := range gitHubPageSize {
+		pages[0] = append(pages[0], gitHubRelease{
+			TagName:     fmt.Sprintf("3.%d-stable", i),
+			Draft:       false,
+			PublishedAt: published,
+			Assets:      nil,
+		})
+	}
+
+	pages[1] = []gitHubRelease{
+		{
+			TagName:     "4.3-stable",
+			PublishedAt: published,
+			Assets: []gitHubAsset{
+				{Name: "Godot_v4.3-stable_linux.x86_64.zip"},
+				{Name: "Godot_v4.3-stable_mono_linux_x86_64.zip"},
+			},
+		},
+		{TagName: "4.4-dev1", Draft: true, PublishedAt: published},              // draft
+		{TagName: "not-a-version", PublishedAt: published},                      // invalid
+		{TagName: "4.2-stable_mono", PublishedAt: published, Assets: nil},       // mono
+		{TagName: "4.2.2-rc1", PublishedAt: published, Assets: []gitHubAsset{}}, // pre-release
+	}
+
+	// Given: A stand-in for the GitHub API which serves the pages.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("per_page"); got != strconv.Itoa(gitHubPageSize) {
+			t.Errorf("per_page: got %s, want %d", got, gitHubPageSize)
+		}
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil || page < 1 {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		releases := []gitHubRelease{}
+		if page <= len(pages) {
+			releases = pages[page-1]
+		}
+
+		if err := json.NewEncoder(w).Encode(releases); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer srv.Close()
+
+	// When: The releases are fetched.
+	got, err := Fetch(context.Background(), client.New(), srv.URL)
+
+	// Then: No error is returned.
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: All valid releases are returned.
+	if n := len(got.Releases); n != gitHubPageSize+2 {
+		t.Fatalf("output: got %d releases, want %d", n, gitHubPageSize+2)
+	}
+
+	want := []Release{
+		{Version: version.MustParse("4.3-stable"), Mono: true, Published: published},
+		{Version: version.MustParse("4.2.2-rc1"), Mono: false, Published: published},
+	}
+
+	if !reflect.DeepEqual(got.Releases[gitHubPageSize:], want) {
+		t.Errorf("output: got %v, want %v", got.Releases[gitHubPageSize:], want)
+	}
+}
+
+/* ------------------------- Test: Fetch (failure) ------------------------- */
+
+func TestFetchFailure(t *testing.T) {
+	// Given: A stand-in for the GitHub API which rejects requests.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	// When: The releases are fetched.
+	_, err := Fetch(context.Background(), client.New(), srv.URL)
+
+	// Then: The request failure is returned.
+	if !errors.Is(err, client.ErrHTTPResponseStatusCode) {
+		t.Errorf("err: got %v, want %v", err, client.ErrHTTPResponseStatusCode)
+	}
+}
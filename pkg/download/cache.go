@@ -0,0 +1,104 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/internal/units"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/checksum"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+// EnvCacheSize is an environment variable which sets the maximum total size of
+// the downloaded archives kept in the store's download cache (e.g. '2GB'). The
+// cache allows artifacts to be reinstalled without downloading them again. The
+// cache is disabled if this is unset or zero.
+const EnvCacheSize = "GDENV_CACHE_SIZE"
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: CacheSize                            */
+/* -------------------------------------------------------------------------- */
+
+// CacheSize returns the maximum total size in bytes of the download cache, as
+// configured by 'EnvCacheSize'. A size of zero means the cache is disabled.
+func CacheSize() (int64, error) {
+	value := os.Getenv(EnvCacheSize)
+	if value == "" {
+		return 0, nil
+	}
+
+	size, err := units.ParseSize(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", EnvCacheSize, err)
+	}
+
+	return size, nil
+}
+
+/* ---------------------------- Function: fromCache ------------------------- */
+
+// fromCache copies the archive with the specified published checksum from the
+// download cache into the directory 'out', if it's available. The archive is
+// validated before it's used; failures are logged and treated as cache misses.
+// An archive which fails validation is removed from the cache.
+func fromCache[T artifact.Artifact, U checksum.Checksums[T]](
+	ctx context.Context,
+	storePath string,
+	a T,
+	value string,
+	localChecksums artifact.Local[U],
+	out string,
+) (artifact.Local[T], store.Provenance, bool) {
+	local := artifact.Local[T]{Artifact: a, Path: filepath.Join(out, a.Name())}
+
+	p, ok, err := store.CacheGet(ctx, storePath, a.Name(), value, local.Path)
+	if err != nil {
+		log.Warnf("failed to read download cache: %s", err)
+	}
+
+	if err != nil || !ok {
+		return artifact.Local[T]{}, store.Provenance{}, false
+	}
+
+	log.Infof("using cached download of artifact: %s", a.Name())
+
+	if err := checksum.Compare(ctx, local, localChecksums); err != nil {
+		log.Warnf("ignoring cached download; %s", err)
+
+		if err := os.Remove(local.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Warnf("failed to remove copy of cached download: %s", err)
+		}
+
+		// Evict the corrupt archive so that it's not used again.
+		if err := store.CacheRemove(ctx, storePath, a.Name(), value); err != nil {
+			log.Warnf("failed to remove cached download: %s", err)
+		}
+
+		return artifact.Local[T]{}, store.Provenance{}, false
+	}
+
+	return local, p, true
+}
+
+/* ----------------------------- Function: toCache -------------------------- */
+
+// toCache adds the validated archive to the download cache. Failures are logged
+// but otherwise ignored, since the cache is only an optimization.
+func toCache[T artifact.Artifact](
+	ctx context.Context,
+	storePath string,
+	local artifact.Local[T],
+	p store.Provenance,
+	maxSize int64,
+) {
+	if err := store.CachePut(ctx, storePath, local.Path, p.Checksum, p, maxSize); err != nil {
+		log.Warnf("failed to add download to cache: %s", err)
+	}
+}
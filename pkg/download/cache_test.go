@@ -0,0 +1,185 @@
+package download
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/internal/osutil"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+const archiveContents = "archive"
+
+// archive returns the executable archive used by download cache tests.
+func archive() executable.Archive {
+	return executable.Archive{Inner: executable.MustParse("Godot_v4.2.1-stable_linux.x86_64")}
+}
+
+/* ---------------------------- Test: fromCache ----------------------------- */
+
+func TestFromCache(t *testing.T) {
+	tests := []struct {
+		name   string
+		cached string
+
+		want bool
+	}{
+		{
+			name: "missing archive is a cache miss",
+
+			want: false,
+		},
+		{
+			name:   "valid archive is a cache hit",
+			cached: archiveContents,
+
+			want: true,
+		},
+		{
+			name:   "corrupt archive is a cache miss and is evicted",
+			cached: "corrupt",
+
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			storePath := filepath.Join(tmp, "store")
+
+			a, value := archive(), checksumOf(archiveContents)
+			localChecksums := mustWriteChecksums(t, tmp, a, value)
+
+			p := store.Provenance{Mirror: "mirror", URL: "https://example.com", Checksum: value, Size: 7}
+
+			// Given: The specified archive contents are cached under the
+			// archive's published checksum.
+			if tc.cached != "" {
+				local := artifact.Local[executable.Archive]{Artifact: a, Path: filepath.Join(tmp, a.Name())}
+				fstest.File{Path: a.Name(), Contents: tc.cached}.Write(t, tmp)
+
+				toCache(context.Background(), storePath, local, p, 1<<10)
+			}
+
+			out := filepath.Join(tmp, "out")
+			fstest.Dir{Path: "out"}.Write(t, tmp)
+
+			// When: The archive is read from the cache.
+			got, gotProvenance, ok := fromCache(context.Background(), storePath, a, value, localChecksums, out)
+
+			// Then: The archive is found only if it's valid.
+			if ok != tc.want {
+				t.Fatalf("output: got %v, want %v", ok, tc.want)
+			}
+
+			if !ok {
+				// Then: No archive is left in the output directory.
+				fstest.Absent{Path: filepath.Join("out", a.Name())}.Assert(t, tmp)
+
+				// Then: No archive is left in the cache.
+				entries, err := store.CacheEntries(storePath)
+				if err != nil || len(entries) != 0 {
+					t.Errorf("output: got %v (err: %v), want %v", entries, err, nil)
+				}
+
+				return
+			}
+
+			// Then: The cached archive is copied to the output directory.
+			fstest.File{Path: filepath.Join("out", a.Name()), Contents: archiveContents}.Assert(t, tmp)
+
+			if want := filepath.Join(out, a.Name()); got.Path != want {
+				t.Errorf("output: got %v, want %v", got.Path, want)
+			}
+
+			// Then: The provenance recorded when the archive was cached is used.
+			if gotProvenance != p {
+				t.Errorf("output: got %v, want %v", gotProvenance, p)
+			}
+		})
+	}
+}
+
+/* ----------------------------- Test: toCache ------------------------------ */
+
+func TestToCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxSize int64
+
+		want int
+	}{
+		{name: "archive within the size limit is cached", maxSize: 1 << 10, want: 1},
+		{name: "archive larger than the size limit isn't cached", maxSize: 1, want: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			storePath := filepath.Join(tmp, "store")
+
+			a, value := archive(), checksumOf(archiveContents)
+
+			// Given: A validated archive was downloaded.
+			local := artifact.Local[executable.Archive]{Artifact: a, Path: filepath.Join(tmp, a.Name())}
+			fstest.File{Path: a.Name(), Contents: archiveContents}.Write(t, tmp)
+
+			p := store.Provenance{Mirror: "", URL: "", Checksum: value, Size: 7}
+
+			// When: The archive is added to the cache.
+			toCache(context.Background(), storePath, local, p, tc.maxSize)
+
+			// Then: The archive is cached only if it fits within the limit.
+			entries, err := store.CacheEntries(storePath)
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			if len(entries) != tc.want {
+				t.Errorf("output: got %v, want %v", len(entries), tc.want)
+			}
+		})
+	}
+}
+
+/* ---------------------- Function: mustWriteChecksums ---------------------- */
+
+// mustWriteChecksums writes a checksums file which publishes the specified
+// checksum for the archive.
+func mustWriteChecksums(
+	t *testing.T,
+	tmp string,
+	a executable.Archive,
+	value string,
+) artifact.Local[executable.Checksums] {
+	t.Helper()
+
+	checksums, err := executable.NewChecksums(a.Version())
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	path := filepath.Join(tmp, checksums.Name())
+	if err := os.WriteFile(path, []byte(value+"  "+a.Name()+"\n"), osutil.ModeUserRW); err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	return artifact.Local[executable.Checksums]{Artifact: checksums, Path: path}
+}
+
+/* -------------------------- Function: checksumOf -------------------------- */
+
+// checksumOf returns the hex-encoded SHA-512 checksum of the contents.
+func checksumOf(contents string) string {
+	sum := sha512.Sum512([]byte(contents))
+
+	return hex.EncodeToString(sum[:])
+}
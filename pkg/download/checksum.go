@@ -0,0 +1,149 @@
+package download
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/checksum"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                    Function: downloadWithChecksumValidation                */
+/* -------------------------------------------------------------------------- */
+
+// downloadWithChecksumValidation downloads an archive and validates that its
+// checksum matches the published value. If the download cache is enabled (see
+// 'EnvCacheSize'), then a previously-downloaded copy of the archive is used if
+// available and a newly-downloaded archive is added to the cache.
+func downloadWithChecksumValidation[T artifact.Artifact, U checksum.Checksums[T]](
+	ctx context.Context,
+	storePath string,
+	a T,
+	checksums U,
+	out string,
+) (artifact.Local[T], store.Provenance, error) {
+	// Custom builds aren't hosted by any mirror; they must be added to the
+	// store directly instead.
+	if v := a.Version(); v.IsCustom() {
+		return artifact.Local[T]{}, store.Provenance{}, fmt.Errorf("%w: %s", ErrCustomBuild, v)
+	}
+
+	maxSize, err := CacheSize()
+	if err != nil {
+		return artifact.Local[T]{}, store.Provenance{}, err
+	}
+
+	if storePath == "" || maxSize == 0 {
+		return downloadAndValidate(ctx, storePath, a, checksums, out)
+	}
+
+	// The published checksum is required to look up the archive in the cache,
+	// so the checksums must be downloaded first.
+	localChecksums, err := Download(ctx, storePath, checksums, out)
+	if err != nil {
+		return artifact.Local[T]{}, store.Provenance{}, err
+	}
+
+	value, err := checksum.Extract[T](ctx, localChecksums, a)
+	if err != nil {
+		return artifact.Local[T]{}, store.Provenance{}, err
+	}
+
+	if local, provenance, ok := fromCache(ctx, storePath, a, value, localChecksums, out); ok {
+		return local, provenance, nil
+	}
+
+	local, provenance, err := downloadWithProvenance(ctx, storePath, a, out)
+	if err != nil {
+		return artifact.Local[T]{}, store.Provenance{}, err
+	}
+
+	if err := checksum.Compare(ctx, local, localChecksums); err != nil {
+		return artifact.Local[T]{}, store.Provenance{}, err
+	}
+
+	provenance, err = completeProvenance(ctx, provenance, local, localChecksums)
+	if err != nil {
+		return artifact.Local[T]{}, store.Provenance{}, err
+	}
+
+	toCache(ctx, storePath, local, provenance, maxSize)
+
+	return local, provenance, nil
+}
+
+/* ------------------------ Function: downloadAndValidate ------------------- */
+
+// downloadAndValidate concurrently downloads an archive and its published
+// checksums and then validates the archive's checksum.
+func downloadAndValidate[T artifact.Artifact, U checksum.Checksums[T]](
+	ctx context.Context,
+	storePath string,
+	a T,
+	checksums U,
+	out string,
+) (artifact.Local[T], store.Provenance, error) {
+	chArchive := make(chan artifact.Local[T], 1)
+	defer close(chArchive)
+
+	chChecksums := make(chan artifact.Local[U], 1)
+	defer close(chChecksums)
+
+	// NOTE: This is only read after the download goroutines have completed.
+	var provenance store.Provenance
+
+	eg, ctxDownload := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		result, p, err := downloadWithProvenance(ctxDownload, storePath, a, out)
+		if err != nil {
+			return err
+		}
+
+		provenance = p
+
+		select {
+		case chArchive <- result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		result, err := Download(ctxDownload, storePath, checksums, out)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case chChecksums <- result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		return nil
+	})
+
+	if err := eg.Wait(); err != nil {
+		return artifact.Local[T]{}, store.Provenance{}, err
+	}
+
+	local, localChecksums := <-chArchive, <-chChecksums
+
+	if err := checksum.Compare(ctx, local, localChecksums); err != nil {
+		return artifact.Local[T]{}, store.Provenance{}, err
+	}
+
+	provenance, err := completeProvenance(ctx, provenance, local, localChecksums)
+	if err != nil {
+		return artifact.Local[T]{}, store.Provenance{}, err
+	}
+
+	return local, provenance, nil
+}
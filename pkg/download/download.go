@@ -2,21 +2,48 @@ package download
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/charmbracelet/log"
 
 	"github.com/coffeebeats/gdenv/internal/client"
+	"github.com/coffeebeats/gdenv/internal/ioutil"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/checksum"
 	"github.com/coffeebeats/gdenv/pkg/godot/mirror"
 	"github.com/coffeebeats/gdenv/pkg/progress"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+// EnvOffline is an environment variable which, when set to a truthy value,
+// prevents all network requests. Artifacts can then only be downloaded from
+// local mirrors (see 'mirror.Local').
+const EnvOffline = "GDENV_OFFLINE"
+
+var (
+	ErrCustomBuild = errors.New("cannot download a custom build; add it with 'gdenv link'")
+	ErrOffline     = errors.New("offline mode")
 )
 
 type progressKey[T artifact.Artifact] struct{}
 
+/* -------------------------------------------------------------------------- */
+/*                             Function: IsOffline                            */
+/* -------------------------------------------------------------------------- */
+
+// IsOffline returns whether offline mode is enabled via 'EnvOffline'.
+func IsOffline() bool {
+	isOffline, err := strconv.ParseBool(os.Getenv(EnvOffline))
+
+	return err == nil && isOffline
+}
+
 /* -------------------------------------------------------------------------- */
 /*                           Function: WithProgress                           */
 /* -------------------------------------------------------------------------- */
@@ -36,43 +63,89 @@ func WithProgress[T artifact.Artifact](
 /* -------------------------------------------------------------------------- */
 
 // Download uses the provided mirror to download the specified artifact and
-// returns an 'artifact.Local' wrapper pointing to it.
+// returns an 'artifact.Local' wrapper pointing to it. Mirrors configured in the
+// store at 'storePath' are used, if any (see 'store.Mirrors').
 func Download[T artifact.Artifact](
 	ctx context.Context,
+	storePath string,
 	a T,
 	out string,
 ) (artifact.Local[T], error) {
+	local, _, err := downloadWithProvenance(ctx, storePath, a, out)
+
+	return local, err
+}
+
+/* --------------------- Function: downloadWithProvenance ------------------- */
+
+// downloadWithProvenance downloads the specified artifact (see 'Download') and
+// also returns the mirror and URL it was downloaded from.
+func downloadWithProvenance[T artifact.Artifact]( //nolint:cyclop,funlen
+	ctx context.Context,
+	storePath string,
+	a T,
+	out string,
+) (artifact.Local[T], store.Provenance, error) {
 	var local artifact.Local[T]
 
+	var provenance store.Provenance
+
 	if err := checkIsDirectory(out); err != nil {
-		return local, err
+		return local, provenance, err
 	}
 
 	log.Infof("selecting mirror for artifact: %s", a.Name())
 
-	m, err := mirror.Select(ctx, availableMirrors[T](), a)
+	mirrors, err := availableMirrors[T](storePath)
+	if err != nil {
+		return local, provenance, err
+	}
+
+	if IsOffline() {
+		mirrors = mirror.FilterLocal(mirrors)
+		if len(mirrors) == 0 {
+			return local, provenance, fmt.Errorf(
+				"%w: no local mirrors configured; set '%s' to a 'file://' URL",
+				ErrOffline,
+				mirror.EnvMirrors,
+			)
+		}
+	}
+
+	m, err := mirror.Select(ctx, mirrors, a)
 	if err != nil {
-		return local, err
+		if IsOffline() && errors.Is(err, mirror.ErrNotFound) {
+			return local, provenance, fmt.Errorf("%w: artifact not found in local mirrors: %s: %w", ErrOffline, a.Name(), err)
+		}
+
+		return local, provenance, err
 	}
 
 	log.Infof("downloading '%s' from mirror: %s", a.Name(), m.Name())
 
 	remote, err := m.Remote(a)
 	if err != nil {
-		return local, err
+		return local, provenance, err
 	}
 
-	c := client.NewWithRedirectDomains(m.Hosts()...)
-
 	out = filepath.Join(out, remote.Artifact.Name())
 
-	p, ok := ctx.Value(progressKey[T]{}).(*progress.Progress)
-	if ok && p != nil {
-		ctx = client.WithProgress(ctx, p)
-	}
+	p, _ := ctx.Value(progressKey[T]{}).(*progress.Progress)
+
+	if remote.URL.Scheme == mirror.SchemeFile {
+		if err := copyFrom(ctx, mirror.PathFromURL(remote.URL), out, p); err != nil {
+			return local, provenance, err
+		}
+	} else {
+		if p != nil {
+			ctx = client.WithProgress(ctx, p)
+		}
+
+		c := client.NewWithRedirectDomains(m.Hosts()...)
 
-	if err := c.DownloadTo(ctx, remote.URL, out); err != nil {
-		return local, err
+		if err := c.DownloadTo(ctx, remote.URL, out); err != nil {
+			return local, provenance, err
+		}
 	}
 
 	log.Debugf("downloaded artifact: %s", out)
@@ -80,20 +153,132 @@ func Download[T artifact.Artifact](
 	local.Artifact = remote.Artifact
 	local.Path = out
 
-	return local, nil
+	provenance.Mirror = m.Name()
+	provenance.URL = remote.URL.String()
+
+	return local, provenance, nil
 }
 
 /* -------------------------------------------------------------------------- */
 /*                         Function: availableMirrors                         */
 /* -------------------------------------------------------------------------- */
 
-// availableMirrors returns the list of possible 'Mirror' hosts.
-func availableMirrors[T artifact.Artifact]() []mirror.Mirror[T] {
-	// TODO: Re-enable TuxFamily once mirror selection can eagerly accept the
-	// first-to-return mirror instead of waiting for all mirrors to finish. This
-	// avoid the problem where TuxFamily, which often doesn't return a response
-	// until its request context times out, causes delays when downloading.
-	return []mirror.Mirror[T]{mirror.GitHub[T]{}}
+// availableMirrors returns the ranked list of possible 'Mirror' hosts. Mirrors
+// are configured by the 'mirror.EnvMirrors' environment variable or, if that's
+// unset, a 'mirrors.json' file in the store at 'storePath'. If neither is set,
+// then the default mirrors are used.
+//
+// NOTE: TuxFamily is not used by default. Mirror selection waits for all
+// mirrors to respond, and TuxFamily often doesn't return a response until its
+// request context times out, which causes delays when downloading.
+func availableMirrors[T artifact.Artifact](storePath string) ([]mirror.Mirror[T], error) {
+	c, err := mirrorConfig(storePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return mirror.FromConfig[T](c)
+}
+
+/* -------------------------- Function: mirrorConfig ------------------------ */
+
+// mirrorConfig returns the user's mirror configuration.
+func mirrorConfig(storePath string) (mirror.Config, error) {
+	if list := os.Getenv(mirror.EnvMirrors); list != "" {
+		log.Debugf("using mirrors from environment: %s", list)
+
+		return mirror.ParseList(list)
+	}
+
+	if storePath == "" {
+		return mirror.Default(), nil
+	}
+
+	path, err := store.Mirrors(storePath)
+	if err != nil {
+		return mirror.Config{}, err
+	}
+
+	c, err := mirror.ReadConfig(path)
+	if err != nil {
+		if !errors.Is(err, mirror.ErrMissingConfig) {
+			return mirror.Config{}, err
+		}
+
+		return mirror.Default(), nil
+	}
+
+	log.Debugf("using mirrors from config file: %s", path)
+
+	return c, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: copyFrom                             */
+/* -------------------------------------------------------------------------- */
+
+// copyFrom copies an artifact hosted on the local filesystem to 'out'. If 'p'
+// is not nil, then copy progress is reported to it.
+func copyFrom(ctx context.Context, src, out string, p *progress.Progress) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+
+	defer dst.Close()
+
+	var w io.Writer = dst
+
+	if p != nil && info.Size() > 0 {
+		if err := p.SetTotal(uint64(info.Size())); err != nil { //nolint:gosec
+			return err
+		}
+
+		w = io.MultiWriter(dst, progress.NewWriter(p))
+	}
+
+	_, err = io.Copy(w, ioutil.NewReaderWithContext(ctx, f.Read))
+
+	return err
+}
+
+/* -------------------------------------------------------------------------- */
+/*                       Function: completeProvenance                         */
+/* -------------------------------------------------------------------------- */
+
+// completeProvenance records the checksum and size of a downloaded archive,
+// which must have already been validated against 'localChecksums'.
+func completeProvenance[T artifact.Artifact, U checksum.Checksums[T]](
+	ctx context.Context,
+	provenance store.Provenance,
+	localArtifact artifact.Local[T],
+	localChecksums artifact.Local[U],
+) (store.Provenance, error) {
+	value, err := checksum.Extract[T](ctx, localChecksums, localArtifact.Artifact)
+	if err != nil {
+		return store.Provenance{}, err
+	}
+
+	info, err := os.Stat(localArtifact.Path)
+	if err != nil {
+		return store.Provenance{}, err
+	}
+
+	provenance.Checksum, provenance.Size = value, info.Size()
+
+	return provenance, nil
 }
 
 /* -------------------------------------------------------------------------- */
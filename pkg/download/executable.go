@@ -3,11 +3,9 @@ package download
 import (
 	"context"
 
-	"golang.org/x/sync/errgroup"
-
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
-	"github.com/coffeebeats/gdenv/pkg/godot/artifact/checksum"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/store"
 )
 
 /* -------------------------------------------------------------------------- */
@@ -15,66 +13,19 @@ import (
 /* -------------------------------------------------------------------------- */
 
 // ExecutableWithChecksumValidation downloads an executable archive and
-// validates that its checksum matches the published value.
+// validates that its checksum matches the published value. The provenance of
+// the archive is also returned so that it can be recorded in the store. If
+// enabled, the download cache is checked first (see 'EnvCacheSize').
 func ExecutableWithChecksumValidation(
 	ctx context.Context,
+	storePath string,
 	ex executable.Executable,
 	out string,
-) (artifact.Local[executable.Archive], error) {
-	chArchive := make(chan artifact.Local[executable.Archive], 1)
-	defer close(chArchive)
-
-	chChecksums := make(chan artifact.Local[executable.Checksums], 1)
-	defer close(chChecksums)
-
-	eg, ctxDownload := errgroup.WithContext(ctx)
-
-	eg.Go(func() error {
-		exArchive := executable.Archive{Inner: ex}
-
-		result, err := Download(ctxDownload, exArchive, out)
-		if err != nil {
-			return err
-		}
-
-		select {
-		case chArchive <- result:
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-
-		return nil
-	})
-
-	eg.Go(func() error {
-		checksums, err := executable.NewChecksums(ex.Version())
-		if err != nil {
-			return err
-		}
-
-		result, err := Download(ctxDownload, checksums, out)
-		if err != nil {
-			return err
-		}
-
-		select {
-		case chChecksums <- result:
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-
-		return nil
-	})
-
-	if err := eg.Wait(); err != nil {
-		return artifact.Local[executable.Archive]{}, err
-	}
-
-	exArchive, exArchiveChecksums := <-chArchive, <-chChecksums
-
-	if err := checksum.Compare(ctx, exArchive, exArchiveChecksums); err != nil {
-		return artifact.Local[executable.Archive]{}, err
+) (artifact.Local[executable.Archive], store.Provenance, error) {
+	checksums, err := executable.NewChecksums(ex.Version())
+	if err != nil {
+		return artifact.Local[executable.Archive]{}, store.Provenance{}, err
 	}
 
-	return exArchive, nil
+	return downloadWithChecksumValidation(ctx, storePath, executable.Archive{Inner: ex}, checksums, out)
 }
@@ -3,12 +3,10 @@ package download
 import (
 	"context"
 
-	"golang.org/x/sync/errgroup"
-
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
-	"github.com/coffeebeats/gdenv/pkg/godot/artifact/checksum"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
 )
 
 /* -------------------------------------------------------------------------- */
@@ -16,66 +14,19 @@ import (
 /* -------------------------------------------------------------------------- */
 
 // SourceWithChecksumValidation downloads a source code archive and validates
-// that its checksum matches the published value.
+// that its checksum matches the published value. The provenance of the archive
+// is also returned so that it can be recorded in the store. If enabled, the
+// download cache is checked first (see 'EnvCacheSize').
 func SourceWithChecksumValidation(
 	ctx context.Context,
+	storePath string,
 	v version.Version,
 	out string,
-) (artifact.Local[source.Archive], error) {
-	chArchive := make(chan artifact.Local[source.Archive], 1)
-	defer close(chArchive)
-
-	chChecksums := make(chan artifact.Local[source.Checksums], 1)
-	defer close(chChecksums)
-
-	eg, ctxDownload := errgroup.WithContext(ctx)
-
-	eg.Go(func() error {
-		srcArchive := source.Archive{Inner: source.New(v)}
-
-		result, err := Download(ctxDownload, srcArchive, out)
-		if err != nil {
-			return err
-		}
-
-		select {
-		case chArchive <- result:
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-
-		return nil
-	})
-
-	eg.Go(func() error {
-		checksums, err := source.NewChecksums(v)
-		if err != nil {
-			return err
-		}
-
-		result, err := Download(ctxDownload, checksums, out)
-		if err != nil {
-			return err
-		}
-
-		select {
-		case chChecksums <- result:
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-
-		return nil
-	})
-
-	if err := eg.Wait(); err != nil {
-		return artifact.Local[source.Archive]{}, err
-	}
-
-	srcArchive, checksums := <-chArchive, <-chChecksums
-
-	if err := checksum.Compare(ctx, srcArchive, checksums); err != nil {
-		return artifact.Local[source.Archive]{}, err
+) (artifact.Local[source.Archive], store.Provenance, error) {
+	checksums, err := source.NewChecksums(v)
+	if err != nil {
+		return artifact.Local[source.Archive]{}, store.Provenance{}, err
 	}
 
-	return srcArchive, nil
+	return downloadWithChecksumValidation(ctx, storePath, source.Archive{Inner: source.New(v)}, checksums, out)
 }
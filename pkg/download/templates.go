@@ -0,0 +1,32 @@
+package download
+
+import (
+	"context"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                  Function: TemplatesWithChecksumValidation                 */
+/* -------------------------------------------------------------------------- */
+
+// TemplatesWithChecksumValidation downloads an export templates archive and
+// validates that its checksum matches the published value. The provenance of
+// the archive is also returned so that it can be recorded in the store. If
+// enabled, the download cache is checked first (see 'EnvCacheSize').
+func TemplatesWithChecksumValidation(
+	ctx context.Context,
+	storePath string,
+	v version.Version,
+	out string,
+) (artifact.Local[templates.Archive], store.Provenance, error) {
+	checksums, err := templates.NewChecksums(v)
+	if err != nil {
+		return artifact.Local[templates.Archive]{}, store.Provenance{}, err
+	}
+
+	return downloadWithChecksumValidation(ctx, storePath, templates.Archive{Inner: templates.New(v)}, checksums, out)
+}
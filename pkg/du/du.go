@@ -0,0 +1,219 @@
+package du
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+const (
+	KindExecutable = "executable"
+	KindSource     = "source"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                                Struct: Entry                               */
+/* -------------------------------------------------------------------------- */
+
+// Entry describes the disk usage of a single installed artifact.
+type Entry struct {
+	// Kind is the type of the artifact; either 'executable' or 'source'.
+	Kind string `json:"kind"`
+	// Version is the artifact's version.
+	Version string `json:"version"`
+	// Platform is the executable's platform label (e.g. 'linux.x86_64'); empty
+	// for source code.
+	Platform string `json:"platform,omitempty"`
+	// Mono is whether the artifact is a Mono (C#) build of Godot.
+	Mono bool `json:"mono"`
+	// Store is the path of the store in which the artifact is installed (see
+	// 'store.Layer').
+	Store string `json:"store"`
+	// Size is the total size in bytes of the artifact's installed files.
+	Size int64 `json:"size"`
+
+	artifact artifact.Artifact
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Struct: Totals                               */
+/* -------------------------------------------------------------------------- */
+
+// Totals summarizes the disk usage of all installed artifacts.
+type Totals struct {
+	// Executables is the total size in bytes of all executables.
+	Executables int64 `json:"executables"`
+	// Mono is the total size in bytes of Mono (C#) executables.
+	Mono int64 `json:"mono"`
+	// Standard is the total size in bytes of non-Mono executables.
+	Standard int64 `json:"standard"`
+	// Sources is the total size in bytes of all source code archives.
+	Sources int64 `json:"sources"`
+	// All is the total size in bytes of all installed artifacts.
+	All int64 `json:"all"`
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Struct: Report                               */
+/* -------------------------------------------------------------------------- */
+
+// Report describes the disk usage of the artifacts installed in a store. Each
+// list of entries is sorted from largest to smallest.
+type Report struct {
+	Executables []Entry `json:"executables"`
+	Sources     []Entry `json:"sources"`
+	Totals      Totals  `json:"totals"`
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Scan                               */
+/* -------------------------------------------------------------------------- */
+
+// Scan computes the disk usage of all executables and source code installed in
+// the store, including those installed in other stores (see 'store.Layers').
+// Sizes are computed concurrently; the scan stops early if the context is
+// canceled.
+func Scan(ctx context.Context, storePath string) (Report, error) {
+	executables, err := store.Executables(ctx, storePath)
+	if err != nil {
+		return Report{}, err
+	}
+
+	sources, err := store.Sources(ctx, storePath)
+	if err != nil {
+		return Report{}, err
+	}
+
+	r := Report{
+		Executables: make([]Entry, 0, len(executables)),
+		Sources:     make([]Entry, 0, len(sources)),
+		Totals:      Totals{Executables: 0, Mono: 0, Standard: 0, Sources: 0, All: 0},
+	}
+
+	for _, ex := range executables {
+		platformLabel, err := platform.Format(ex.Artifact.Platform(), ex.Artifact.Version())
+		if err != nil {
+			return Report{}, err
+		}
+
+		r.Executables = append(r.Executables, newEntry(KindExecutable, ex.Artifact, ex.Artifact.Version(), platformLabel))
+	}
+
+	for _, src := range sources {
+		r.Sources = append(r.Sources, newEntry(KindSource, src.Artifact.Inner, src.Artifact.Version(), ""))
+	}
+
+	if err := measure(ctx, storePath, r.Executables, r.Sources); err != nil {
+		return Report{}, err
+	}
+
+	for _, e := range r.Executables {
+		r.Totals.Executables += e.Size
+
+		if e.Mono {
+			r.Totals.Mono += e.Size
+		} else {
+			r.Totals.Standard += e.Size
+		}
+	}
+
+	for _, e := range r.Sources {
+		r.Totals.Sources += e.Size
+	}
+
+	r.Totals.All = r.Totals.Executables + r.Totals.Sources
+
+	sortEntries(r.Executables)
+	sortEntries(r.Sources)
+
+	return r, nil
+}
+
+/* ---------------------------- Function: newEntry -------------------------- */
+
+// newEntry creates an 'Entry' for the artifact; its size is computed later.
+func newEntry(kind string, a artifact.Artifact, v version.Version, platformLabel string) Entry {
+	return Entry{
+		Kind:     kind,
+		Version:  v.String(),
+		Platform: platformLabel,
+		Mono:     isMono(v),
+		Store:    "",
+		Size:     0,
+
+		artifact: a,
+	}
+}
+
+/* ---------------------------- Function: measure --------------------------- */
+
+// measure concurrently computes the size and store of each of the entries,
+// updating them in place. The first error cancels all remaining work.
+func measure(ctx context.Context, storePath string, entries ...[]Entry) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(runtime.NumCPU())
+
+	for _, ee := range entries {
+		for i := range ee {
+			e := &ee[i]
+
+			eg.Go(func() error {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				layer, err := store.Layer(storePath, e.artifact)
+				if err != nil {
+					return err
+				}
+
+				size, err := store.Size(ctx, storePath, e.artifact)
+				if err != nil {
+					return err
+				}
+
+				e.Store, e.Size = layer, size
+
+				return nil
+			})
+		}
+	}
+
+	return eg.Wait()
+}
+
+/* -------------------------- Function: sortEntries ------------------------- */
+
+// sortEntries orders entries from largest to smallest, breaking ties by
+// version and then platform.
+func sortEntries(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+
+		switch {
+		case a.Size != b.Size:
+			return a.Size > b.Size
+		case a.Version != b.Version:
+			return version.Compare(a.artifact.Version(), b.artifact.Version()) < 0
+		default:
+			return a.Platform < b.Platform
+		}
+	})
+}
+
+/* ----------------------------- Function: isMono --------------------------- */
+
+// isMono returns whether the version denotes a Mono (C#) build of Godot. Unlike
+// 'version.Version.IsMono', this includes pre-release Mono builds (e.g.
+// '4.3-beta1_mono').
+func isMono(v version.Version) bool {
+	return strings.HasSuffix(v.Label(), "_"+version.Mono)
+}
@@ -0,0 +1,167 @@
+package du
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* ------------------------------- Test: Scan ------------------------------- */
+
+func TestScan(t *testing.T) {
+	linux := executable.MustParse("Godot_v4.0-stable_linux.x86_64")
+	mono := executable.MustParse("Godot_v4.1-stable_mono_linux.x86_64")
+	windows := executable.MustParse("Godot_v4.0-stable_win64.exe")
+
+	tests := []struct {
+		name  string
+		files []fstest.Writer
+
+		want Report
+		err  error
+	}{
+		{
+			name: "empty store returns empty report",
+
+			want: Report{Executables: []Entry{}, Sources: []Entry{}, Totals: Totals{}},
+		},
+		{
+			name: "sizes are reported per version, platform and kind",
+			files: []fstest.Writer{
+				fstest.File{Path: executablePath(t, linux), Contents: "ab"},
+				fstest.File{Path: filepath.Join(filepath.Dir(executablePath(t, linux)), ".gdenv-manifest.json"), Contents: "c"},
+				fstest.File{Path: executablePath(t, windows), Contents: "abcd"},
+				fstest.File{Path: executablePath(t, mono), Contents: "abcdefgh"},
+				fstest.File{
+					Path:     filepath.Join("src", "v4.0-stable", "godot-4.0-stable.tar.xz"),
+					Contents: "abcdefghij",
+				},
+			},
+
+			want: Report{
+				Executables: []Entry{
+					{Kind: KindExecutable, Version: "v4.1-stable_mono", Platform: "linux_x86_64", Mono: true, Size: 8},
+					{Kind: KindExecutable, Version: "v4.0-stable", Platform: "win64", Size: 4},
+					{Kind: KindExecutable, Version: "v4.0-stable", Platform: "linux.x86_64", Size: 2},
+				},
+				Sources: []Entry{
+					{Kind: KindSource, Version: "v4.0-stable", Size: 10},
+				},
+				Totals: Totals{Executables: 14, Mono: 8, Standard: 6, Sources: 10, All: 24},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			t.Setenv("GDENV_PATH", "")
+
+			// Given: The specified files exist in the store.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The store's disk usage is computed.
+			got, err := Scan(context.Background(), tmp)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected report is returned.
+			for i := range got.Executables {
+				if got.Executables[i].Store != tmp {
+					t.Errorf("store: got %v, want %v", got.Executables[i].Store, tmp)
+				}
+
+				got.Executables[i].Store, got.Executables[i].artifact = "", nil
+			}
+
+			for i := range got.Sources {
+				if got.Sources[i].Store != tmp {
+					t.Errorf("store: got %v, want %v", got.Sources[i].Store, tmp)
+				}
+
+				got.Sources[i].Store, got.Sources[i].artifact = "", nil
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("output: got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ---------------------------- Test: ScanCanceled -------------------------- */
+
+func TestScanCanceled(t *testing.T) {
+	tmp := t.TempDir()
+
+	t.Setenv("GDENV_PATH", "")
+
+	// Given: An executable is installed in the store.
+	ex := executable.MustParse("Godot_v4.0-stable_linux.x86_64")
+	fstest.File{Path: executablePath(t, ex)}.Write(t, tmp)
+
+	// Given: A canceled context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// When: The store's disk usage is computed.
+	_, err := Scan(ctx, tmp)
+
+	// Then: The cancellation is reported.
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err: got %v, want %v", err, context.Canceled)
+	}
+}
+
+/* ------------------------ Function: executablePath ------------------------ */
+
+// executablePath returns the path of the executable relative to the store.
+func executablePath(t *testing.T, ex executable.Executable) string {
+	t.Helper()
+
+	label, err := platform.Format(ex.Platform(), ex.Version())
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	return filepath.Join("editor", ex.Version().String(), label, ex.Path())
+}
+
+/* ------------------------------ Test: isMono ------------------------------ */
+
+func TestIsMono(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "4.0", want: false},
+		{version: "4.0-stable_mono", want: true},
+		{version: "4.3-beta1_mono", want: true},
+		{version: "4.3-beta1", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.version, func(t *testing.T) {
+			// When: The version is checked for being a Mono build.
+			got := isMono(version.MustParse(tc.version))
+
+			// Then: The expected value is returned.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
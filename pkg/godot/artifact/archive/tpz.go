@@ -0,0 +1,51 @@
+package archive
+
+import (
+	"context"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+const extensionTPZ = ".tpz"
+
+/* -------------------------------------------------------------------------- */
+/*                                 Struct: TPZ                                */
+/* -------------------------------------------------------------------------- */
+
+// A struct representing a 'zip'-compressed archive which uses Godot's '.tpz'
+// file extension (i.e. export templates).
+type TPZ[T Archivable] struct {
+	Inner T
+}
+
+/* ------------------------- Impl: artifact.Artifact ------------------------ */
+
+// Artifact "registers" 'TPZ' as a Godot release artifact.
+func (a TPZ[T]) Artifact() {}
+
+/* -------------------------- Impl: artifact.Named -------------------------- */
+
+func (a TPZ[T]) Name() string {
+	name := a.Inner.Name()
+	if name != "" {
+		name += extensionTPZ
+	}
+
+	return name
+}
+
+/* ------------------------ Impl: artifact.Versioned ------------------------ */
+
+func (a TPZ[T]) Version() version.Version {
+	return a.Inner.Version()
+}
+
+/* ------------------------------ Impl: Archive ----------------------------- */
+
+// Extracts the archived contents to the specified directory.
+//
+// NOTE: A '.tpz' file is a 'zip' archive with a different extension, so this
+// simply delegates to the 'Zip' implementation.
+func (a TPZ[T]) extract(ctx context.Context, path, out string) error {
+	return Zip[T](a).extract(ctx, path, out)
+}
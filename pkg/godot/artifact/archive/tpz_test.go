@@ -0,0 +1,79 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/internal/osutil"
+)
+
+/* ------------------------ Function: TestTPZExtract ------------------------ */
+
+func TestTPZExtract(t *testing.T) {
+	tests := []struct {
+		name     string
+		artifact fstest.Writer
+
+		want []fstest.Asserter
+		err  error
+	}{
+		// Invalid inputs
+		{
+			name:     "missing archive returns file not found",
+			artifact: fstest.Absent{Path: "archive.tpz"},
+
+			err: os.ErrNotExist,
+		},
+
+		// Valid inputs
+		{
+			name: "nested files can be extracted",
+			artifact: fstest.Zip{
+				Path: "archive.tpz",
+				// Relative to archive file.
+				Contents: []fstest.Writer{
+					fstest.Dir{Path: "templates"},
+					fstest.File{Path: "templates/version.txt", Contents: "4.3.stable"},
+				},
+			},
+
+			// Relative to extraction directory.
+			want: []fstest.Asserter{
+				fstest.Dir{Path: "templates"},
+				fstest.File{Path: "templates/version.txt", Contents: "4.3.stable"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			// Given: A directory to extract into.
+			out := filepath.Join(tmp, "extract")
+			if err := os.Mkdir(out, osutil.ModeUserRWX); err != nil {
+				t.Fatal(err)
+			}
+
+			// Given: The specified archive exists on the file system.
+			tc.artifact.Write(t, tmp)
+
+			// When: The archive is extracted.
+			err := (TPZ[MockArtifact]{}).extract(context.Background(), tc.artifact.Abs(t, tmp), out)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("got: %v, want: %v", err, tc.err)
+			}
+
+			// Then: The expected files exist on the file system.
+			for _, f := range tc.want {
+				f.Assert(t, out)
+			}
+		})
+	}
+}
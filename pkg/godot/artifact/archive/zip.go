@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"context"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -141,6 +142,12 @@ func extractZipFile(
 		return err
 	}
 
+	defer src.Close()
+
+	if mode&fs.ModeSymlink != 0 {
+		return extractZipSymlink(src, f.Name, out)
+	}
+
 	if err := copyFile(ctx, src, mode, out); err != nil {
 		return err
 	}
@@ -148,6 +155,27 @@ func extractZipFile(
 	return nil
 }
 
+/* ----------------------- Function: extractZipSymlink ---------------------- */
+
+// extractZipSymlink creates a symbolic link at 'out' for the zip archive entry
+// 'name', whose contents are the link's target. The target must be relative
+// and remain within the archive's contents (e.g. links within a macOS
+// application bundle).
+func extractZipSymlink(src io.Reader, name, out string) error {
+	bb, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+
+	target := string(bb)
+
+	if filepath.IsAbs(target) || !filepath.IsLocal(filepath.Join(filepath.Dir(name), target)) {
+		return fmt.Errorf("%w: %s -> %s", zip.ErrInsecurePath, name, target)
+	}
+
+	return os.Symlink(target, out)
+}
+
 /* ------------------------ Function: newZipProgress ------------------------ */
 
 // newZipProgress sets the 'total' value of the 'progress.Progress' instance
@@ -1,9 +1,11 @@
 package archive
 
 import (
+	"archive/zip"
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -158,6 +160,102 @@ func TestZipExtract(t *testing.T) {
 	}
 }
 
+/* --------------------- Function: TestZipExtractSymlink -------------------- */
+
+func TestZipExtractSymlink(t *testing.T) {
+	tests := []struct {
+		name   string
+		link   string
+		target string
+
+		err error
+	}{
+		{name: "relative link is extracted", link: "a/link", target: "../godot.exe"},
+		{name: "absolute link returns an error", link: "a/link", target: "/etc/passwd", err: zip.ErrInsecurePath},
+		{name: "link outside the archive returns an error", link: "a/link", target: "../../godot.exe", err: zip.ErrInsecurePath},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			// Given: A directory to extract into.
+			out := filepath.Join(tmp, "extract")
+			if err := os.Mkdir(out, osutil.ModeUserRWX); err != nil {
+				t.Fatal(err)
+			}
+
+			// Given: An archive containing a file and a symbolic link.
+			path := filepath.Join(tmp, "archive.zip")
+			writeZipWithSymlink(t, path, tc.link, tc.target)
+
+			// When: The archive is extracted.
+			err := (Zip[MockArtifact]{}).extract(context.Background(), path, out)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("got: %v, want: %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			// Then: The symbolic link is recreated.
+			got, err := os.Readlink(filepath.Join(out, tc.link))
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			if got != tc.target {
+				t.Errorf("output: got %v, want %v", got, tc.target)
+			}
+		})
+	}
+}
+
+/* --------------------- Function: writeZipWithSymlink ---------------------- */
+
+// writeZipWithSymlink writes a zip archive to 'path' which contains a file and
+// a symbolic link stored like the 'zip' tool does (i.e. the target is stored as
+// the entry's contents).
+func writeZipWithSymlink(t *testing.T, path, link, target string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+
+	for name, entry := range map[string]struct {
+		mode     fs.FileMode
+		contents string
+	}{
+		"godot.exe": {mode: osutil.ModeUserRW, contents: "godot"},
+		link:        {mode: fs.ModeSymlink | osutil.ModeUserRWX, contents: target},
+	} {
+		h := &zip.FileHeader{Name: name, Method: zip.Store}
+		h.SetMode(entry.mode)
+
+		dst, err := w.CreateHeader(h)
+		if err != nil {
+			t.Fatalf("test setup: %v", err)
+		}
+
+		if _, err := dst.Write([]byte(entry.contents)); err != nil {
+			t.Fatalf("test setup: %v", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+}
+
 /* ------------------ Function: TestZipExtractWithProgress ------------------ */
 
 func TestZipExtractWithProgress(t *testing.T) {
@@ -42,6 +42,12 @@ func TestName(t *testing.T) {
 		{platform: linux32(), version: "4.0-stable_mono", want: "Godot_v4.0-stable_mono_linux_x86_32"},
 		{platform: linux64(), version: "4.0-stable_mono", want: "Godot_v4.0-stable_mono_linux_x86_64"},
 
+		// v4.2
+		{platform: linuxArm32(), version: "4.1", want: ""},
+		{platform: linuxArm32(), version: "4.2", want: "Godot_v4.2-stable_linux.arm32"},
+		{platform: linuxArm64(), version: "4.2", want: "Godot_v4.2-stable_linux.arm64"},
+		{platform: linuxArm64(), version: "4.2-stable_mono", want: "Godot_v4.2-stable_mono_linux_arm64"},
+
 		// v5.0-rc4
 		{platform: linux32(), version: "5.0-rc4", want: "Godot_v5.0-rc4_linux.x86_32"},
 		{platform: linux64(), version: "5.0-rc4", want: "Godot_v5.0-rc4_linux.x86_64"},
@@ -78,6 +84,11 @@ func TestName(t *testing.T) {
 		{platform: windows32(), version: "4.0-stable_mono", want: "Godot_v4.0-stable_mono_win32.exe"},
 		{platform: windows64(), version: "4.0-stable_mono", want: "Godot_v4.0-stable_mono_win64.exe"},
 
+		// v4.3
+		{platform: windowsArm64(), version: "4.2", want: ""},
+		{platform: windowsArm64(), version: "4.3", want: "Godot_v4.3-stable_windows_arm64.exe"},
+		{platform: windowsArm64(), version: "4.3-stable_mono", want: "Godot_v4.3-stable_mono_windows_arm64.exe"},
+
 		// v5.0-rc4
 		{platform: windows32(), version: "5.0-rc4", want: "Godot_v5.0-rc4_win32.exe"},
 		{platform: windows64(), version: "5.0-rc4", want: "Godot_v5.0-rc4_win64.exe"},
@@ -109,6 +120,16 @@ func linux64() platform.Platform {
 	return platform.Platform{Arch: platform.Amd64, OS: platform.Linux}
 }
 
+// Returns a 'Platform' struct for 32-bit ('ARM') 'Linux'.
+func linuxArm32() platform.Platform {
+	return platform.Platform{Arch: platform.Arm32, OS: platform.Linux}
+}
+
+// Returns a 'Platform' struct for 64-bit ('ARM') 'Linux'.
+func linuxArm64() platform.Platform {
+	return platform.Platform{Arch: platform.Arm64, OS: platform.Linux}
+}
+
 // Returns a 'Platform' struct for 64-bit ('x86') 'MacOS'.
 func macOSX86_64() platform.Platform {
 	return platform.Platform{Arch: platform.Amd64, OS: platform.MacOS}
@@ -133,3 +154,8 @@ func windows32() platform.Platform {
 func windows64() platform.Platform {
 	return platform.Platform{Arch: platform.Amd64, OS: platform.Windows}
 }
+
+// Returns a 'Platform' struct for 64-bit ('ARM') 'Windows'.
+func windowsArm64() platform.Platform {
+	return platform.Platform{Arch: platform.Arm64, OS: platform.Windows}
+}
@@ -48,9 +48,14 @@ func Parse(input string) (Executable, error) {
 	// the platform due to the "mono" version label containing the
 	// 'nameSeparator' rune. Fix that here by removing the 'mono' prefix from
 	// the platform and attaching it as a suffix to the version.
-	if strings.HasPrefix(parts[indexPlatform], version.Mono) {
-		parts[indexVersion] = parts[indexVersion] + nameSeparator + version.Mono
-		parts[indexPlatform] = strings.TrimPrefix(parts[indexPlatform], version.Mono+nameSeparator)
+	//
+	// NOTE: Custom builds will also have build metadata following the 'mono'
+	// prefix (e.g. 'mono+fork.1_linux.x86_64').
+	if rest, ok := strings.CutPrefix(parts[indexPlatform], version.Mono); ok {
+		build, platformLabel, _ := strings.Cut(rest, nameSeparator)
+
+		parts[indexVersion] = parts[indexVersion] + nameSeparator + version.Mono + build
+		parts[indexPlatform] = platformLabel
 	}
 
 	v, err := version.Parse(parts[indexVersion])
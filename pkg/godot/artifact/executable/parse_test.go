@@ -18,6 +18,10 @@ func TestParseExecutable(t *testing.T) {
 		v3     = version.MustParse("3.0.4-alpha1")
 		v4     = version.MustParse("4.0.11-dev.20230101")
 		v4Mono = version.MustParse("4.0-stable_mono")
+		v43    = version.MustParse("4.3")
+
+		v43Custom     = version.MustParse("4.3-stable+fork.1")
+		v43CustomMono = version.MustParse("4.3-stable_mono+fork.1")
 	)
 
 	tests := []struct {
@@ -39,6 +43,8 @@ func TestParseExecutable(t *testing.T) {
 		{s: "Godot_v3.0.4-alpha1_x11.32", want: Executable{v3, linux32()}},
 		{s: "Godot_v4.0.11-dev.20230101_x11.64", want: Executable{v4, linux64()}},
 		{s: "Godot_v4.0-stable_mono_linux_x86_64", want: Executable{v4Mono, linux64()}},
+		{s: "Godot_v4.3-stable_linux.arm32", want: Executable{v43, linuxArm32()}},
+		{s: "Godot_v4.3-stable_linux.arm64", want: Executable{v43, linuxArm64()}},
 
 		// Darwin
 		{s: "Godot_v1.0-stable_osx.fat", want: Executable{v1, macOSUniversal()}},
@@ -53,6 +59,12 @@ func TestParseExecutable(t *testing.T) {
 		{s: "Godot_v3.0.4-alpha1_win32", want: Executable{v3, windows32()}},
 		{s: "Godot_v4.0.11-dev.20230101_win64", want: Executable{v4, windows64()}},
 		{s: "Godot_v4.0-stable_mono_win64", want: Executable{v4Mono, windows64()}},
+		{s: "Godot_v4.3-stable_windows_arm64.exe", want: Executable{v43, windowsArm64()}},
+
+		// Custom builds
+		{s: "Godot_v4.3-stable+fork.1_linux.x86_64", want: Executable{v43Custom, linux64()}},
+		{s: "Godot_v4.3-stable_mono+fork.1_linux_x86_64", want: Executable{v43CustomMono, linux64()}},
+		{s: "Godot_v4.3-stable+fork.1_windows_arm64.exe", want: Executable{v43Custom, windowsArm64()}},
 	}
 
 	for i, tc := range tests {
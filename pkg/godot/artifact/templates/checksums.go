@@ -0,0 +1,75 @@
+package templates
+
+import (
+	"crypto/sha512"
+	"hash"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/checksum"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+const filenameChecksums = "SHA512-SUMS.txt"
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: Checksums                             */
+/* -------------------------------------------------------------------------- */
+
+// An 'Artifact' representing a Godot export templates checksums file.
+//
+// NOTE: Godot publishes export template checksums within the same file as the
+// executable archive checksums.
+type Checksums struct {
+	version version.Version
+}
+
+// Returns a new 'Checksums' struct after validating the Godot version.
+func NewChecksums(v version.Version) (Checksums, error) {
+	var c Checksums
+
+	if v.CompareNormal(versionTemplatesChecksumsSupported()) < 0 {
+		return c, checksum.ErrChecksumsUnsupported
+	}
+
+	c.version = v
+
+	return c, nil
+}
+
+/* ------------------------- Impl: artifact.Artifact ------------------------ */
+
+// Artifact "registers" 'Checksums' as a Godot release artifact.
+func (c Checksums) Artifact() {}
+
+/* -------------------------- Impl: artifact.Named -------------------------- */
+
+func (c Checksums) Name() string {
+	return filenameChecksums
+}
+
+/* ------------------------ Impl: artifact.Versioned ------------------------ */
+
+func (c Checksums) Version() version.Version {
+	return c.version
+}
+
+/* ------------------------ Impl: checksum.Checksums ------------------------ */
+
+// Supports "registers" 'Checksums' as containing checksums for the specified
+// artifact type.
+func (c Checksums) Supports(_ Archive) {}
+
+// Hash returns a new 'hash.Hash' for computing the file hash of an export
+// templates archive.
+func (c Checksums) Hash() hash.Hash {
+	return sha512.New()
+}
+
+/* -------------------------------------------------------------------------- */
+/*                Function: versionTemplatesChecksumsSupported                */
+/* -------------------------------------------------------------------------- */
+
+// Returns the first version at which 'SHA512-SUMS.txt' files for export
+// templates archives began being published.
+func versionTemplatesChecksumsSupported() version.Version {
+	return version.MustParse("3.2.2")
+}
@@ -0,0 +1,117 @@
+package templates
+
+import (
+	"strings"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/archive"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+const (
+	namePrefix    = "Godot"
+	nameSeparator = "_"
+	nameSuffix    = "export_templates"
+
+	dirnameArchiveContents = "templates"
+
+	dirnameSeparator = "."
+)
+
+type Archive = archive.TPZ[Templates]
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: Templates                             */
+/* -------------------------------------------------------------------------- */
+
+// An 'Artifact' representing the export templates for a specific version of
+// Godot. Note that "mono"-flavored templates are selected via the 'Version'
+// label, just like executables.
+type Templates struct {
+	version version.Version
+}
+
+// Compile-time verifications that 'Templates' implements 'Artifact'.
+var _ artifact.Artifact = (*Templates)(nil)
+
+/* ------------------------------ Function: New ----------------------------- */
+
+// Creates a new 'Templates' for the specified 'Version'.
+func New(v version.Version) Templates {
+	return Templates{v}
+}
+
+/* ----------------------------- Method: Dirname ---------------------------- */
+
+// Dirname returns the name of the directory from which the Godot editor loads
+// these export templates (e.g. '4.2.1.stable' or '4.3.stable.mono'). This
+// matches the contents of the 'version.txt' file included in the archive.
+//
+// NOTE: Godot omits a '0' patch version, but otherwise uses '.' as the only
+// separator between version components, the label, and the "mono" flavor.
+func (t Templates) Dirname() string {
+	normal, label, _ := strings.Cut(
+		strings.TrimPrefix(t.version.String(), version.Prefix),
+		version.SeparatorPreReleaseVersion,
+	)
+
+	name := normal + dirnameSeparator
+
+	// Godot places the "mono" flavor after the label (e.g. 'stable_mono' is
+	// written as 'stable.mono').
+	if l, ok := strings.CutSuffix(label, nameSeparator+version.Mono); ok {
+		return name + l + dirnameSeparator + version.Mono
+	}
+
+	return name + label
+}
+
+/* ------------------------------ Method: Path ------------------------------ */
+
+// Path returns the name of the directory, relative to the extracted archive,
+// which contains the export templates files.
+func (t Templates) Path() string {
+	return dirnameArchiveContents
+}
+
+/* ------------------------ Impl: archive.Archivable ------------------------ */
+
+// Allows 'Templates' to be used by 'Archive' implementation.
+func (t Templates) Archivable() {}
+
+/* ------------------------- Impl: artifact.Artifact ------------------------ */
+
+// Artifact "registers" 'Templates' as a Godot release artifact.
+func (t Templates) Artifact() {}
+
+/* -------------------------- Impl: artifact.Named -------------------------- */
+
+// Returns the name of the Godot export templates for the specified 'Version'.
+//
+// NOTE: Godot names its export templates in the format
+// 'Godot_<VERSION>_export_templates'.
+func (t Templates) Name() string {
+	var name strings.Builder
+
+	name.WriteString(namePrefix)
+	name.WriteString(nameSeparator)
+
+	name.WriteString(t.version.String())
+	name.WriteString(nameSeparator)
+
+	name.WriteString(nameSuffix)
+
+	return name.String()
+}
+
+/* ------------------------ Impl: artifact.Versioned ------------------------ */
+
+func (t Templates) Version() version.Version {
+	return t.version
+}
+
+/* ----------------------------- Impl: Stringer ----------------------------- */
+
+func (t Templates) String() string {
+	return t.Name()
+}
@@ -0,0 +1,59 @@
+package templates
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* -------------------------- Test: Templates.Name -------------------------- */
+
+func TestTemplatesName(t *testing.T) {
+	tests := []struct {
+		version version.Version
+		want    string
+	}{
+		// Valid inputs
+		{version: version.Godot3(), want: "Godot_v3.0-stable_export_templates"},
+		{version: version.MustParse("4.2.1"), want: "Godot_v4.2.1-stable_export_templates"},
+		{version: version.MustParse("4.3-rc1"), want: "Godot_v4.3-rc1_export_templates"},
+		{version: version.MustParse("4.3-stable_mono"), want: "Godot_v4.3-stable_mono_export_templates"},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d-'%s'", i, tc.version), func(t *testing.T) {
+			if got := New(tc.version).Name(); got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+
+			if got, want := (Archive{Inner: New(tc.version)}).Name(), tc.want+".tpz"; got != want {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+/* ------------------------ Test: Templates.Dirname ------------------------- */
+
+func TestTemplatesDirname(t *testing.T) {
+	tests := []struct {
+		version version.Version
+		want    string
+	}{
+		// Valid inputs
+		{version: version.Godot3(), want: "3.0.stable"},
+		{version: version.MustParse("4.2.1"), want: "4.2.1.stable"},
+		{version: version.MustParse("4.3-rc1"), want: "4.3.rc1"},
+		{version: version.MustParse("4.3-stable_mono"), want: "4.3.stable.mono"},
+		{version: version.MustParse("4.3-rc1_mono"), want: "4.3.rc1.mono"},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d-'%s'", i, tc.version), func(t *testing.T) {
+			if got := New(tc.version).Dirname(); got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package mirror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+)
+
+const (
+	// EnvMirrors is an environment variable which specifies a ranked, comma-
+	// separated list of mirrors. Each entry is either the name of a built-in
+	// mirror or a URL template (see 'Template').
+	EnvMirrors = "GDENV_MIRRORS"
+
+	NameGitHub    = "github"
+	NameTuxFamily = "tuxfamily"
+)
+
+var (
+	ErrInvalidConfig       = errors.New("invalid mirror config")
+	ErrMissingConfig       = errors.New("missing mirror config")
+	ErrUnrecognizedBuiltin = errors.New("unrecognized mirror")
+)
+
+/* -------------------------------------------------------------------------- */
+/*                                Struct: Spec                                */
+/* -------------------------------------------------------------------------- */
+
+// Spec describes a single configured mirror. If 'Path' is set then a 'Local'
+// mirror is created from the directory. Otherwise, if 'URL' is set then a
+// 'Template' mirror is created from the 'URL' template. If neither is set then
+// 'Name' must refer to a built-in mirror (see 'NameGitHub' and
+// 'NameTuxFamily').
+type Spec struct {
+	// Name is the display name of the mirror or the name of a built-in mirror.
+	Name string `json:"name"`
+
+	// URL is a URL template used to locate artifacts (see 'Template').
+	URL string `json:"url"`
+
+	// Hosts is a list of additional hosts the mirror may redirect to.
+	Hosts []string `json:"hosts"`
+
+	// Path is a local directory (or 'file://' URL) containing artifacts.
+	Path string `json:"path"`
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Struct: Config                               */
+/* -------------------------------------------------------------------------- */
+
+// Config is a ranked list of mirrors to download artifacts from. Earlier
+// mirrors are preferred over later ones.
+type Config struct {
+	Mirrors []Spec `json:"mirrors"`
+}
+
+/* ---------------------------- Function: Default --------------------------- */
+
+// Default returns the default mirror configuration.
+func Default() Config {
+	return Config{Mirrors: []Spec{{Name: NameGitHub}}} //nolint:exhaustruct
+}
+
+/* --------------------------- Function: ParseList -------------------------- */
+
+// ParseList parses a 'Config' from a comma-separated list of mirrors (see
+// 'EnvMirrors').
+func ParseList(input string) (Config, error) {
+	var c Config
+
+	for _, entry := range strings.Split(input, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if strings.HasPrefix(entry, SchemeFile+"://") {
+			c.Mirrors = append(c.Mirrors, Spec{Path: entry}) //nolint:exhaustruct
+
+			continue
+		}
+
+		if strings.Contains(entry, "://") {
+			c.Mirrors = append(c.Mirrors, Spec{URL: entry}) //nolint:exhaustruct
+
+			continue
+		}
+
+		c.Mirrors = append(c.Mirrors, Spec{Name: entry}) //nolint:exhaustruct
+	}
+
+	if len(c.Mirrors) == 0 {
+		return Config{}, fmt.Errorf("%w: '%s'", ErrInvalidConfig, input)
+	}
+
+	return c, nil
+}
+
+/* -------------------------- Function: ReadConfig -------------------------- */
+
+// ReadConfig parses a JSON-formatted 'Config' from the specified file.
+func ReadConfig(path string) (Config, error) {
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return Config{}, err
+		}
+
+		return Config{}, fmt.Errorf("%w: '%s'", ErrMissingConfig, path)
+	}
+
+	var c Config
+	if err := json.Unmarshal(bb, &c); err != nil {
+		return Config{}, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	if len(c.Mirrors) == 0 {
+		return Config{}, fmt.Errorf("%w: no mirrors specified: '%s'", ErrInvalidConfig, path)
+	}
+
+	return c, nil
+}
+
+/* --------------------------- Function: FromConfig ------------------------- */
+
+// FromConfig creates the list of mirrors described by the 'Config', in ranked
+// order (see 'Select').
+func FromConfig[T artifact.Artifact](c Config) ([]Mirror[T], error) {
+	out := make([]Mirror[T], 0, len(c.Mirrors))
+
+	for _, s := range c.Mirrors {
+		m, err := fromSpec[T](s)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, m)
+	}
+
+	return out, nil
+}
+
+/* --------------------------- Function: fromSpec --------------------------- */
+
+// fromSpec creates the 'Mirror' described by the 'Spec'.
+func fromSpec[T artifact.Artifact](s Spec) (Mirror[T], error) {
+	if s.Path != "" {
+		return NewLocal[T](s.Name, s.Path)
+	}
+
+	if strings.HasPrefix(s.URL, SchemeFile+"://") {
+		return NewLocal[T](s.Name, s.URL)
+	}
+
+	if s.URL != "" {
+		return NewTemplate[T](s.Name, s.URL, s.Hosts...)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(s.Name)) {
+	case NameGitHub:
+		return GitHub[T]{}, nil
+	case NameTuxFamily:
+		return TuxFamily[T]{}, nil
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnrecognizedBuiltin, s.Name)
+	}
+}
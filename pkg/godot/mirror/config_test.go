@@ -0,0 +1,166 @@
+package mirror
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+)
+
+/* ---------------------------- Test: ParseList ----------------------------- */
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		input string
+
+		want Config
+		err  error
+	}{
+		// Invalid inputs
+		{input: "", err: ErrInvalidConfig},
+		{input: " , ", err: ErrInvalidConfig},
+
+		// Valid inputs
+		{input: "github", want: Config{Mirrors: []Spec{{Name: "github"}}}},
+		{
+			input: "https://example.com/{tag}/{name}, tuxfamily",
+			want: Config{Mirrors: []Spec{
+				{URL: "https://example.com/{tag}/{name}"},
+				{Name: "tuxfamily"},
+			}},
+		},
+		{
+			input: "file:///srv/godot,github",
+			want: Config{Mirrors: []Spec{
+				{Path: "file:///srv/godot"},
+				{Name: "github"},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			// When: The list of mirrors is parsed.
+			got, err := ParseList(tc.input)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected config is returned.
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ---------------------------- Test: ReadConfig ---------------------------- */
+
+func TestReadConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string // An empty value means the file is missing.
+
+		want Config
+		err  error
+	}{
+		{name: "missing file returns an error", err: ErrMissingConfig},
+		{name: "invalid JSON returns an error", contents: "{", err: ErrInvalidConfig},
+		{name: "empty mirror list returns an error", contents: `{"mirrors": []}`, err: ErrInvalidConfig},
+		{
+			name:     "valid config is parsed",
+			contents: `{"mirrors": [{"name": "local", "url": "https://example.com/{name}", "hosts": ["cdn.example.com"]}, {"name": "github"}]}`,
+			want: Config{Mirrors: []Spec{
+				{Name: "local", URL: "https://example.com/{name}", Hosts: []string{"cdn.example.com"}},
+				{Name: "github"},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: A path to a mirror config file.
+			path := filepath.Join(t.TempDir(), "mirrors.json")
+
+			if tc.contents != "" {
+				if err := os.WriteFile(path, []byte(tc.contents), 0o600); err != nil {
+					t.Fatalf("test setup: %v", err)
+				}
+			}
+
+			// When: The config file is read.
+			got, err := ReadConfig(path)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected config is returned.
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ---------------------------- Test: FromConfig ---------------------------- */
+
+func TestFromConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+
+		want []string
+		err  error
+	}{
+		{
+			name:   "unrecognized builtin returns an error",
+			config: Config{Mirrors: []Spec{{Name: "unknown"}}},
+			err:    ErrUnrecognizedBuiltin,
+		},
+		{
+			name:   "invalid template returns an error",
+			config: Config{Mirrors: []Spec{{URL: "https://example.com/{tag}"}}},
+			err:    ErrInvalidTemplate,
+		},
+		{
+			name: "mirrors are created in order",
+			config: Config{Mirrors: []Spec{
+				{Name: "local", URL: "https://example.com/{name}"},
+				{Name: "GitHub"},
+				{Name: "tuxfamily"},
+				{Name: "offline", Path: "/srv/godot"},
+				{Name: "airgap", URL: "file:///srv/godot"},
+			}},
+			want: []string{"local", (GitHub[executable.Archive]{}).Name(), (TuxFamily[executable.Archive]{}).Name(), "offline", "airgap"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: Mirrors are created from the config.
+			got, err := FromConfig[executable.Archive](tc.config)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected mirrors are returned.
+			var names []string
+			for _, m := range got {
+				names = append(names, m.Name())
+			}
+
+			if !reflect.DeepEqual(names, tc.want) {
+				t.Errorf("output: got %v, want %v", names, tc.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
 )
 
@@ -47,6 +48,7 @@ func (m GitHub[T]) Remote(a T) (artifact.Remote[T], error) {
 	switch any(a).(type) { // FIXME: https://github.com/golang/go/issues/45380
 	case executable.Archive, executable.Checksums:
 	case source.Archive, source.Checksums:
+	case templates.Archive, templates.Checksums:
 	default:
 		return remote, fmt.Errorf("%w: %T", ErrUnsupportedArtifact, a)
 	}
@@ -74,23 +76,31 @@ func (m GitHub[T]) Name() string {
 
 // Returns a URL to the version-specific release containing release assets.
 func urlGitHubRelease(v version.Version) string {
-	// The release will be tagged as the "normal version", but a patch version
-	// of '0' will be dropped.
-	var normal string
-
-	switch v.Patch() {
-	case 0:
-		normal = fmt.Sprintf("%d.%d", v.Major(), v.Minor())
-	default:
-		normal = v.Normal()
-	}
-
-	tag := fmt.Sprintf("%s-%s", normal, v.Label())
-
-	releaseURL, err := url.JoinPath(gitHubAssetsURLBase, tag)
+	releaseURL, err := url.JoinPath(gitHubAssetsURLBase, releaseTag(v))
 	if err != nil {
 		panic(err) // This indicates an error in the asset URL base constant.
 	}
 
 	return releaseURL
 }
+
+/* -------------------------- Function: releaseTag -------------------------- */
+
+// Returns the name of the release which contains the specified version's assets
+// (e.g. '4.2.1-stable' or '4.3-beta1'). This naming scheme is shared by the
+// Godot GitHub repositories.
+func releaseTag(v version.Version) string {
+	return fmt.Sprintf("%s-%s", releaseVersion(v), v.Label())
+}
+
+/* ------------------------ Function: releaseVersion ------------------------ */
+
+// Returns the "normal version" of the specified version, but with a patch
+// version of '0' dropped (e.g. '4.2.1' or '4.3').
+func releaseVersion(v version.Version) string {
+	if v.Patch() == 0 {
+		return fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+	}
+
+	return v.Normal()
+}
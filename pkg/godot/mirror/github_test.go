@@ -10,6 +10,7 @@ import (
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/artifacttest"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
 )
 
@@ -34,6 +35,14 @@ func TestGitHubRemote(t *testing.T) {
 			artifact: executable.Archive{Inner: executable.MustParse("Godot_v4.1-stable_linux.x86_64")},
 			url:      mustParseURL(t, gitHubAssetsURLBase+"/4.1-stable/Godot_v4.1-stable_linux.x86_64.zip"),
 		},
+		{
+			artifact: executable.Archive{Inner: executable.MustParse("Godot_v4.2-stable_linux.arm64")},
+			url:      mustParseURL(t, gitHubAssetsURLBase+"/4.2-stable/Godot_v4.2-stable_linux.arm64.zip"),
+		},
+		{
+			artifact: executable.Archive{Inner: executable.MustParse("Godot_v4.3-stable_windows_arm64.exe")},
+			url:      mustParseURL(t, gitHubAssetsURLBase+"/4.3-stable/Godot_v4.3-stable_windows_arm64.exe.zip"),
+		},
 		{
 			artifact: source.Archive{Inner: source.New(version.MustParse("4.1.1-stable"))},
 			url:      mustParseURL(t, gitHubAssetsURLBase+"/4.1.1-stable/godot-4.1.1-stable.tar.xz"),
@@ -58,6 +67,14 @@ func TestGitHubRemote(t *testing.T) {
 			artifact: mustMakeNewSourceChecksum(t, version.MustParse("4.1.0-stable")),
 			url:      mustParseURL(t, gitHubAssetsURLBase+"/4.1-stable/godot-4.1-stable.tar.xz.sha256"),
 		},
+		{
+			artifact: templates.Archive{Inner: templates.New(version.MustParse("4.1.1-stable"))},
+			url:      mustParseURL(t, gitHubAssetsURLBase+"/4.1.1-stable/Godot_v4.1.1-stable_export_templates.tpz"),
+		},
+		{
+			artifact: templates.Archive{Inner: templates.New(version.MustParse("4.1.0-stable"))},
+			url:      mustParseURL(t, gitHubAssetsURLBase+"/4.1-stable/Godot_v4.1-stable_export_templates.tpz"),
+		},
 	}
 
 	for _, tc := range tests {
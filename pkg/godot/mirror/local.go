@@ -0,0 +1,165 @@
+package mirror
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
+)
+
+// SchemeFile is the URL scheme used by artifacts hosted by a 'Local' mirror.
+const SchemeFile = "file"
+
+var ErrInvalidPath = errors.New("invalid mirror path")
+
+/* -------------------------------------------------------------------------- */
+/*                                Struct: Local                               */
+/* -------------------------------------------------------------------------- */
+
+// A mirror implementation for fetching artifacts from a directory on the local
+// filesystem (e.g. for air-gapped installs). Artifacts are located within a
+// subdirectory named after the release tag (e.g. '4.2.1-stable/<name>') or, if
+// that doesn't exist, directly within the root directory.
+//
+// NOTE: Because each release's checksums file has the same name, checksums can
+// only be located within the release-specific subdirectory.
+type Local[T artifact.Artifact] struct {
+	name, root string
+}
+
+// Validate at compile-time that 'Local' implements 'Mirror' interfaces.
+var _ Hoster = (*Local[artifact.Artifact])(nil)
+var _ Remoter[artifact.Artifact] = (*Local[artifact.Artifact])(nil)
+
+/* --------------------------- Function: NewLocal --------------------------- */
+
+// NewLocal creates a new 'Local' mirror with the specified display name which
+// hosts artifacts within the directory 'root'. The 'root' path may also be
+// specified as a 'file://' URL.
+func NewLocal[T artifact.Artifact](name, root string) (Local[T], error) {
+	if u, err := url.Parse(root); err == nil && u.Scheme == SchemeFile {
+		root = PathFromURL(u)
+	}
+
+	if root == "" {
+		return Local[T]{}, fmt.Errorf("%w: missing path", ErrInvalidPath)
+	}
+
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return Local[T]{}, fmt.Errorf("%w: %w", ErrInvalidPath, err)
+	}
+
+	if name == "" {
+		name = root
+	}
+
+	return Local[T]{name: name, root: root}, nil
+}
+
+/* ------------------------------ Impl: Hoster ------------------------------ */
+
+// Hosts returns the host URLs at which artifacts are hosted. Because a 'Local'
+// mirror doesn't issue network requests, no hosts are returned.
+func (m Local[T]) Hosts() []string {
+	return nil
+}
+
+/* ------------------------------ Impl: Remoter ----------------------------- */
+
+// Remote returns an 'artifact.Remote' wrapper around a specified artifact. The
+// remote wrapper contains the 'file://' URL at which the artifact is located.
+func (m Local[T]) Remote(a T) (artifact.Remote[T], error) {
+	var remote artifact.Remote[T]
+
+	switch any(a).(type) { // FIXME: https://github.com/golang/go/issues/45380
+	case executable.Archive, executable.Checksums:
+	case source.Archive, source.Checksums:
+	case templates.Archive, templates.Checksums:
+	default:
+		return remote, fmt.Errorf("%w: %T", ErrUnsupportedArtifact, a)
+	}
+
+	path := filepath.Join(m.root, releaseTag(a.Version()), a.Name())
+
+	// Fall back to a flat directory layout, but only for artifacts with
+	// version-specific names.
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) && !isChecksums(a) {
+		path = filepath.Join(m.root, a.Name())
+	}
+
+	remote.Artifact, remote.URL = a, FileURL(path)
+
+	return remote, nil
+}
+
+/* ------------------------------ Impl: Mirror ------------------------------ */
+
+// Name returns the display name of the mirror.
+func (m Local[T]) Name() string {
+	return m.name
+}
+
+/* ------------------------- Function: FilterLocal -------------------------- */
+
+// FilterLocal returns the subset of the provided mirrors which host artifacts
+// on the local filesystem, preserving their order.
+func FilterLocal[T artifact.Artifact](mirrors []Mirror[T]) []Mirror[T] {
+	out := make([]Mirror[T], 0, len(mirrors))
+
+	for _, m := range mirrors {
+		if _, ok := m.(Local[T]); ok {
+			out = append(out, m)
+		}
+	}
+
+	return out
+}
+
+/* -------------------------- Function: PathFromURL ------------------------- */
+
+// PathFromURL returns the local filesystem path referred to by a 'file://' URL.
+func PathFromURL(u *url.URL) string {
+	path := u.Path
+
+	// On Windows, strip the leading slash before a volume name (e.g. '/C:/').
+	if trimmed := strings.TrimPrefix(path, "/"); filepath.VolumeName(trimmed) != "" {
+		path = trimmed
+	}
+
+	return filepath.FromSlash(path)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Function: FileURL                             */
+/* -------------------------------------------------------------------------- */
+
+// FileURL returns a 'file://' URL referring to the provided absolute path.
+func FileURL(path string) *url.URL {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return &url.URL{Scheme: SchemeFile, Path: path} //nolint:exhaustruct
+}
+
+/* -------------------------- Function: isChecksums ------------------------- */
+
+// isChecksums returns whether the artifact is a checksums file.
+func isChecksums(a artifact.Artifact) bool {
+	switch a.(type) {
+	case executable.Checksums, templates.Checksums:
+		return true
+	default:
+		return false
+	}
+}
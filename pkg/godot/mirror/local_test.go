@@ -0,0 +1,199 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/artifacttest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* ---------------------------- Test: NewLocal ------------------------------ */
+
+func TestNewLocal(t *testing.T) {
+	tests := []struct {
+		name string
+		root string
+
+		want string
+		err  error
+	}{
+		{name: "missing path returns an error", err: ErrInvalidPath},
+		{name: "absolute path is used", root: "/srv/godot", want: "/srv/godot"},
+		{name: "file URL is converted to a path", root: "file:///srv/godot", want: "/srv/godot"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: A new local mirror is created.
+			got, err := NewLocal[executable.Archive]("", tc.root)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The mirror is named after its root directory.
+			if got.Name() != filepath.FromSlash(tc.want) {
+				t.Errorf("output: got %v, want %v", got.Name(), tc.want)
+			}
+		})
+	}
+}
+
+/* ---------------------------- Test: Local.Remote -------------------------- */
+
+func TestLocalRemote(t *testing.T) {
+	// Given: A local directory containing release artifacts.
+	root := t.TempDir()
+
+	mustWriteFile(t, filepath.Join(root, "4.2-stable", "Godot_v4.2-stable_linux.x86_64.zip"))
+	mustWriteFile(t, filepath.Join(root, "Godot_v4.1-stable_linux.x86_64.zip"))
+
+	tests := []struct {
+		artifact artifact.Artifact
+
+		path string
+		err  error
+	}{
+		// Invalid inputs
+		{artifact: artifacttest.MockArtifact{}, err: ErrUnsupportedArtifact},
+
+		// Valid inputs
+		{
+			artifact: executable.Archive{Inner: executable.MustParse("Godot_v4.2-stable_linux.x86_64")},
+			path:     filepath.Join(root, "4.2-stable", "Godot_v4.2-stable_linux.x86_64.zip"),
+		},
+		{
+			artifact: executable.Archive{Inner: executable.MustParse("Godot_v4.1-stable_linux.x86_64")},
+			path:     filepath.Join(root, "Godot_v4.1-stable_linux.x86_64.zip"),
+		},
+		{
+			artifact: mustMakeNewExecutableChecksum(t, version.MustParse("4.1-stable")),
+			path:     filepath.Join(root, "4.1-stable", "SHA512-SUMS.txt"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.artifact.Name(), func(t *testing.T) {
+			m, err := NewLocal[artifact.Artifact]("", root)
+			if err != nil {
+				t.Fatalf("test setup: %v", err)
+			}
+
+			// When: The remote URL of the artifact is determined.
+			got, err := m.Remote(tc.artifact)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			// Then: The URL refers to the expected local file.
+			if got.URL.Scheme != SchemeFile || PathFromURL(got.URL) != tc.path {
+				t.Errorf("output: got %v, want %v", got.URL, tc.path)
+			}
+		})
+	}
+}
+
+/* ---------------------------- Test: Select (Local) ------------------------ */
+
+func TestSelectLocal(t *testing.T) {
+	// Given: An artifact to download.
+	a := executable.Archive{Inner: executable.MustParse("Godot_v4.3-stable_linux.x86_64")}
+
+	// Given: A local directory containing only the artifact.
+	root := t.TempDir()
+	mustWriteFile(t, filepath.Join(root, "4.3-stable", a.Name()))
+
+	missing, err := NewLocal[executable.Archive]("missing", t.TempDir())
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	available, err := NewLocal[executable.Archive]("available", root)
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		mirrors []Mirror[executable.Archive]
+
+		want Mirror[executable.Archive]
+		err  error
+	}{
+		{
+			name:    "no mirror hosts the artifact",
+			mirrors: []Mirror[executable.Archive]{missing},
+			err:     ErrNotFound,
+		},
+		{
+			name:    "available mirror is selected",
+			mirrors: []Mirror[executable.Archive]{missing, available},
+			want:    available,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: A mirror is selected for the artifact.
+			got, err := Select(context.Background(), tc.mirrors, a)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected mirror is selected.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* -------------------------- Test: FilterLocal ----------------------------- */
+
+func TestFilterLocal(t *testing.T) {
+	// Given: A local mirror.
+	local, err := NewLocal[executable.Archive]("local", t.TempDir())
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	// Given: A list of local and remote mirrors.
+	mirrors := []Mirror[executable.Archive]{GitHub[executable.Archive]{}, local, TuxFamily[executable.Archive]{}}
+
+	// When: The list of mirrors is filtered.
+	got := FilterLocal(mirrors)
+
+	// Then: Only the local mirror remains.
+	if len(got) != 1 || got[0] != local {
+		t.Errorf("output: got %v, want %v", got, []Mirror[executable.Archive]{local})
+	}
+}
+
+/* ------------------------- Function: mustWriteFile ------------------------ */
+
+func mustWriteFile(t *testing.T, path string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+}
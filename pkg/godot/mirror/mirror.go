@@ -3,6 +3,8 @@ package mirror
 import (
 	"context"
 	"errors"
+	"io/fs"
+	"os"
 	"slices"
 	"time"
 
@@ -137,6 +139,20 @@ func checkIfExists[T artifact.Artifact](
 		return false, err
 	}
 
+	// Artifacts hosted on the local filesystem don't require a request.
+	if remote.URL.Scheme == SchemeFile {
+		info, err := os.Stat(PathFromURL(remote.URL))
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return false, err
+			}
+
+			return false, nil
+		}
+
+		return info.Mode().IsRegular(), nil
+	}
+
 	// NOTE: It would be cleaner to expose this as an actual dependency, as an
 	// HTTP client *is* required. However, the internal 'client.Client'
 	// implementation is opinionated and not ready to be exposed yet as a public
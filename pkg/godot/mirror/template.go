@@ -0,0 +1,130 @@
+package mirror
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/coffeebeats/gdenv/internal/client"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
+)
+
+const (
+	// PlaceholderLabel is replaced by the version label (e.g. 'stable').
+	PlaceholderLabel = "{label}"
+	// PlaceholderName is replaced by the artifact's file name (e.g.
+	// 'Godot_v4.2.1-stable_linux.x86_64.zip').
+	PlaceholderName = "{name}"
+	// PlaceholderTag is replaced by the release tag (e.g. '4.2.1-stable').
+	PlaceholderTag = "{tag}"
+	// PlaceholderVersion is replaced by the "normal version", omitting a patch
+	// version of '0' (e.g. '4.2.1' or '4.3').
+	PlaceholderVersion = "{version}"
+)
+
+var ErrInvalidTemplate = errors.New("invalid mirror template")
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: Template                              */
+/* -------------------------------------------------------------------------- */
+
+// A mirror implementation for fetching artifacts from a user-defined host. The
+// URL of each artifact is formed by replacing placeholders in a URL template
+// (e.g. 'https://example.com/godot/{tag}/{name}').
+//
+// NOTE: 'Template' must be used by pointer so that it's comparable, which is
+// required for ranking mirrors in 'Select'.
+type Template[T artifact.Artifact] struct {
+	name  string
+	url   string
+	hosts []string
+}
+
+// Validate at compile-time that 'Template' implements 'Mirror' interfaces.
+var _ Hoster = (*Template[artifact.Artifact])(nil)
+var _ Remoter[artifact.Artifact] = (*Template[artifact.Artifact])(nil)
+
+/* -------------------------- Function: NewTemplate ------------------------- */
+
+// NewTemplate creates a new 'Template' mirror with the specified display name
+// and URL template. The URL template must contain the 'PlaceholderName'
+// placeholder. The host of the URL template is always allowed; any additional
+// hosts which the mirror may redirect to must be specified in 'hosts'.
+func NewTemplate[T artifact.Artifact](name, urlTemplate string, hosts ...string) (*Template[T], error) {
+	if !strings.Contains(urlTemplate, PlaceholderName) {
+		return nil, fmt.Errorf("%w: missing '%s' placeholder: %s", ErrInvalidTemplate, PlaceholderName, urlTemplate)
+	}
+
+	u, err := client.ParseURL(urlTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+
+	if name == "" {
+		name = u.Host
+	}
+
+	allowed := []string{u.Hostname()}
+
+	for _, h := range hosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h != "" && !slices.Contains(allowed, h) {
+			allowed = append(allowed, h)
+		}
+	}
+
+	return &Template[T]{name: name, url: urlTemplate, hosts: allowed}, nil
+}
+
+/* ------------------------------ Impl: Hoster ------------------------------ */
+
+// Hosts returns the host URLs at which artifacts are hosted.
+func (m *Template[T]) Hosts() []string {
+	return slices.Clone(m.hosts)
+}
+
+/* ------------------------------ Impl: Remoter ----------------------------- */
+
+// Remote returns an 'artifact.Remote' wrapper around a specified artifact. The
+// remote wrapper contains the URL at which the artifact can be downloaded.
+func (m *Template[T]) Remote(a T) (artifact.Remote[T], error) {
+	var remote artifact.Remote[T]
+
+	switch any(a).(type) { // FIXME: https://github.com/golang/go/issues/45380
+	case executable.Archive, executable.Checksums:
+	case source.Archive, source.Checksums:
+	case templates.Archive, templates.Checksums:
+	default:
+		return remote, fmt.Errorf("%w: %T", ErrUnsupportedArtifact, a)
+	}
+
+	v := a.Version()
+
+	urlRaw := strings.NewReplacer(
+		PlaceholderLabel, url.PathEscape(v.Label()),
+		PlaceholderName, url.PathEscape(a.Name()),
+		PlaceholderTag, url.PathEscape(releaseTag(v)),
+		PlaceholderVersion, url.PathEscape(releaseVersion(v)),
+	).Replace(m.url)
+
+	urlParsed, err := client.ParseURL(urlRaw)
+	if err != nil {
+		return remote, errors.Join(ErrInvalidURL, err)
+	}
+
+	remote.Artifact, remote.URL = a, urlParsed
+
+	return remote, nil
+}
+
+/* ------------------------------ Impl: Mirror ------------------------------ */
+
+// Name returns the display name of the mirror.
+func (m *Template[T]) Name() string {
+	return m.name
+}
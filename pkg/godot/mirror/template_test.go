@@ -0,0 +1,215 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/artifacttest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* --------------------------- Test: NewTemplate ---------------------------- */
+
+func TestNewTemplate(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		hosts []string
+
+		wantName  string
+		wantHosts []string
+		err       error
+	}{
+		// Invalid inputs
+		{
+			name: "missing name placeholder returns an error",
+			url:  "https://example.com/godot/{tag}",
+			err:  ErrInvalidTemplate,
+		},
+		{
+			name: "relative URL returns an error",
+			url:  "example.com/godot/{name}",
+			err:  ErrInvalidTemplate,
+		},
+
+		// Valid inputs
+		{
+			name:      "display name defaults to the host",
+			url:       "https://example.com:8080/godot/{tag}/{name}",
+			wantName:  "example.com:8080",
+			wantHosts: []string{"example.com"},
+		},
+		{
+			name:      "additional hosts are allowed",
+			url:       "https://example.com/godot/{name}",
+			hosts:     []string{" CDN.example.com", "example.com", ""},
+			wantName:  "example.com",
+			wantHosts: []string{"example.com", "cdn.example.com"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: A new template mirror is created.
+			got, err := NewTemplate[executable.Archive]("", tc.url, tc.hosts...)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			// Then: The mirror has the expected name.
+			if got.Name() != tc.wantName {
+				t.Errorf("name: got %v, want %v", got.Name(), tc.wantName)
+			}
+
+			// Then: The mirror has the expected hosts.
+			if !reflect.DeepEqual(got.Hosts(), tc.wantHosts) {
+				t.Errorf("hosts: got %v, want %v", got.Hosts(), tc.wantHosts)
+			}
+		})
+	}
+}
+
+/* ---------------------------- Test: Template.Remote ----------------------- */
+
+func TestTemplateRemote(t *testing.T) {
+	const urlTemplate = "https://example.com/godot/{version}/{label}/{tag}/{name}"
+
+	tests := []struct {
+		artifact artifact.Artifact
+
+		url string
+		err error
+	}{
+		// Invalid inputs
+		{artifact: artifacttest.MockArtifact{}, err: ErrUnsupportedArtifact},
+
+		// Valid inputs
+		{
+			artifact: executable.Archive{Inner: executable.MustParse("Godot_v4.1.1-stable_linux.x86_64")},
+			url:      "https://example.com/godot/4.1.1/stable/4.1.1-stable/Godot_v4.1.1-stable_linux.x86_64.zip",
+		},
+		{
+			artifact: executable.Archive{Inner: executable.MustParse("Godot_v4.3-beta1_mono_linux_x86_64")},
+			url:      "https://example.com/godot/4.3/beta1_mono/4.3-beta1_mono/Godot_v4.3-beta1_mono_linux.x86_64.zip",
+		},
+		{
+			artifact: source.Archive{Inner: source.New(version.MustParse("4.1.0-stable"))},
+			url:      "https://example.com/godot/4.1/stable/4.1-stable/godot-4.1-stable.tar.xz",
+		},
+		{
+			artifact: templates.Archive{Inner: templates.New(version.MustParse("4.2.1-stable"))},
+			url:      "https://example.com/godot/4.2.1/stable/4.2.1-stable/Godot_v4.2.1-stable_export_templates.tpz",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			m, err := NewTemplate[artifact.Artifact]("", urlTemplate)
+			if err != nil {
+				t.Fatalf("test setup: %v", err)
+			}
+
+			// When: The remote URL of the artifact is determined.
+			got, err := m.Remote(tc.artifact)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected URL is returned.
+			if err == nil && got.URL.String() != tc.url {
+				t.Errorf("output: got %v, want %v", got.URL, tc.url)
+			}
+		})
+	}
+}
+
+/* ---------------------------- Test: Select (Template) --------------------- */
+
+func TestSelectTemplate(t *testing.T) {
+	// Given: An artifact to download.
+	a := executable.Archive{Inner: executable.MustParse("Godot_v4.3-stable_linux.x86_64")}
+
+	// Given: A local mirror which only hosts artifacts under '/available'.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/available/4.3-stable/"+a.Name() {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	missing, err := NewTemplate[executable.Archive]("missing", srv.URL+"/missing/{tag}/{name}")
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	available, err := NewTemplate[executable.Archive]("available", srv.URL+"/available/{tag}/{name}")
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	other, err := NewTemplate[executable.Archive]("other", srv.URL+"/available/{tag}/{name}")
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		mirrors []Mirror[executable.Archive]
+
+		want Mirror[executable.Archive]
+		err  error
+	}{
+		{
+			name:    "no mirror hosts the artifact",
+			mirrors: []Mirror[executable.Archive]{missing},
+			err:     ErrNotFound,
+		},
+		{
+			name:    "available mirror is selected",
+			mirrors: []Mirror[executable.Archive]{missing, available},
+			want:    available,
+		},
+		{
+			name:    "best available mirror is selected",
+			mirrors: []Mirror[executable.Archive]{other, missing, available},
+			want:    other, // Appears first in 'mirrors'.
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: A mirror is selected for the artifact.
+			got, err := Select(context.Background(), tc.mirrors, a)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected mirror is selected.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
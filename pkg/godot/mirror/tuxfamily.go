@@ -11,6 +11,7 @@ import (
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
 )
 
@@ -56,6 +57,7 @@ func (m TuxFamily[T]) Remote(a T) (artifact.Remote[T], error) {
 	switch any(a).(type) { // FIXME: https://github.com/golang/go/issues/45380
 	case executable.Archive, executable.Checksums:
 	case source.Archive, source.Checksums:
+	case templates.Archive, templates.Checksums:
 	default:
 		return remote, fmt.Errorf("%w: %T", ErrUnsupportedArtifact, a)
 	}
@@ -92,16 +94,7 @@ func urlTuxFamilyVersionDir(v version.Version) string {
 
 	// The first directory will be the "normal version", but a patch version of
 	// '0' will be dropped.
-	var normal string
-
-	switch v.Patch() {
-	case 0:
-		normal = fmt.Sprintf("%d.%d", v.Major(), v.Minor())
-	default:
-		normal = v.Normal()
-	}
-
-	p = append(p, normal)
+	p = append(p, releaseVersion(v))
 
 	// If the build is a "stable", non-"mono" flavor, then the assets will be in
 	// the version directory. Otherwise, the assets will be in one or more sub-
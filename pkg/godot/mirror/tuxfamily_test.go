@@ -10,6 +10,7 @@ import (
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/artifacttest"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
 )
 
@@ -67,6 +68,14 @@ func TestTuxFamilyRemote(t *testing.T) {
 			artifact: mustMakeNewSourceChecksum(t, version.MustParse("4.1.0-stable")),
 			url:      mustParseURL(t, tuxFamilyAssetsURLBase+"/4.1/godot-4.1-stable.tar.xz.sha256"),
 		},
+		{
+			artifact: templates.Archive{Inner: templates.New(version.MustParse("4.1.1-stable"))},
+			url:      mustParseURL(t, tuxFamilyAssetsURLBase+"/4.1.1/Godot_v4.1.1-stable_export_templates.tpz"),
+		},
+		{
+			artifact: templates.Archive{Inner: templates.New(version.MustParse("4.1-stable_mono"))},
+			url:      mustParseURL(t, tuxFamilyAssetsURLBase+"/4.1/mono/Godot_v4.1-stable_mono_export_templates.tpz"),
+		},
 	}
 
 	for _, tc := range tests {
@@ -24,6 +24,7 @@ const (
 	Arm64
 	I386
 	Universal
+	Arm32
 )
 
 /* ------------------------- Function: MustParseArch ------------------------ */
@@ -60,9 +61,12 @@ func ParseArch(input string) (Arch, error) {
 	case "amd64", "x86_64", "x86-64":
 		return Amd64, nil
 
-	case "arm64", "arm64be":
+	case "arm64", "arm64be", "aarch64":
 		return Arm64, nil
 
+	case "arm", "arm32", "armv7", "armv7l", "armhf":
+		return Arm32, nil
+
 	case "fat", "universal":
 		return Universal, nil
 
@@ -77,6 +81,8 @@ func (a Arch) String() string {
 	switch a {
 	case Amd64:
 		return "amd64"
+	case Arm32:
+		return "arm32"
 	case Arm64:
 		return "arm64"
 	case I386:
@@ -25,6 +25,13 @@ func TestParseArch(t *testing.T) {
 
 		{s: "arm64", want: Arm64},
 		{s: "arm64be", want: Arm64},
+		{s: "aarch64", want: Arm64},
+
+		{s: "arm", want: Arm32},
+		{s: "arm32", want: Arm32},
+		{s: "armv7", want: Arm32},
+		{s: "armv7l", want: Arm32},
+		{s: "armhf", want: Arm32},
 
 		{s: "386", want: I386},
 		{s: "i386", want: I386},
@@ -24,6 +24,11 @@ var (
 	// the 'dev.*' pre-alpha versions. This expressions has been tested manually
 	// and some unit tests validate this as well.
 	reV4LinuxLabelsWithoutX86 = regexp.MustCompile(`^(alpha([1-9]|1[0-4])|(dev\.[0-9]{8}))$`)
+
+	// The first versions which include ARM builds for Linux and Windows,
+	// respectively. Note that all pre-release versions of these are included.
+	versionLinuxARM   = version.MustParse("4.2")
+	versionWindowsARM = version.MustParse("4.3")
 )
 
 /* -------------------------------------------------------------------------- */
@@ -54,12 +59,20 @@ func Parse(input string) (Platform, error) {
 		return Platform{I386, Linux}, nil
 	case "x11.64", "linux.x86_64":
 		return Platform{Amd64, Linux}, nil
+	case "linux.arm32":
+		return Platform{Arm32, Linux}, nil
+	case "linux.arm64":
+		return Platform{Arm64, Linux}, nil
 
 	// Linux (mono builds)
 	case "linux_x86_32":
 		return Platform{I386, Linux}, nil
 	case "linux_x86_64":
 		return Platform{Amd64, Linux}, nil
+	case "linux_arm32":
+		return Platform{Arm32, Linux}, nil
+	case "linux_arm64":
+		return Platform{Arm64, Linux}, nil
 
 	// MacOS - Note that the supported architectures between 'osx.fat' and
 	// 'osx.universal' are *NOT* the same. It's important to maintain the
@@ -75,6 +88,8 @@ func Parse(input string) (Platform, error) {
 		return Platform{I386, Windows}, nil
 	case "win64":
 		return Platform{Amd64, Windows}, nil
+	case "windows_arm64":
+		return Platform{Arm64, Windows}, nil
 
 	default:
 		return Platform{}, fmt.Errorf("%w: '%s'", ErrUnrecognizedPlatform, input)
@@ -170,6 +185,20 @@ func formatLinux(a Arch, v version.Version) (string, error) { //nolint:cyclop
 		case Amd64:
 			p = "linux.x86_64"
 
+		// ARM builds were introduced in v4.2.
+		case Arm32:
+			if v.CompareNormal(versionLinuxARM) < 0 {
+				return "", fmt.Errorf("%w: %v", ErrUnrecognizedArch, a)
+			}
+
+			p = "linux.arm32"
+		case Arm64:
+			if v.CompareNormal(versionLinuxARM) < 0 {
+				return "", fmt.Errorf("%w: %v", ErrUnrecognizedArch, a)
+			}
+
+			p = "linux.arm64"
+
 		default:
 			return "", fmt.Errorf("%w: %v", ErrUnrecognizedArch, a)
 		}
@@ -262,6 +291,14 @@ func formatWindows(a Arch, v version.Version) (string, error) {
 		case Amd64:
 			return "win64", nil
 
+		// ARM builds were introduced in v4.3.
+		case Arm64:
+			if v.CompareNormal(versionWindowsARM) < 0 {
+				return "", fmt.Errorf("%w: %v", ErrUnrecognizedArch, a)
+			}
+
+			return "windows_arm64", nil
+
 		default:
 			return "", fmt.Errorf("%w: %v", ErrUnrecognizedArch, a)
 		}
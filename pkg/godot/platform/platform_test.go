@@ -28,6 +28,10 @@ func TestParsePlatform(t *testing.T) {
 		{s: "linux.x86_64", want: Platform{Amd64, Linux}, err: nil},
 		{s: "linux_x86_32", want: Platform{I386, Linux}, err: nil},
 		{s: "linux_x86_64", want: Platform{Amd64, Linux}, err: nil},
+		{s: "linux.arm32", want: Platform{Arm32, Linux}, err: nil},
+		{s: "linux.arm64", want: Platform{Arm64, Linux}, err: nil},
+		{s: "linux_arm32", want: Platform{Arm32, Linux}, err: nil},
+		{s: "linux_arm64", want: Platform{Arm64, Linux}, err: nil},
 
 		// MacOS
 		{s: "osx.64", want: Platform{Amd64, MacOS}, err: nil},
@@ -38,6 +42,7 @@ func TestParsePlatform(t *testing.T) {
 		// Windows
 		{s: "win32", want: Platform{I386, Windows}, err: nil},
 		{s: "win64", want: Platform{Amd64, Windows}, err: nil},
+		{s: "windows_arm64", want: Platform{Arm64, Windows}, err: nil},
 
 		// Valid inputs (user-supplied)
 		{s: "WIN64", want: Platform{Amd64, Windows}, err: nil},
@@ -111,8 +116,19 @@ func TestFormatPlatform(t *testing.T) {
 		{platform: Platform{OS: Linux, Arch: I386}, version: "4.0-stable_mono", want: "linux_x86_32"},
 		{platform: Platform{OS: Linux, Arch: Amd64}, version: "4.0-stable_mono", want: "linux_x86_64"},
 		{platform: Platform{OS: Linux, Arch: Arm64}, version: "4.0", err: ErrUnrecognizedArch},
+		{platform: Platform{OS: Linux, Arch: Arm32}, version: "4.0", err: ErrUnrecognizedArch},
 		{platform: Platform{OS: Linux, Arch: Universal}, version: "4.0", err: ErrUnrecognizedArch},
 
+		// v4.2+
+		{platform: Platform{OS: Linux, Arch: Arm64}, version: "4.1.3", err: ErrUnrecognizedArch},
+		{platform: Platform{OS: Linux, Arch: Arm32}, version: "4.1.3", err: ErrUnrecognizedArch},
+		{platform: Platform{OS: Linux, Arch: Arm64}, version: "4.2-beta1", want: "linux.arm64"},
+		{platform: Platform{OS: Linux, Arch: Arm32}, version: "4.2-beta1", want: "linux.arm32"},
+		{platform: Platform{OS: Linux, Arch: Arm64}, version: "4.2", want: "linux.arm64"},
+		{platform: Platform{OS: Linux, Arch: Arm32}, version: "4.2", want: "linux.arm32"},
+		{platform: Platform{OS: Linux, Arch: Arm64}, version: "4.2-stable_mono", want: "linux_arm64"},
+		{platform: Platform{OS: Linux, Arch: Arm32}, version: "4.2-stable_mono", want: "linux_arm32"},
+
 		// Valid inputs - MacOS
 
 		// v3.0 - v3.0.6
@@ -201,7 +217,14 @@ func TestFormatPlatform(t *testing.T) {
 		{platform: Platform{OS: Windows, Arch: I386}, version: "4.0", want: "win32"},
 		{platform: Platform{OS: Windows, Arch: Amd64}, version: "4.0", want: "win64"},
 		{platform: Platform{OS: Windows, Arch: Arm64}, version: "4.0", err: ErrUnrecognizedArch},
+		{platform: Platform{OS: Windows, Arch: Arm32}, version: "4.0", err: ErrUnrecognizedArch},
 		{platform: Platform{OS: Windows, Arch: Universal}, version: "4.0", err: ErrUnrecognizedArch},
+
+		// v4.3+
+		{platform: Platform{OS: Windows, Arch: Arm64}, version: "4.2.2", err: ErrUnrecognizedArch},
+		{platform: Platform{OS: Windows, Arch: Arm64}, version: "4.3-beta1", want: "windows_arm64"},
+		{platform: Platform{OS: Windows, Arch: Arm64}, version: "4.3", want: "windows_arm64"},
+		{platform: Platform{OS: Windows, Arch: Arm32}, version: "4.3", err: ErrUnrecognizedArch},
 	}
 
 	for _, tc := range tests {
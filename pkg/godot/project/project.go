@@ -0,0 +1,266 @@
+package project
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+const (
+	// Filename is the name of the file which defines a Godot project.
+	Filename = "project.godot"
+
+	// FeatureMono is the project feature denoting a project which uses C#.
+	FeatureMono = "C#"
+
+	keyFeatures        = "config/features"
+	sectionApplication = "application"
+)
+
+var (
+	ErrMissingProject = errors.New("missing Godot project")
+	ErrMissingVersion = errors.New("project does not declare a Godot version")
+
+	// Matches a quoted string within a Godot property value.
+	reQuotedString = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+
+	// Matches a project feature naming a Godot version (e.g. '4.3').
+	reFeatureVersion = regexp.MustCompile(`^\d+\.\d+$`)
+)
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: Project                               */
+/* -------------------------------------------------------------------------- */
+
+// Project contains the properties of a Godot project (i.e. a 'project.godot'
+// file) which are relevant to 'gdenv'.
+type Project struct {
+	// Features is the list of features required by the project (e.g. '4.3',
+	// 'C#', and 'Forward Plus'). Godot 3 projects don't declare features.
+	Features []string
+}
+
+/* ----------------------------- Method: IsMono ----------------------------- */
+
+// IsMono returns whether the project requires a "mono" (i.e. C#) build of
+// Godot.
+func (p Project) IsMono() bool {
+	for _, f := range p.Features {
+		if f == FeatureMono {
+			return true
+		}
+	}
+
+	return false
+}
+
+/* ----------------------------- Method: Version ---------------------------- */
+
+// Version returns the Godot version declared by the project's features. Only
+// the major and minor versions are declared (e.g. '4.3'), so the returned
+// 'Version' will never specify a patch version.
+func (p Project) Version() (version.Version, error) {
+	for _, f := range p.Features {
+		if !reFeatureVersion.MatchString(f) {
+			continue
+		}
+
+		return version.Parse(f)
+	}
+
+	return version.Version{}, ErrMissingVersion
+}
+
+/* --------------------------- Method: Constraint --------------------------- */
+
+// Constraint returns a 'version.Constraint' which matches the newest stable
+// release of the project's declared Godot version (e.g. '4.3-latest-stable'),
+// selecting "mono" builds if the project uses C#.
+func (p Project) Constraint() (version.Constraint, error) {
+	v, err := p.Version()
+	if err != nil {
+		return version.Constraint{}, err
+	}
+
+	label := version.LabelStable
+	if p.IsMono() {
+		label = version.LabelMono
+	}
+
+	return version.ParseConstraint(
+		fmt.Sprintf("%d.%d-%s-%s", v.Major(), v.Minor(), version.Latest, label),
+	)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Read                               */
+/* -------------------------------------------------------------------------- */
+
+// Read parses the 'Project' defined at the specified path, which is either a
+// 'project.godot' file or the directory containing one.
+func Read(path string) (Project, error) {
+	if filepath.Base(path) != Filename {
+		path = filepath.Join(path, Filename)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Project{}, fmt.Errorf("%w: '%s'", ErrMissingProject, path)
+		}
+
+		return Project{}, err
+	}
+
+	defer f.Close()
+
+	return Parse(f)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Parse                              */
+/* -------------------------------------------------------------------------- */
+
+// Parse parses a 'Project' from the contents of a 'project.godot' file.
+func Parse(r io.Reader) (Project, error) {
+	p := Project{Features: nil}
+
+	scanner := bufio.NewScanner(r)
+
+	var section, property string
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Property values may span multiple lines (e.g. input maps); consume
+		// lines until the value's brackets are balanced.
+		if property != "" {
+			property += "\n" + line
+			if isBalanced(property) {
+				property = ""
+			}
+
+			continue
+		}
+
+		switch {
+		case line == "" || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = strings.TrimSpace(line[1 : len(line)-1])
+
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		if !isBalanced(value) {
+			property = value
+
+			continue
+		}
+
+		if section == sectionApplication && strings.TrimSpace(key) == keyFeatures {
+			p.Features = parseStrings(value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Project{}, err
+	}
+
+	return p, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Function: Locate                              */
+/* -------------------------------------------------------------------------- */
+
+// Locate returns the directory containing the 'project.godot' file which
+// applies to the specified directory. The specified directory and its
+// ancestors are checked in order; if none contain a project file, then
+// 'ErrMissingProject' is returned.
+func Locate(ctx context.Context, path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		info, err := os.Stat(filepath.Join(path, Filename))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+
+		if err == nil && info.Mode().IsRegular() {
+			return path, nil
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			break
+		}
+
+		path = parent
+	}
+
+	return "", ErrMissingProject
+}
+
+/* ------------------------- Function: parseStrings ------------------------- */
+
+// parseStrings returns the quoted strings within a Godot property value (e.g.
+// 'PackedStringArray("4.3", "C#")').
+func parseStrings(value string) []string {
+	matches := reQuotedString.FindAllStringSubmatch(value, -1)
+
+	out := make([]string, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, strings.ReplaceAll(m[1], `\"`, `"`))
+	}
+
+	return out
+}
+
+/* -------------------------- Function: isBalanced -------------------------- */
+
+// isBalanced returns whether all brackets within a Godot property value are
+// closed, ignoring those within quoted strings.
+func isBalanced(value string) bool {
+	depth, quoted, escaped := 0, false, false
+
+	for _, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+			continue
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		}
+	}
+
+	return depth <= 0 && !quoted
+}
@@ -0,0 +1,196 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+const projectGodot4 = `; Engine configuration file.
+; It's best edited using the editor UI and not directly,
+; since the parameters that go here are not all obvious.
+
+config_version=5
+
+[application]
+
+config/name="Example"
+config/features=PackedStringArray("4.3", "C#", "Forward Plus")
+config/icon="res://icon.svg"
+
+[input]
+
+ui_accept={
+"deadzone": 0.5,
+"events": [Object(InputEventKey,"resource_local_to_scene":false,"keycode":4194309)
+]
+}
+
+[rendering]
+
+config/features=PackedStringArray("ignored")
+`
+
+/* ------------------------------- Test: Parse ------------------------------ */
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+
+		want Project
+	}{
+		{
+			name: "empty file has no features",
+
+			want: Project{Features: nil},
+		},
+		{
+			name:  "Godot 3 project has no features",
+			input: "config_version=4\n\n[application]\n\nconfig/name=\"Example\"\n",
+
+			want: Project{Features: nil},
+		},
+		{
+			name:  "Godot 4 project has features",
+			input: projectGodot4,
+
+			want: Project{Features: []string{"4.3", "C#", "Forward Plus"}},
+		},
+		{
+			name:  "features after a multi-line value are parsed",
+			input: "[application]\n\nconfig/x={\n\"a\": \"[\",\n}\nconfig/features=PackedStringArray(\"4.2\")\n",
+
+			want: Project{Features: []string{"4.2"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: The project file is parsed.
+			got, err := Parse(strings.NewReader(tc.input))
+
+			// Then: No error is returned.
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Then: The expected project is returned.
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("output: got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ---------------------------- Test: Constraint ---------------------------- */
+
+func TestConstraint(t *testing.T) {
+	tests := []struct {
+		features []string
+
+		want string
+		err  error
+	}{
+		{features: nil, err: ErrMissingVersion},
+		{features: []string{"Forward Plus"}, err: ErrMissingVersion},
+		{features: []string{"4.3", "Forward Plus"}, want: "4.3-latest-stable"},
+		{features: []string{"4.2", "C#", "Mobile"}, want: "4.2-latest-stable_mono"},
+	}
+
+	for _, tc := range tests {
+		t.Run(strings.Join(tc.features, ","), func(t *testing.T) {
+			// When: The project's version constraint is determined.
+			got, err := Project{Features: tc.features}.Constraint()
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			// Then: The expected constraint is returned.
+			if want := version.MustParseConstraint(tc.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+/* ------------------------------ Test: Locate ------------------------------ */
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []fstest.Writer
+		path  string
+
+		want string
+		err  error
+	}{
+		{
+			name: "missing project returns an error",
+			path: "a/b",
+
+			err: ErrMissingProject,
+		},
+		{
+			name:  "project in directory is found",
+			files: []fstest.Writer{fstest.File{Path: "a/" + Filename}},
+			path:  "a",
+
+			want: "a",
+		},
+		{
+			name:  "project in ancestor directory is found",
+			files: []fstest.Writer{fstest.File{Path: "a/" + Filename}, fstest.Dir{Path: "a/b/c"}},
+			path:  "a/b/c",
+
+			want: "a",
+		},
+		{
+			name:  "directory named after project file is ignored",
+			files: []fstest.Writer{fstest.Dir{Path: "a/b/" + Filename}},
+			path:  "a/b",
+
+			err: ErrMissingProject,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			// Given: The specified files exist.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The project directory is located.
+			got, err := Locate(context.Background(), filepath.Join(tmp, tc.path))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			// Then: The expected directory is returned.
+			if want := filepath.Join(tmp, tc.want); got != want {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+		})
+	}
+}
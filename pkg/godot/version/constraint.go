@@ -0,0 +1,472 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	// Latest is a keyword used to select the newest version within a range of
+	// versions (e.g. '4.3-latest' or '4.3-latest-stable').
+	Latest = "latest"
+
+	opCaret = "^"
+	opEQ    = "="
+	opGT    = ">"
+	opGTE   = ">="
+	opLT    = "<"
+	opLTE   = "<="
+	opTilde = "~"
+)
+
+var (
+	ErrConflictingBuilds = errors.New("conflicting build metadata")
+	ErrConflictingLabels = errors.New("conflicting version labels")
+	ErrInvalidConstraint = errors.New("invalid version constraint")
+)
+
+/* -------------------------------------------------------------------------- */
+/*                             Struct: Constraint                             */
+/* -------------------------------------------------------------------------- */
+
+// Constraint is a specification of a range of acceptable Godot versions. A
+// 'Constraint' is a set of comparisons against the "normal version" (see
+// https://semver.org/#spec-item-2) which must all be satisfied, along with a
+// required version label. Note that matching versions must have the exact
+// same label; a label is never considered "greater" than another. The only
+// exception is a label naming a release channel without a number (e.g. 'beta'
+// or 'rc_mono'), which matches all labels in that channel (e.g. 'beta2').
+// Similarly, matching versions must have the exact same build metadata, so
+// custom builds of Godot (e.g. '4.3-stable+fork.1') are only matched by
+// constraints which specify the same build metadata.
+//
+// The following formats are supported:
+//   - An exact version (e.g. '4.2.1' or '4.3-stable_mono')
+//   - Comparisons (e.g. '>=4.2.1 <4.4', '>4.2-stable_mono')
+//   - Tilde ranges (e.g. '~4.3' is equivalent to '>=4.3 <4.4')
+//   - Caret ranges (e.g. '^4.3' is equivalent to '>=4.3 <5.0')
+//   - Latest versions (e.g. '4.3-latest-stable', '4-latest', 'latest-beta', or
+//     'latest'), optionally with build metadata (e.g. '4.3-latest+fork.1')
+type Constraint struct {
+	comparisons []comparison
+	label       string
+	build       string
+}
+
+/* ------------------------- Function: NewConstraint ------------------------ */
+
+// NewConstraint creates a new 'Constraint' which only matches the exact
+// 'Version' specified.
+func NewConstraint(v Version) Constraint {
+	return Constraint{
+		comparisons: []comparison{{op: opEQ, version: v}},
+		label:       v.Label(),
+		build:       v.Build(),
+	}
+}
+
+/* ------------------------ Function: ParseConstraint ----------------------- */
+
+// ParseConstraint parses a 'Constraint' from the provided string. See
+// 'Constraint' for a list of supported formats.
+func ParseConstraint(input string) (Constraint, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return Constraint{}, ErrMissing
+	}
+
+	// NOTE: This must be checked before exact versions because 'Parse' will
+	// otherwise accept the 'latest' suffix as a version label.
+	if prefix, label, ok := cutLatest(input); ok {
+		return parseLatest(prefix, label)
+	}
+
+	// Exact versions are the most common constraint format.
+	if v, err := Parse(input); err == nil {
+		return NewConstraint(v), nil
+	}
+
+	var c Constraint
+
+	for _, field := range strings.Fields(input) {
+		cc, err := parseComparisons(field)
+		if err != nil {
+			return Constraint{}, err
+		}
+
+		for _, cmp := range cc {
+			label := cmp.version.Label()
+			if c.label != "" && c.label != label {
+				return Constraint{}, fmt.Errorf("%w: '%s' and '%s'", ErrConflictingLabels, c.label, label)
+			}
+
+			build := cmp.version.Build()
+			if len(c.comparisons) > 0 && c.build != build {
+				return Constraint{}, fmt.Errorf("%w: '%s' and '%s'", ErrConflictingBuilds, c.build, build)
+			}
+
+			c.label, c.build = label, build
+			c.comparisons = append(c.comparisons, cmp)
+		}
+	}
+
+	return c, nil
+}
+
+/* ---------------------- Function: MustParseConstraint --------------------- */
+
+// MustParseConstraint parses a 'Constraint' from the provided string or panics
+// if it would fail.
+func MustParseConstraint(input string) Constraint {
+	c, err := ParseConstraint(input)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+/* ------------------------------ Method: Exact ----------------------------- */
+
+// Exact returns the single 'Version' matched by the 'Constraint', if the
+// 'Constraint' only matches one version.
+func (c Constraint) Exact() (Version, bool) {
+	if len(c.comparisons) != 1 || c.comparisons[0].op != opEQ {
+		return Version{}, false
+	}
+
+	return c.comparisons[0].version, true
+}
+
+/* ------------------------------ Method: Label ----------------------------- */
+
+// Label returns the version label required by the 'Constraint'.
+func (c Constraint) Label() string {
+	if c.label == "" {
+		return LabelDefault()
+	}
+
+	return c.label
+}
+
+/* ----------------------------- Method: Matches ---------------------------- */
+
+// Matches returns whether the provided 'Version' satisfies the 'Constraint'.
+func (c Constraint) Matches(v Version) bool {
+	return matchesLabel(c.Label(), v.Label()) && v.Build() == c.build && c.MatchesNormal(v)
+}
+
+/* -------------------------- Method: MatchesNormal ------------------------- */
+
+// MatchesNormal returns whether the "normal version" of the provided 'Version'
+// satisfies the 'Constraint', ignoring the version label.
+func (c Constraint) MatchesNormal(v Version) bool {
+	for _, cmp := range c.comparisons {
+		if !cmp.matches(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/* ----------------------------- Method: Select ----------------------------- */
+
+// Select returns the newest 'Version' (see 'Compare') from those provided which
+// satisfies the 'Constraint'. If none match then 'false' is returned.
+func (c Constraint) Select(versions []Version) (Version, bool) {
+	var out Version
+
+	found := false
+
+	for _, v := range versions {
+		if !c.Matches(v) {
+			continue
+		}
+
+		if !found || Compare(v, out) > 0 {
+			out, found = v, true
+		}
+	}
+
+	return out, found
+}
+
+/* ---------------------------- Method: WithLabel --------------------------- */
+
+// WithLabel returns a copy of the 'Constraint' which requires the specified
+// version label instead (e.g. to select the "mono" variant of a release).
+func (c Constraint) WithLabel(label string) Constraint {
+	out := Constraint{
+		comparisons: slices.Clone(c.comparisons),
+		label:       label,
+		build:       c.build,
+	}
+
+	if label == LabelDefault() {
+		label = ""
+	}
+
+	for i := range out.comparisons {
+		out.comparisons[i].version.label = label
+	}
+
+	return out
+}
+
+/* ----------------------------- Impl: Stringer ----------------------------- */
+
+func (c Constraint) String() string {
+	if v, ok := c.Exact(); ok {
+		return v.String()
+	}
+
+	if len(c.comparisons) == 0 {
+		out := Latest + SeparatorPreReleaseVersion + c.Label()
+		if c.build != "" {
+			out += SeparatorBuildMetadata + c.build
+		}
+
+		return out
+	}
+
+	parts := make([]string, len(c.comparisons))
+	for i, cmp := range c.comparisons {
+		// NOTE: Bounds derived from a version prefix don't record the label, so
+		// use the 'Constraint' label to avoid printing a misleading version.
+		v := cmp.version
+		v.label, v.build = c.label, c.build
+
+		parts[i] = cmp.op + v.String()
+	}
+
+	return strings.Join(parts, " ")
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Struct: comparison                             */
+/* -------------------------------------------------------------------------- */
+
+// comparison is a single comparison against the "normal version" of a
+// 'Version'.
+type comparison struct {
+	op      string
+	version Version
+}
+
+/* ----------------------------- Method: matches ---------------------------- */
+
+func (c comparison) matches(v Version) bool {
+	result := v.CompareNormal(c.version)
+
+	switch c.op {
+	case opEQ:
+		return result == 0
+	case opGT:
+		return result > 0
+	case opGTE:
+		return result >= 0
+	case opLT:
+		return result < 0
+	case opLTE:
+		return result <= 0
+	default:
+		return false
+	}
+}
+
+/* -------------------------- Function: matchesLabel ------------------------ */
+
+// matchesLabel returns whether the version label 'got' satisfies the required
+// label 'want'. Labels must be identical unless 'want' names a release channel
+// without a number (e.g. 'beta' matches 'beta2' and 'rc_mono' matches
+// 'rc1_mono').
+func matchesLabel(want, got string) bool {
+	if want == got {
+		return true
+	}
+
+	base, isMono := strings.CutSuffix(got, "_"+Mono)
+
+	channel := strings.TrimRight(base, "0123456789")
+	if isMono {
+		channel += "_" + Mono
+	}
+
+	return channel == want
+}
+
+/* ------------------------ Function: parseComparisons ---------------------- */
+
+// parseComparisons parses a single whitespace-delimited constraint term into
+// one or more comparisons. Tilde and caret ranges expand into two comparisons.
+func parseComparisons(input string) ([]comparison, error) {
+	// NOTE: Two-rune operators must be checked before their one-rune prefixes.
+	for _, op := range []string{opGTE, opLTE, opGT, opLT, opEQ, opTilde, opCaret} {
+		operand, ok := strings.CutPrefix(input, op)
+		if !ok {
+			continue
+		}
+
+		v, n, err := parsePartial(operand)
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case opTilde:
+			// NOTE: A tilde range allows patch-level changes if a minor version
+			// is specified and minor-level changes otherwise.
+			lower, upper := boundsOf(v, n)
+
+			return []comparison{{opGTE, v}, {opLT, upper}}, boundsErr(lower, upper)
+		case opCaret:
+			// NOTE: A caret range allows changes which do not modify the major
+			// version.
+			lower, upper := boundsOf(v, 1)
+
+			return []comparison{{opGTE, v}, {opLT, upper}}, boundsErr(lower, upper)
+		}
+
+		return []comparison{{op, v}}, nil
+	}
+
+	// A version without an operator is treated as an exact match.
+	v, _, err := parsePartial(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return []comparison{{opEQ, v}}, nil
+}
+
+/* ------------------------- Function: parseLatest -------------------------- */
+
+// parseLatest creates a 'Constraint' matching all versions that share the
+// specified version prefix and label. The label may be followed by build
+// metadata (e.g. 'stable+fork.1').
+func parseLatest(prefix, label string) (Constraint, error) {
+	label, build, found := strings.Cut(label, SeparatorBuildMetadata)
+	if found && !isValidBuild(build) {
+		return Constraint{}, fmt.Errorf("%w: invalid build metadata: '%s'", ErrInvalidConstraint, build)
+	}
+
+	if label == "" {
+		label = LabelDefault()
+	}
+
+	c := Constraint{label: label, build: build} //nolint:exhaustruct
+
+	if prefix == "" {
+		return c, nil
+	}
+
+	v, n, err := parsePartial(prefix)
+	if err != nil {
+		return Constraint{}, err
+	}
+
+	// The label must be specified after the 'latest' keyword.
+	if v.label != "" {
+		return Constraint{}, fmt.Errorf("%w: '%s'", ErrInvalidConstraint, prefix)
+	}
+
+	lower, upper := boundsOf(v, n)
+	if n == 3 { //nolint:mnd
+		c.comparisons = []comparison{{opGTE, lower}, {opLTE, lower}}
+
+		return c, nil
+	}
+
+	c.comparisons = []comparison{{opGTE, lower}, {opLT, upper}}
+
+	return c, boundsErr(lower, upper)
+}
+
+/* -------------------------- Function: parsePartial ------------------------ */
+
+// parsePartial parses a (possibly partial) version string, returning the
+// 'Version' and the number of "normal version" components specified.
+func parsePartial(input string) (Version, int, error) {
+	v, err := Parse(input)
+	if err != nil {
+		return Version{}, 0, fmt.Errorf("%w: %w", ErrInvalidConstraint, err)
+	}
+
+	normal, _, _ := strings.Cut(strings.TrimPrefix(input, Prefix), SeparatorPreReleaseVersion)
+
+	return v, strings.Count(normal, ".") + 1, nil
+}
+
+/* --------------------------- Function: cutLatest -------------------------- */
+
+// cutLatest splits a "latest"-style constraint (e.g. '4.3-latest-stable') into
+// its version prefix and label. The returned 'bool' reports whether the input
+// was a "latest"-style constraint.
+func cutLatest(input string) (string, string, bool) {
+	if input == Latest {
+		return "", "", true
+	}
+
+	// Build metadata may directly follow the 'latest' keyword (e.g.
+	// 'latest+fork.1'); it's returned as part of the label.
+	if build, ok := strings.CutPrefix(input, Latest+SeparatorBuildMetadata); ok {
+		return "", SeparatorBuildMetadata + build, true
+	}
+
+	if label, ok := strings.CutPrefix(input, Latest+SeparatorPreReleaseVersion); ok {
+		return "", label, label != ""
+	}
+
+	prefix, rest, ok := strings.Cut(input, SeparatorPreReleaseVersion+Latest)
+	if !ok {
+		return "", "", false
+	}
+
+	switch label, found := strings.CutPrefix(rest, SeparatorPreReleaseVersion); {
+	case rest == "":
+		return prefix, "", true
+	case strings.HasPrefix(rest, SeparatorBuildMetadata):
+		return prefix, rest, true
+	case found && label != "":
+		return prefix, label, true
+	default:
+		return "", "", false
+	}
+}
+
+/* --------------------------- Function: boundsOf --------------------------- */
+
+// boundsOf returns the lower (inclusive) and upper (exclusive) bounds of the
+// range of versions which share the first 'n' "normal version" components with
+// the provided 'Version'.
+func boundsOf(v Version, n int) (Version, Version) {
+	lower, upper := v, v
+
+	switch n {
+	case 1:
+		lower.minor, lower.patch = 0, 0
+		upper.major, upper.minor, upper.patch = v.major+1, 0, 0
+	case 2: //nolint:mnd
+		lower.patch = 0
+		upper.minor, upper.patch = v.minor+1, 0
+	default:
+		upper.minor, upper.patch = v.minor+1, 0
+	}
+
+	return lower, upper
+}
+
+/* --------------------------- Function: boundsErr -------------------------- */
+
+// boundsErr returns an error if the computed upper bound overflowed.
+func boundsErr(lower, upper Version) error {
+	if upper.CompareNormal(lower) <= 0 {
+		return fmt.Errorf("%w: %w: exceeds %d", ErrInvalidConstraint, ErrInvalidNumber, math.MaxUint8)
+	}
+
+	return nil
+}
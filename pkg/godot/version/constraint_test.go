@@ -0,0 +1,190 @@
+package version
+
+import (
+	"errors"
+	"testing"
+)
+
+/* ------------------------- Test: ParseConstraint -------------------------- */
+
+func TestParseConstraint(t *testing.T) {
+	tests := []struct {
+		s string
+
+		matches []string
+		rejects []string
+		exact   bool
+		err     error
+	}{
+		// Invalid inputs
+		{s: "", err: ErrMissing},
+		{s: "abc", err: ErrInvalidConstraint},
+		{s: ">=abc", err: ErrInvalidConstraint},
+		{s: "~4.x", err: ErrInvalidConstraint},
+		{s: ">=4.2 <4.4-stable_mono", err: ErrConflictingLabels},
+		{s: ">=4.2+fork <4.4", err: ErrConflictingBuilds},
+		{s: "4.3-latest+fork..1", err: ErrInvalidConstraint},
+		{s: "4.3-rc1-latest", err: ErrInvalidConstraint},
+		{s: "~255", err: ErrInvalidConstraint},
+
+		// Valid inputs - exact
+		{s: "4.3", matches: []string{"4.3-stable"}, rejects: []string{"4.3.1", "4.3-rc1", "4.3-stable_mono"}, exact: true},
+		{s: "=4.3-rc1", matches: []string{"4.3-rc1"}, rejects: []string{"4.3", "4.3-rc2"}, exact: true},
+		{s: " v4.3-STABLE_MONO\n", matches: []string{"4.3-stable_mono"}, rejects: []string{"4.3"}, exact: true},
+
+		// Valid inputs - comparisons
+		{s: ">=4.2.1 <4.4", matches: []string{"4.2.1", "4.3", "4.3.5"}, rejects: []string{"4.2", "4.4", "4.3-rc1"}},
+		{s: ">4.2 <=4.3", matches: []string{"4.2.1", "4.3"}, rejects: []string{"4.2", "4.3.1"}},
+		{s: ">=4.2-stable_mono", matches: []string{"4.2-stable_mono", "5.0-stable_mono"}, rejects: []string{"4.2"}},
+		{s: "4.2 >=4.1", matches: []string{"4.2"}, rejects: []string{"4.1"}},
+
+		// Valid inputs - ranges
+		{s: "~4.3", matches: []string{"4.3", "4.3.9"}, rejects: []string{"4.2.9", "4.4"}},
+		{s: "~4.3.1", matches: []string{"4.3.1", "4.3.9"}, rejects: []string{"4.3", "4.4"}},
+		{s: "~4", matches: []string{"4.0", "4.9"}, rejects: []string{"3.5", "5.0"}},
+		{s: "^4.3", matches: []string{"4.3", "4.9"}, rejects: []string{"4.2", "5.0"}},
+
+		// Valid inputs - latest
+		{s: "4.3-latest-stable", matches: []string{"4.3", "4.3.2"}, rejects: []string{"4.4", "4.3-rc1"}},
+		{s: "4.3-latest", matches: []string{"4.3", "4.3.2"}, rejects: []string{"4.4", "4.3-stable_mono"}},
+		{s: "4-latest-rc1", matches: []string{"4.3-rc1"}, rejects: []string{"4.3", "5.0-rc1"}},
+		{s: "4.3.1-latest", matches: []string{"4.3.1"}, rejects: []string{"4.3", "4.3.2"}},
+		{s: "latest", matches: []string{"3.0", "4.3"}, rejects: []string{"4.3-rc1"}},
+		{s: "latest-stable_mono", matches: []string{"4.3-stable_mono"}, rejects: []string{"4.3"}},
+		{s: "latest-beta", matches: []string{"4.3-beta1", "4.3-beta12"}, rejects: []string{"4.3", "4.3-beta2_mono"}},
+		{s: "4.3-latest-rc_mono", matches: []string{"4.3-rc1_mono"}, rejects: []string{"4.3-rc1", "4.4-rc1_mono"}},
+
+		// Valid inputs - build metadata
+		{s: "4.3-stable+fork.1", matches: []string{"4.3+fork.1"}, rejects: []string{"4.3", "4.3+fork.2"}, exact: true},
+		{s: "~4.3", matches: []string{"4.3"}, rejects: []string{"4.3+fork.1"}},
+		{s: ">=4.2+fork <4.4+fork", matches: []string{"4.3+fork"}, rejects: []string{"4.3", "4.3+fork.1"}},
+		{s: "4.3-latest+fork", matches: []string{"4.3.1+fork"}, rejects: []string{"4.3.1", "4.4+fork"}},
+		{s: "latest-stable_mono+fork", matches: []string{"4.3-stable_mono+fork"}, rejects: []string{"4.3+fork"}},
+		{s: "latest+fork", matches: []string{"4.3+fork"}, rejects: []string{"4.3"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := ParseConstraint(tc.s)
+
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			if _, ok := got.Exact(); err == nil && ok != tc.exact {
+				t.Errorf("exact: got %v, want %v", ok, tc.exact)
+			}
+
+			for _, s := range tc.matches {
+				if !got.Matches(MustParse(s)) {
+					t.Errorf("expected '%s' to match: %s", tc.s, s)
+				}
+			}
+
+			for _, s := range tc.rejects {
+				if got.Matches(MustParse(s)) {
+					t.Errorf("expected '%s' to not match: %s", tc.s, s)
+				}
+			}
+		})
+	}
+}
+
+/* ------------------------ Test: Constraint.Select ------------------------- */
+
+func TestConstraintSelect(t *testing.T) {
+	versions := []Version{
+		MustParse("4.2.2"),
+		MustParse("4.3.1"),
+		MustParse("4.3"),
+		MustParse("4.4-rc1"),
+		MustParse("4.4-rc2"),
+		MustParse("4.3.2-stable_mono"),
+	}
+
+	tests := []struct {
+		s string
+
+		want Version
+		ok   bool
+	}{
+		{s: "~4.3", want: MustParse("4.3.1"), ok: true},
+		{s: ">=4.2 <4.4", want: MustParse("4.3.1"), ok: true},
+		{s: "4.2-latest", want: MustParse("4.2.2"), ok: true},
+		{s: "latest-rc1", want: MustParse("4.4-rc1"), ok: true},
+		{s: "latest-rc", want: MustParse("4.4-rc2"), ok: true},
+		{s: "~4.3-stable_mono", want: MustParse("4.3.2-stable_mono"), ok: true},
+		{s: "~4.1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.s, func(t *testing.T) {
+			got, ok := MustParseConstraint(tc.s).Select(versions)
+
+			if ok != tc.ok {
+				t.Errorf("ok: got %v, want %v", ok, tc.ok)
+			}
+
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* --------------------- Test: Constraint.MatchesNormal --------------------- */
+
+func TestConstraintMatchesNormal(t *testing.T) {
+	tests := []struct {
+		s string
+		v string
+
+		want bool
+	}{
+		{s: "~4.3", v: "4.3.1", want: true},
+		{s: "~4.3", v: "4.3.1-beta1_mono", want: true},
+		{s: ">=4.2 <4.4", v: "4.4-rc1", want: false},
+		{s: "3-latest", v: "3.5.3-stable_mono", want: true},
+		{s: "3-latest", v: "4.0", want: false},
+		{s: "latest", v: "4.0-dev1", want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.s+"/"+tc.v, func(t *testing.T) {
+			got := MustParseConstraint(tc.s).MatchesNormal(MustParse(tc.v))
+
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ----------------------- Test: Constraint.WithLabel ----------------------- */
+
+func TestConstraintWithLabel(t *testing.T) {
+	tests := []struct {
+		s     string
+		label string
+
+		want string
+	}{
+		{s: "4.3", label: LabelMono, want: "4.3-stable_mono"},
+		{s: "~4.3", label: LabelMono, want: "~4.3-stable_mono"},
+		{s: "4.3-latest", label: LabelMono, want: "4.3-latest-stable_mono"},
+		{s: "latest-beta+fork", label: "beta_mono", want: "latest-beta_mono+fork"},
+		{s: "4.3-stable_mono", label: LabelStable, want: "4.3"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.s+"/"+tc.label, func(t *testing.T) {
+			// When: The constraint's label is replaced.
+			got := MustParseConstraint(tc.s).WithLabel(tc.label)
+
+			// Then: The constraint matches the one parsed with that label.
+			if want := MustParseConstraint(tc.want); got.String() != want.String() {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+		})
+	}
+}
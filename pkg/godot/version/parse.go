@@ -40,10 +40,18 @@ func Parse(input string) (Version, error) {
 	// 'semver' does.
 	input = Prefix + strings.TrimPrefix(input, Prefix)
 
+	// Trim build metadata off, but store it for later. Godot doesn't use these
+	// (see https://semver.org/#spec-item-10), but custom builds of Godot can be
+	// distinguished from official releases with them (e.g. '4.3-stable+fork.1').
+	input, build, found := strings.Cut(input, SeparatorBuildMetadata)
+	if found && !isValidBuild(build) {
+		return version, fmt.Errorf("%w: invalid build metadata: '%s'", ErrInvalid, build)
+	}
+
 	// Trim the label off, but store it for later.
-	input, label, found := strings.Cut(input, SeparatorPreReleaseVersion)
-	if (found && label == "") || !semver.IsValid(input) {
-		err := fmt.Errorf("%w: '%s'", ErrInvalid, strings.TrimPrefix(input, Prefix))
+	normalVersion, label, found := strings.Cut(input, SeparatorPreReleaseVersion)
+	if (found && label == "") || !semver.IsValid(normalVersion) {
+		err := fmt.Errorf("%w: '%s'", ErrInvalid, strings.TrimPrefix(normalVersion, Prefix))
 
 		return version, err
 	}
@@ -52,18 +60,7 @@ func Parse(input string) (Version, error) {
 		label = ""
 	}
 
-	version.label = label
-
-	// Trim build metadata - Godot does not use these (see https://semver.org/#spec-item-10).
-
-	// NOTE: This step occurs *after* label extraction, so labels will keep any
-	// build metadata suffixes. This future-proofs 'gdenv' if Godot changes its
-	// build labeling practices. However, 'gdenv' doesn't support metadata
-	// directly following the "normal version number".
-	normalVersion, _, found := strings.Cut(input, SeparatorBuildMetadata)
-	if found {
-		return version, fmt.Errorf("%w: '%s'", ErrUnsupported, input)
-	}
+	version.label, version.build = label, build
 
 	parts, err := parseNormalVersion(normalVersion)
 	if err != nil {
@@ -88,6 +85,30 @@ func MustParse(input string) Version {
 	return v
 }
 
+/* ------------------------- Function: isValidBuild ------------------------- */
+
+// isValidBuild returns whether the build metadata consists of dot-separated,
+// non-empty identifiers of lowercase alphanumerics and hyphens (see
+// https://semver.org/#spec-item-10).
+//
+// NOTE: Like the rest of the version, build metadata is normalized to lowercase
+// before it's validated, so uppercase input is accepted but not preserved.
+func isValidBuild(build string) bool {
+	for _, identifier := range strings.Split(build, ".") {
+		if identifier == "" {
+			return false
+		}
+
+		for _, r := range identifier {
+			if (r < '0' || r > '9') && (r < 'a' || r > 'z') && r != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 /* ---------------------- Function: parseNormalVersion ---------------------- */
 
 // Parses the "normal version" (see https://semver.org/#spec-item-2) from a
@@ -57,7 +57,7 @@ func TestParse(t *testing.T) {
 
 			// Valid (depending on input)
 			s := "suffix"
-			want, err := Version{t.want.major, t.want.minor, t.want.patch, s}, t.err
+			want, err := Version{major: t.want.major, minor: t.want.minor, patch: t.want.patch, label: s}, t.err
 			if t.err != nil {
 				want.label = ""
 				err = ErrInvalid
@@ -68,7 +68,7 @@ func TestParse(t *testing.T) {
 			// Valid (depending on input)
 			sNormalized, s := s, "SUFFIX\t\n "
 
-			want, err = Version{t.want.major, t.want.minor, t.want.patch, sNormalized}, t.err
+			want, err = Version{major: t.want.major, minor: t.want.minor, patch: t.want.patch, label: sNormalized}, t.err
 			if t.err != nil {
 				want.label = ""
 				err = ErrInvalid
@@ -138,6 +138,84 @@ func TestParse(t *testing.T) {
 	}
 }
 
+/* ---------------------------- Test: ParseBuild ---------------------------- */
+
+func TestParseBuild(t *testing.T) {
+	tests := []struct {
+		input string
+
+		want Version
+		err  error
+	}{
+		// Invalid inputs
+		{input: "4.3+", err: ErrInvalid},
+		{input: "4.3-stable+", err: ErrInvalid},
+		{input: "4.3-stable+fork..1", err: ErrInvalid},
+		{input: "4.3-stable+fork_1", err: ErrInvalid},
+		{input: "4.3-+fork", err: ErrInvalid},
+
+		// Valid inputs
+		{input: "4.3+fork", want: Version{major: 4, minor: 3, build: "fork"}},
+		{input: "4.3-stable+fork.1", want: Version{major: 4, minor: 3, build: "fork.1"}},
+		{input: "v4.3.1-rc1+Studio-7", want: Version{major: 4, minor: 3, patch: 1, label: "rc1", build: "studio-7"}},
+		{input: "4.3-stable_mono+fork.1", want: Version{major: 4, minor: 3, label: LabelMono, build: "fork.1"}},
+		// Uppercase build metadata is accepted but normalized to lowercase.
+		{input: "4.3-stable+FORK.A1", want: Version{major: 4, minor: 3, build: "fork.a1"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Setenv(EnvDefaultMono, "")
+
+			got, err := Parse(tc.input)
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %#v, want %#v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("output: got %#v, want %#v", got, tc.want)
+			}
+
+			// Then: Valid versions are formatted with their build metadata.
+			if err == nil && MustParse(got.String()) != got {
+				t.Errorf("round trip: got %#v, want %#v", MustParse(got.String()), got)
+			}
+		})
+	}
+}
+
+/* --------------------------- Test: isValidBuild --------------------------- */
+
+func TestIsValidBuild(t *testing.T) {
+	tests := []struct {
+		build string
+		want  bool
+	}{
+		{build: "", want: false},
+		{build: "fork.", want: false},
+		{build: "fork_1", want: false},
+		{build: "fork+1", want: false},
+		{build: "fork.1", want: true},
+		{build: "studio-7", want: true},
+		{build: "0a-z.9", want: true},
+		{build: "Studio-7", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.build, func(t *testing.T) {
+			// When: The build metadata is validated.
+			got := isValidBuild(tc.build)
+
+			// Then: Lowercase alphanumerics and hyphens are accepted; 'Parse'
+			// lowercases build metadata before it's validated.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 /* -------------------- Function: TestParseInvalidNumber -------------------- */
 
 func TestParseInvalidNumber(t *testing.T) {
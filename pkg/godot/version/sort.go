@@ -0,0 +1,145 @@
+package version
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                              Function: Compare                             */
+/* -------------------------------------------------------------------------- */
+
+// Compare defines a total ordering of 'Version' structs, suitable for use with
+// 'slices.SortFunc'. Versions are ordered by "normal version" first and then by
+// release channel ('dev' < 'alpha' < 'beta' < 'rc' < 'stable'), followed by the
+// number affixed to the channel (e.g. 'beta2' < 'beta10' or 'dev.20240101' <
+// 'dev.20240215'). "mono" builds are ordered directly after their non-"mono"
+// variant. Unrecognized channels are ordered before all others. Custom builds
+// (i.e. versions with build metadata) are ordered after the official release.
+//
+// The result will be '0' if 'a' == 'b', '-1' if 'a' < 'b', or '+1' if
+// 'a' > 'b'.
+func Compare(a, b Version) int {
+	if result := a.CompareNormal(b); result != 0 {
+		return result
+	}
+
+	if result := compareLabels(a.Label(), b.Label()); result != 0 {
+		return result
+	}
+
+	// Official releases are ordered before custom builds of the same version.
+	return cmp.Compare(a.build, b.build)
+}
+
+/* ------------------------- Function: compareLabels ------------------------ */
+
+// compareLabels orders version labels by release channel, channel number, and
+// then "mono" variant (see 'Compare').
+func compareLabels(labelA, labelB string) int {
+	if labelA == labelB {
+		return 0
+	}
+
+	channelA, numberA, monoA := parseLabel(labelA)
+	channelB, numberB, monoB := parseLabel(labelB)
+
+	if result := cmp.Compare(rankChannel(channelA), rankChannel(channelB)); result != 0 {
+		return result
+	}
+
+	// NOTE: Unrecognized channels all share a rank, so order them by name.
+	if result := cmp.Compare(channelA, channelB); result != 0 {
+		return result
+	}
+
+	if result := cmp.Compare(numberA, numberB); result != 0 {
+		return result
+	}
+
+	if monoA != monoB {
+		if monoA {
+			return 1
+		}
+
+		return -1
+	}
+
+	// Ensure a total ordering for labels which only differ in formatting (e.g.
+	// 'beta2' and 'beta02').
+	return cmp.Compare(labelA, labelB)
+}
+
+/* ----------------------------- Function: Sort ----------------------------- */
+
+// Sort sorts the provided versions in ascending order (see 'Compare').
+func Sort(versions []Version) {
+	slices.SortStableFunc(versions, Compare)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Type: Versions                               */
+/* -------------------------------------------------------------------------- */
+
+// Versions is a list of 'Version' structs which implements 'sort.Interface',
+// ordering versions in ascending order (see 'Compare').
+type Versions []Version
+
+/* -------------------------- Impl: sort.Interface -------------------------- */
+
+func (vv Versions) Len() int {
+	return len(vv)
+}
+
+func (vv Versions) Less(i, j int) bool {
+	return Compare(vv[i], vv[j]) < 0
+}
+
+func (vv Versions) Swap(i, j int) {
+	vv[i], vv[j] = vv[j], vv[i]
+}
+
+/* -------------------------- Function: parseLabel -------------------------- */
+
+// parseLabel splits a version label into its release channel, the number
+// affixed to the channel (or '0' if there isn't one), and whether the label
+// denotes a "mono" build (e.g. 'beta2_mono' -> 'beta', '2', 'true').
+func parseLabel(label string) (string, uint64, bool) {
+	label, mono := strings.CutSuffix(label, "_"+Mono)
+
+	channel := strings.TrimRight(label, "0123456789")
+	suffix := strings.TrimPrefix(label, channel)
+
+	// Development snapshots may be dated with a separator (e.g. 'dev.20240101').
+	channel = strings.TrimSuffix(channel, ".")
+
+	number, err := strconv.ParseUint(suffix, 10, 64)
+	if err != nil {
+		number = 0
+	}
+
+	return channel, number, mono
+}
+
+/* ------------------------- Function: rankChannel -------------------------- */
+
+// rankChannel returns the relative order of a release channel; unrecognized
+// channels are ranked before all others.
+func rankChannel(channel string) int {
+	switch channel {
+	case "dev":
+		return 1
+	case "alpha":
+		return 2 //nolint:mnd
+	case "beta":
+		return 3 //nolint:mnd
+	case "rc":
+		return 4 //nolint:mnd
+	case LabelStable:
+		return 5 //nolint:mnd
+	default:
+		return 0
+	}
+}
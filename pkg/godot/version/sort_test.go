@@ -0,0 +1,118 @@
+package version
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+/* ------------------------------ Test: Compare ----------------------------- */
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		// Normal versions
+		{a: "4.2", b: "4.2", want: 0},
+		{a: "4.2", b: "4.10", want: -1},
+		{a: "4.2.1", b: "4.2", want: 1},
+		{a: "3.6-stable", b: "4.0-dev1", want: -1},
+
+		// Channels
+		{a: "4.3-dev.20240101", b: "4.3-alpha1", want: -1},
+		{a: "4.3-alpha1", b: "4.3-beta1", want: -1},
+		{a: "4.3-beta3", b: "4.3-rc1", want: -1},
+		{a: "4.3-rc1", b: "4.3-stable", want: -1},
+		{a: "4.3-stable", b: "4.3-rc1", want: 1},
+		{a: "4.3-custom", b: "4.3-dev1", want: -1},
+
+		// Channel numbers
+		{a: "4.3-beta2", b: "4.3-beta10", want: -1},
+		{a: "4.3-beta", b: "4.3-beta1", want: -1},
+		{a: "4.3-dev.20240215", b: "4.3-dev.20240101", want: 1},
+
+		// Mono variants
+		{a: "4.3-stable_mono", b: "4.3-stable", want: 1},
+		{a: "4.3-beta2_mono", b: "4.3-beta2", want: 1},
+		{a: "4.3-beta1_mono", b: "4.3-beta2", want: -1},
+		{a: "4.3-rc1_mono", b: "4.3-stable", want: -1},
+		{a: "4.3-stable_mono", b: "4.3-stable_mono", want: 0},
+
+		// Custom builds
+		{a: "4.3-stable+fork.1", b: "4.3-stable", want: 1},
+		{a: "4.3-stable+fork.1", b: "4.3-stable+fork.2", want: -1},
+		{a: "4.3-stable+fork.1", b: "4.3.1-stable", want: -1},
+		{a: "4.3-stable+fork.1", b: "4.3-stable_mono", want: -1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.a+"/"+tc.b, func(t *testing.T) {
+			a, b := MustParse(tc.a), MustParse(tc.b)
+
+			// When: The versions are compared.
+			got := Compare(a, b)
+
+			// Then: The expected ordering is returned.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+
+			// Then: The reversed comparison returns the opposite ordering.
+			if reversed := b.Compare(a); reversed != -tc.want {
+				t.Errorf("reversed: got %v, want %v", reversed, -tc.want)
+			}
+		})
+	}
+}
+
+/* ------------------------------- Test: Sort ------------------------------- */
+
+func TestSort(t *testing.T) {
+	want := []Version{
+		MustParse("3.5.3"),
+		MustParse("4.2"),
+		MustParse("4.2.1-stable_mono"),
+		MustParse("4.3-dev.20240101"),
+		MustParse("4.3-beta1"),
+		MustParse("4.3-beta2"),
+		MustParse("4.3-beta2_mono"),
+		MustParse("4.3-beta10"),
+		MustParse("4.3-rc1"),
+		MustParse("4.3"),
+		MustParse("4.3-stable_mono"),
+		MustParse("4.10"),
+	}
+
+	// Given: A shuffled list of versions.
+	shuffled := slices.Clone(want)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		got := slices.Clone(shuffled)
+
+		// When: The versions are sorted.
+		Sort(got)
+
+		// Then: The versions are in ascending order.
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("output: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("sort.Interface", func(t *testing.T) {
+		got := slices.Clone(shuffled)
+
+		// When: The versions are sorted using 'sort.Interface'.
+		sort.Sort(Versions(got))
+
+		// Then: The versions are in ascending order.
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("output: got %v, want %v", got, want)
+		}
+	})
+}
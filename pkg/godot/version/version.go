@@ -73,6 +73,11 @@ type Version struct {
 	// though Godot affixes "stable" to its stable releases. Note that an empty
 	// 'Label' will be interpreted as a stable version.
 	label string
+
+	// Equivalent to "build metadata" (see https://semver.org/#spec-item-10).
+	// Official Godot releases never specify this, so it's used to identify
+	// custom builds of Godot (e.g. 'fork.1' in '4.3-stable+fork.1').
+	build string
 }
 
 /* ------------------------------ Method: Major ----------------------------- */
@@ -107,6 +112,22 @@ func (v Version) Label() string {
 	return v.label
 }
 
+/* ------------------------------ Method: Build ----------------------------- */
+
+// Returns the build metadata (see https://semver.org/#spec-item-10), which is
+// empty for official Godot releases.
+func (v Version) Build() string {
+	return v.build
+}
+
+/* ----------------------------- Method: IsCustom --------------------------- */
+
+// Returns whether the version specifies a custom build of Godot (i.e. one with
+// build metadata), which can't be downloaded from a mirror.
+func (v Version) IsCustom() bool {
+	return v.build != ""
+}
+
 /* ----------------------------- Method: IsMono ----------------------------- */
 
 // Returns whether the version specifies a "mono" release (i.e. 'stable_mono').
@@ -122,6 +143,17 @@ func (v Version) IsStable() bool {
 	return v.Label() == LabelStable || v.Label() == LabelMono
 }
 
+/* ----------------------------- Method: Channel ---------------------------- */
+
+// Returns the release channel of the version, i.e. its label with any numeric
+// suffix and "mono" suffix removed (e.g. 'beta2_mono' -> 'beta' or
+// 'dev.20240101' -> 'dev').
+func (v Version) Channel() string {
+	channel, _, _ := parseLabel(v.Label())
+
+	return channel
+}
+
 /* ----------------------------- Method: Normal ----------------------------- */
 
 // Returns the "normal version" format of the 'Version' (see
@@ -139,6 +171,15 @@ func (v Version) CompareNormal(w Version) int {
 	return semver.Compare(Prefix+v.Normal(), Prefix+w.Normal())
 }
 
+/* ----------------------------- Method: Compare ---------------------------- */
+
+// Compares the 'Version' to another 'Version' struct, first by "normal version"
+// and then by release label (see 'Compare'). The result will be '0' if 'v' ==
+// 'w', '-1' if 'v' < 'w', or '+1' if 'v' > 'w'.
+func (v Version) Compare(w Version) int {
+	return Compare(v, w)
+}
+
 /* ----------------------------- Impl: Stringer ----------------------------- */
 
 func (v Version) String() string {
@@ -160,5 +201,10 @@ func (v Version) String() string {
 	out.WriteRune('-')
 	out.WriteString(v.Label())
 
+	if v.build != "" {
+		out.WriteString(SeparatorBuildMetadata)
+		out.WriteString(v.build)
+	}
+
 	return out.String()
 }
@@ -112,3 +112,30 @@ func TestVersionString(t *testing.T) {
 		})
 	}
 }
+
+/* -------------------------- Test: Version.Channel ------------------------- */
+
+func TestVersionChannel(t *testing.T) {
+	tests := []struct {
+		v    string
+		want string
+	}{
+		{v: "4.2", want: LabelStable},
+		{v: "4.2-stable_mono", want: LabelStable},
+		{v: "4.3-beta2", want: "beta"},
+		{v: "4.3-beta2_mono", want: "beta"},
+		{v: "4.4-rc1", want: "rc"},
+		{v: "4.4-dev7", want: "dev"},
+		{v: "4.4-dev.20240101", want: "dev"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.v, func(t *testing.T) {
+			got := MustParse(tc.v).Channel()
+
+			if got != tc.want {
+				t.Errorf("output: got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
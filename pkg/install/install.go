@@ -31,6 +31,13 @@ func Executable( //nolint:funlen
 ) error {
 	p, v := ex.Platform(), ex.Version()
 
+	unlock, err := lockArtifact(ctx, storePath, ex)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
 	ok, err := store.Has(storePath, ex)
 	if err != nil {
 		return err
@@ -58,7 +65,7 @@ func Executable( //nolint:funlen
 
 	log.Debugf("using temporary directory: %s", tmp)
 
-	localExArchive, err := download.ExecutableWithChecksumValidation(ctx, ex, tmp)
+	localExArchive, provenance, err := download.ExecutableWithChecksumValidation(ctx, storePath, ex, tmp)
 	if err != nil {
 		return err
 	}
@@ -88,7 +95,7 @@ func Executable( //nolint:funlen
 		})
 	}
 
-	if err := store.Add(ctx, storePath, artifacts...); err != nil {
+	if err := store.Add(store.WithProvenance(ctx, provenance), storePath, artifacts...); err != nil {
 		return err
 	}
 
@@ -104,13 +111,20 @@ func Executable( //nolint:funlen
 // Downloads and caches a specific version of Godot's source code.
 func Source(ctx context.Context, storePath string, v version.Version, force bool) error {
 	// Ensure the store exists.
-	if err := store.Touch(storePath); err != nil {
+	if err := store.Touch(ctx, storePath); err != nil {
 		return err
 	}
 
 	// Define the target 'Source'.
 	src := source.New(v)
 
+	unlock, err := lockArtifact(ctx, storePath, src)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
 	ok, err := store.Has(storePath, src)
 	if err != nil {
 		return err
@@ -133,7 +147,7 @@ func Source(ctx context.Context, storePath string, v version.Version, force bool
 
 	log.Debugf("using temporary directory: %s", tmp)
 
-	localSourceArchive, err := download.SourceWithChecksumValidation(ctx, src.Version(), tmp)
+	localSourceArchive, provenance, err := download.SourceWithChecksumValidation(ctx, storePath, src.Version(), tmp)
 	if err != nil {
 		return err
 	}
@@ -141,7 +155,7 @@ func Source(ctx context.Context, storePath string, v version.Version, force bool
 	log.Debug("installing source in gdenv store")
 
 	if err := store.Add(
-		ctx,
+		store.WithProvenance(ctx, provenance),
 		storePath,
 		artifact.Local[artifact.Artifact]{
 			Artifact: localSourceArchive.Artifact,
@@ -155,3 +169,23 @@ func Source(ctx context.Context, storePath string, v version.Version, force bool
 
 	return nil
 }
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: lockArtifact                           */
+/* -------------------------------------------------------------------------- */
+
+// lockArtifact acquires the artifact's store lock so that concurrent 'gdenv'
+// processes don't race to install the same artifact. The returned function
+// releases the lock.
+func lockArtifact(ctx context.Context, storePath string, a artifact.Artifact) (func(), error) {
+	unlock, err := store.LockArtifact(ctx, storePath, a)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		if err := unlock(); err != nil {
+			log.Warnf("failed to release store lock: %s", err)
+		}
+	}, nil
+}
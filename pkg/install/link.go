@@ -0,0 +1,139 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/internal/osutil"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/mirror"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+// mirrorLink is the mirror name recorded for linked executables (see 'Link').
+const mirrorLink = "local build"
+
+var (
+	ErrAlreadyInstalled = errors.New("version already installed")
+	ErrInvalidBuild     = errors.New("invalid Godot build")
+	ErrNotCustom        = errors.New("version must include build metadata (e.g. '4.3-stable+fork.1')")
+)
+
+/* -------------------------------------------------------------------------- */
+/*                                Function: Link                              */
+/* -------------------------------------------------------------------------- */
+
+// Link adds a locally-built Godot editor at 'path' to the store as the
+// specified executable, after which it can be pinned and used like any other
+// installed version. The executable's version must specify build metadata (see
+// 'version.Version.IsCustom') so that it's distinct from official releases. On
+// macOS, 'path' must be the application bundle (i.e. 'Godot.app'); otherwise,
+// it must be the editor binary. If 'force' is set, then an existing executable
+// is replaced.
+func Link(ctx context.Context, storePath string, ex executable.Executable, path string, force bool) error {
+	p, v := ex.Platform(), ex.Version()
+
+	if !v.IsCustom() {
+		return fmt.Errorf("%w: %s", ErrNotCustom, v)
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if isApp := p.OS == platform.MacOS; info.IsDir() != isApp || (!isApp && !info.Mode().IsRegular()) {
+		return fmt.Errorf("%w: expected %s: '%s'", ErrInvalidBuild, describeBuild(p), path)
+	}
+
+	unlock, err := lockArtifact(ctx, storePath, ex)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	ok, err := store.Has(storePath, ex)
+	if err != nil {
+		return err
+	}
+
+	if ok && !force {
+		return fmt.Errorf("%w: %s", ErrAlreadyInstalled, v)
+	}
+
+	platformLabel, err := platform.Format(p, v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", platform.ErrUnrecognizedPlatform, err)
+	}
+
+	log.Infof("linking version: %s (%s)", v, platformLabel)
+
+	tmp, err := os.MkdirTemp("", "gdenv-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(tmp)
+
+	log.Debugf("using temporary directory: %s", tmp)
+
+	// NOTE: The store names each entry after the staged file, so copy the
+	// build to the expected name first (e.g. 'Godot.app' on macOS).
+	name, _, _ := strings.Cut(filepath.ToSlash(ex.Path()), "/")
+	pathStaged := filepath.Join(tmp, name)
+
+	if info.IsDir() {
+		err = osutil.CopyDir(ctx, path, pathStaged)
+	} else {
+		err = osutil.CopyFile(ctx, path, pathStaged)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	provenance := store.Provenance{
+		Mirror:   mirrorLink,
+		URL:      mirror.FileURL(path).String(),
+		Checksum: "",
+		Size:     0,
+	}
+
+	if err := store.Add(
+		store.WithProvenance(ctx, provenance),
+		storePath,
+		artifact.Local[artifact.Artifact]{Artifact: ex, Path: pathStaged},
+	); err != nil {
+		return err
+	}
+
+	log.Infof("successfully linked version: %s (%s,%s)", v, p.OS, p.Arch)
+
+	return nil
+}
+
+/* ------------------------- Function: describeBuild ------------------------ */
+
+// describeBuild describes the kind of file expected for a Godot editor build
+// on the specified platform.
+func describeBuild(p platform.Platform) string {
+	if p.OS == platform.MacOS {
+		return "an application bundle (e.g. 'Godot.app')"
+	}
+
+	return "an executable file"
+}
@@ -0,0 +1,149 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+/* -------------------------------- Test: Link ------------------------------ */
+
+func TestLink(t *testing.T) {
+	linux := platform.Platform{OS: platform.Linux, Arch: platform.Amd64}
+	macOS := platform.Platform{OS: platform.MacOS, Arch: platform.Universal}
+
+	custom := executable.New(version.MustParse("4.3-stable+fork.1"), linux)
+
+	tests := []struct {
+		name  string
+		ex    executable.Executable
+		files []fstest.Writer
+		path  string
+		force bool
+
+		want string
+		err  error
+	}{
+		{
+			name:  "official release returns an error",
+			ex:    executable.New(version.MustParse("4.3-stable"), linux),
+			files: []fstest.Writer{fstest.File{Path: "godot", Contents: "new"}},
+			path:  "godot",
+
+			err: ErrNotCustom,
+		},
+		{
+			name: "missing build returns an error",
+			ex:   custom,
+			path: "godot",
+
+			err: fs.ErrNotExist,
+		},
+		{
+			name:  "directory returns an error on Linux",
+			ex:    custom,
+			files: []fstest.Writer{fstest.Dir{Path: "godot"}},
+			path:  "godot",
+
+			err: ErrInvalidBuild,
+		},
+		{
+			name:  "file returns an error on macOS",
+			ex:    executable.New(version.MustParse("4.3-stable+fork.1"), macOS),
+			files: []fstest.Writer{fstest.File{Path: "Godot.app", Contents: "new"}},
+			path:  "Godot.app",
+
+			err: ErrInvalidBuild,
+		},
+		{
+			name:  "build is added to the store",
+			ex:    custom,
+			files: []fstest.Writer{fstest.File{Path: "godot", Contents: "new"}},
+			path:  "godot",
+
+			want: "new",
+		},
+		{
+			name: "installed version without force returns an error",
+			ex:   custom,
+			files: []fstest.Writer{
+				fstest.File{Path: "godot", Contents: "new"},
+				fstest.File{Path: executablePath(t, custom), Contents: "old"},
+			},
+			path: "godot",
+
+			want: "old",
+			err:  ErrAlreadyInstalled,
+		},
+		{
+			name: "installed version with force is replaced",
+			ex:   custom,
+			files: []fstest.Writer{
+				fstest.File{Path: "godot", Contents: "new"},
+				fstest.File{Path: executablePath(t, custom), Contents: "old"},
+			},
+			path:  "godot",
+			force: true,
+
+			want: "new",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			storePath := filepath.Join(tmp, ".gdenv")
+
+			t.Setenv("GDENV_HOME", storePath)
+			t.Setenv("GDENV_PATH", "")
+
+			// Given: The specified files exist on the file system.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The build is linked into the store.
+			err := Link(context.Background(), storePath, tc.ex, filepath.Join(tmp, tc.path), tc.force)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The store contains the expected executable, if any.
+			if tc.want == "" {
+				ok, err := store.Has(storePath, tc.ex)
+				if err != nil || ok {
+					t.Errorf("output: got %v (err: %v), want %v", ok, err, false)
+				}
+
+				return
+			}
+
+			fstest.File{Path: executablePath(t, tc.ex), Contents: tc.want}.Assert(t, tmp)
+		})
+	}
+}
+
+/* ------------------------- Function: executablePath ----------------------- */
+
+// executablePath returns the path to the executable within the store, relative
+// to the store's parent directory.
+func executablePath(t *testing.T, ex executable.Executable) string {
+	t.Helper()
+
+	path, err := store.Executable(".gdenv", ex)
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	return path
+}
@@ -0,0 +1,209 @@
+package install
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/internal/osutil"
+	"github.com/coffeebeats/gdenv/pkg/download"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/archive"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
+)
+
+const (
+	envAppData     = "APPDATA"
+	envXDGDataHome = "XDG_DATA_HOME"
+
+	dirnameEditorDataLinux = "godot"
+	dirnameEditorData      = "Godot"
+
+	dirnameTemplatesV3 = "templates"
+	dirnameTemplatesV4 = "export_templates"
+)
+
+var ErrMissingEditorData = errors.New("missing editor data directory")
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: Templates                            */
+/* -------------------------------------------------------------------------- */
+
+// Downloads and caches a specific version of Godot's export templates and then
+// installs them into the editor data directory for the specified 'Platform'.
+// This is where the Godot editor expects to find export templates.
+func Templates( //nolint:funlen
+	ctx context.Context,
+	storePath string,
+	p platform.Platform,
+	v version.Version,
+	force bool,
+) error {
+	// Ensure the store exists.
+	if err := store.Touch(ctx, storePath); err != nil {
+		return err
+	}
+
+	// Define the target 'Templates'.
+	tpl := templates.New(v)
+
+	unlock, err := lockArtifact(ctx, storePath, tpl)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	ok, err := store.Has(storePath, tpl)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ok && !force:
+		log.Info("skipping download; export templates already found")
+	default:
+		if err := addTemplatesToStore(ctx, storePath, tpl); err != nil {
+			return err
+		}
+	}
+
+	pathEditorData, err := templatesEditorDir(p, v)
+	if err != nil {
+		return err
+	}
+
+	out := filepath.Join(pathEditorData, tpl.Dirname())
+
+	info, err := os.Stat(out)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if info != nil && !force {
+		log.Infof("skipping installation; export templates already found: %s", out)
+
+		return nil
+	}
+
+	pathTemplates, err := store.Templates(storePath, tpl)
+	if err != nil {
+		return err
+	}
+
+	// Remove the existing directory to make way for new templates.
+	if err := os.RemoveAll(out); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(pathEditorData, osutil.ModeUserRWXGroupRX); err != nil {
+		return err
+	}
+
+	if err := osutil.CopyDir(ctx, pathTemplates, out); err != nil {
+		return err
+	}
+
+	log.Infof("successfully installed export templates: %s (%s)", v, out)
+
+	return nil
+}
+
+/* ---------------------- Function: addTemplatesToStore --------------------- */
+
+// addTemplatesToStore downloads, validates, and extracts the specified export
+// templates into the store.
+func addTemplatesToStore(ctx context.Context, storePath string, tpl templates.Templates) error {
+	log.Infof("installing export templates: %s", tpl.Version())
+
+	tmp, err := os.MkdirTemp("", "gdenv-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(tmp)
+
+	log.Debugf("using temporary directory: %s", tmp)
+
+	localTplArchive, provenance, err := download.TemplatesWithChecksumValidation(ctx, storePath, tpl.Version(), tmp)
+	if err != nil {
+		return err
+	}
+
+	log.Info("adding export templates to gdenv store")
+
+	if err := archive.Extract(ctx, localTplArchive, tmp); err != nil {
+		return err
+	}
+
+	if err := os.Remove(localTplArchive.Path); err != nil {
+		return err
+	}
+
+	log.Debug("successfully extracted export templates archive")
+
+	return store.Add(
+		store.WithProvenance(ctx, provenance),
+		storePath,
+		artifact.Local[artifact.Artifact]{
+			Artifact: tpl,
+			Path:     filepath.Join(tmp, tpl.Path()),
+		},
+	)
+}
+
+/* ---------------------- Function: templatesEditorDir ---------------------- */
+
+// templatesEditorDir returns the path to the directory in which the Godot
+// editor looks for installed export templates. This depends on both the
+// operating system and the major version of Godot.
+//
+// See https://docs.godotengine.org/en/stable/tutorials/io/data_paths.html.
+func templatesEditorDir(p platform.Platform, v version.Version) (string, error) {
+	var pathEditorData string
+
+	switch p.OS {
+	case platform.Linux:
+		pathDataHome := os.Getenv(envXDGDataHome)
+		if pathDataHome == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", errors.Join(ErrMissingEditorData, err)
+			}
+
+			pathDataHome = filepath.Join(home, ".local", "share")
+		}
+
+		pathEditorData = filepath.Join(pathDataHome, dirnameEditorDataLinux)
+	case platform.MacOS:
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", errors.Join(ErrMissingEditorData, err)
+		}
+
+		pathEditorData = filepath.Join(home, "Library", "Application Support", dirnameEditorData)
+	case platform.Windows:
+		pathAppData := os.Getenv(envAppData)
+		if pathAppData == "" {
+			return "", fmt.Errorf("%w: missing environment variable: %s", ErrMissingEditorData, envAppData)
+		}
+
+		pathEditorData = filepath.Join(pathAppData, dirnameEditorData)
+	default:
+		return "", fmt.Errorf("%w: %s", platform.ErrUnrecognizedOS, p.OS)
+	}
+
+	if v.Major() < 4 { //nolint:mnd
+		return filepath.Join(pathEditorData, dirnameTemplatesV3), nil
+	}
+
+	return filepath.Join(pathEditorData, dirnameTemplatesV4), nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
 	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
 	"github.com/coffeebeats/gdenv/pkg/pin"
 	"github.com/coffeebeats/gdenv/pkg/store"
 )
@@ -18,24 +19,103 @@ var ErrNotInstalled = errors.New("version not installed")
 /* -------------------------------------------------------------------------- */
 
 // Which returns the path to the cached Godot executable specified by the
-// locally or globally pinned version.
+// locally or globally pinned version. If the pin is a version constraint, then
+// the newest installed version for the specified 'Platform' which satisfies it
+// is used.
 func Which(ctx context.Context, storePath string, p platform.Platform, atPath string) (string, error) {
-	v, err := pin.VersionAt(ctx, storePath, atPath)
+	ex, err := WhichExecutable(ctx, storePath, p, atPath)
 	if err != nil {
 		return "", err
 	}
 
+	return store.Executable(storePath, ex)
+}
+
+/* ------------------------ Function: WhichExecutable ----------------------- */
+
+// WhichExecutable returns the cached Godot executable specified by the locally
+// or globally pinned version (see 'Which').
+func WhichExecutable(
+	ctx context.Context,
+	storePath string,
+	p platform.Platform,
+	atPath string,
+) (executable.Executable, error) {
+	c, err := pin.ConstraintAt(ctx, storePath, atPath)
+	if err != nil {
+		return executable.Executable{}, err
+	}
+
+	return ResolveExecutable(ctx, storePath, p, c)
+}
+
+/* ----------------------- Function: ResolveExecutable ---------------------- */
+
+// ResolveExecutable returns the cached Godot executable for the specified
+// 'Platform' which satisfies the 'version.Constraint'. If the constraint isn't
+// an exact version, then the newest installed version satisfying it is used.
+func ResolveExecutable(
+	ctx context.Context,
+	storePath string,
+	p platform.Platform,
+	c version.Constraint,
+) (executable.Executable, error) {
+	v, ok := c.Exact()
+	if !ok {
+		var err error
+
+		v, err = ResolveInstalled(ctx, storePath, p, c)
+		if err != nil {
+			return executable.Executable{}, err
+		}
+	}
+
 	ex := executable.New(v, p)
 
 	ok, err := store.Has(storePath, ex)
 	if err != nil {
-		return "", err
+		return executable.Executable{}, err
 	}
 
 	if !ok {
 		// TODO: Determine whether this should be an error.
-		return "", fmt.Errorf("%w: %s", ErrNotInstalled, v)
+		return executable.Executable{}, fmt.Errorf("%w: %s", ErrNotInstalled, v)
 	}
 
-	return store.Executable(storePath, ex)
+	return ex, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Function: ResolveInstalled                        */
+/* -------------------------------------------------------------------------- */
+
+// ResolveInstalled selects the newest installed executable version for the
+// specified 'Platform' which satisfies the 'version.Constraint'.
+func ResolveInstalled(
+	ctx context.Context,
+	storePath string,
+	p platform.Platform,
+	c version.Constraint,
+) (version.Version, error) {
+	executables, err := store.Executables(ctx, storePath)
+	if err != nil {
+		return version.Version{}, err
+	}
+
+	versions := make([]version.Version, 0, len(executables))
+
+	for _, ex := range executables {
+		if ex.Artifact.Platform() != p {
+			continue
+		}
+
+		versions = append(versions, ex.Artifact.Version())
+	}
+
+	v, ok := c.Select(versions)
+	if !ok {
+		return version.Version{}, fmt.Errorf("%w: %s", ErrNotInstalled, c)
+	}
+
+	return v, nil
 }
@@ -0,0 +1,97 @@
+package pin
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                              Interface: format                             */
+/* -------------------------------------------------------------------------- */
+
+// format is a file format which can pin a version of Godot. Files may contain
+// more than the pinned version (e.g. other tools' versions), so updates must
+// preserve the rest of the file's contents.
+type format interface {
+	// Filename returns the name of pin files using this format.
+	Filename() string
+
+	// Parse parses the pinned 'version.Constraint' from a pin file's contents.
+	// If the contents don't pin a version of Godot, then 'ErrMissingPin' is
+	// returned.
+	Parse(data []byte) (version.Constraint, error)
+
+	// Update returns the pin file's contents with the pinned version set to
+	// 'v'. The contents are empty if the pin file doesn't exist yet.
+	Update(data []byte, v version.Version) ([]byte, error)
+
+	// Remove returns the pin file's contents without the pinned version. If
+	// nothing else remains, then the returned contents should be empty.
+	Remove(data []byte) ([]byte, error)
+}
+
+/* ---------------------------- Function: formats --------------------------- */
+
+// formats returns the supported pin file formats in order of precedence. If a
+// directory contains multiple pin files, then the first one (in this order)
+// which pins a version of Godot applies.
+func formats() []format {
+	return []format{godotVersion{}, manifestFormat{}, toolVersions{}}
+}
+
+/* --------------------------- Function: formatOf --------------------------- */
+
+// formatOf returns the pin file format used by the file at 'path', if any.
+func formatOf(path string) (format, bool) {
+	for _, f := range formats() {
+		if f.Filename() == filepath.Base(path) {
+			return f, true
+		}
+	}
+
+	return nil, false
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Struct: godotVersion                            */
+/* -------------------------------------------------------------------------- */
+
+// godotVersion is the 'gdenv'-specific pin file format, a '.godot-version'
+// file containing only a version (or version constraint).
+type godotVersion struct{}
+
+// Validate at compile-time that 'godotVersion' implements 'format'.
+var _ format = godotVersion{}
+
+/* ---------------------------- Method: Filename ---------------------------- */
+
+func (godotVersion) Filename() string {
+	return pinFilename
+}
+
+/* ------------------------------ Method: Parse ----------------------------- */
+
+func (godotVersion) Parse(data []byte) (version.Constraint, error) {
+	return version.ParseConstraint(string(data))
+}
+
+/* ----------------------------- Method: Update ----------------------------- */
+
+func (godotVersion) Update(_ []byte, v version.Version) ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+/* ----------------------------- Method: Remove ----------------------------- */
+
+func (godotVersion) Remove(_ []byte) ([]byte, error) {
+	return nil, nil
+}
+
+/* ---------------------------- Function: isBlank --------------------------- */
+
+// isBlank returns whether the pin file contents are empty or only whitespace.
+func isBlank(data []byte) bool {
+	return strings.TrimSpace(string(data)) == ""
+}
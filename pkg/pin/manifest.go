@@ -0,0 +1,493 @@
+package pin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+const (
+	// ManifestFilename is the name of the structured, per-project 'gdenv'
+	// configuration file.
+	ManifestFilename = "gdenv.toml"
+
+	keyMono      = "mono"
+	keyPlatforms = "platforms"
+	keyTemplates = "templates"
+	keyVersion   = "version"
+)
+
+var (
+	ErrInvalidManifest    = errors.New("invalid manifest")
+	ErrMissingManifest    = errors.New("missing manifest")
+	ErrPlatformNotAllowed = errors.New("platform not allowed by manifest")
+)
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: Manifest                              */
+/* -------------------------------------------------------------------------- */
+
+// Manifest is a structured, per-project 'gdenv' configuration file (i.e. a
+// 'gdenv.toml' file). Only top-level keys are read; tables are ignored. For
+// example:
+//
+//	version = "4.3"                 # an exact version or version constraint
+//	mono = true                     # use "mono" builds (i.e. with C# support)
+//	templates = true                # the project requires export templates
+//	platforms = ["linux", "macos"]  # the platforms the project may be used on
+type Manifest struct {
+	// Version is the pinned Godot version or version constraint. If empty, then
+	// the manifest doesn't pin a version of Godot.
+	Version string
+
+	// Mono is whether "mono" builds of Godot should be used. If set, a version
+	// without a "mono" label selects its "mono" variant (e.g. '4.3-stable'
+	// selects '4.3-stable_mono').
+	Mono bool
+
+	// Templates is whether the project requires export templates.
+	Templates bool
+
+	// Platforms is the list of operating systems on which the project may be
+	// used. If empty, all platforms are allowed.
+	Platforms []platform.OS
+}
+
+/* ---------------------------- Method: Constraint -------------------------- */
+
+// Constraint returns the 'version.Constraint' pinned by the manifest. If the
+// manifest doesn't pin a version, then 'ErrMissingPin' is returned.
+func (m Manifest) Constraint() (version.Constraint, error) {
+	if m.Version == "" {
+		return version.Constraint{}, fmt.Errorf("%w: no '%s' key", ErrMissingPin, keyVersion)
+	}
+
+	c, err := version.ParseConstraint(m.Version)
+	if err != nil {
+		return version.Constraint{}, err
+	}
+
+	if m.Mono && !isMonoLabel(c.Label()) {
+		c = c.WithLabel(c.Label() + "_" + version.Mono)
+	}
+
+	return c, nil
+}
+
+/* ----------------------------- Method: Allows ----------------------------- */
+
+// Allows returns whether the manifest allows the project to be used on the
+// specified operating system.
+func (m Manifest) Allows(o platform.OS) bool {
+	return len(m.Platforms) == 0 || slices.Contains(m.Platforms, o)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: ReadManifest                           */
+/* -------------------------------------------------------------------------- */
+
+// ReadManifest parses the 'Manifest' at the specified path, which is either a
+// 'gdenv.toml' file or the directory containing one.
+func ReadManifest(path string) (Manifest, error) {
+	if path == "" {
+		return Manifest{}, ErrMissingPath
+	}
+
+	if filepath.Base(path) != ManifestFilename {
+		path = filepath.Join(path, ManifestFilename)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Manifest{}, fmt.Errorf("%w: '%s'", ErrMissingManifest, path)
+		}
+
+		return Manifest{}, err
+	}
+
+	defer f.Close()
+
+	return ParseManifest(f)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: ParseManifest                          */
+/* -------------------------------------------------------------------------- */
+
+// ParseManifest parses a 'Manifest' from the contents of a 'gdenv.toml' file.
+// The contents must be a valid TOML document; keys not used by 'Manifest',
+// including all keys within tables, are ignored.
+func ParseManifest(r io.Reader) (Manifest, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	return parseManifest(data)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Struct: manifestFormat                           */
+/* -------------------------------------------------------------------------- */
+
+// manifestFormat is the pin file format of 'gdenv.toml' files (see 'Manifest').
+// Updates only modify the 'version' (and, if present, 'mono') keys; the rest of
+// the file, including comments and formatting, is preserved.
+type manifestFormat struct{}
+
+// Validate at compile-time that 'manifestFormat' implements 'format'.
+var _ format = manifestFormat{}
+
+/* ---------------------------- Method: Filename ---------------------------- */
+
+func (manifestFormat) Filename() string {
+	return ManifestFilename
+}
+
+/* ------------------------------ Method: Parse ----------------------------- */
+
+func (manifestFormat) Parse(data []byte) (version.Constraint, error) {
+	m, err := parseManifest(data)
+	if err != nil {
+		return version.Constraint{}, err
+	}
+
+	return m.Constraint()
+}
+
+/* ----------------------------- Method: Update ----------------------------- */
+
+func (manifestFormat) Update(data []byte, v version.Version) ([]byte, error) {
+	m, err := parseManifest(data)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, table, err := scanManifest(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var edits []manifestEdit
+
+	// Keep an existing 'mono' key consistent with the version's label so that
+	// reading the manifest produces the same version.
+	if k, ok := keys[keyMono]; ok && m.Mono != isMonoLabel(v.Label()) {
+		edits = append(edits, manifestEdit{value: k.value, replacement: strconv.FormatBool(!m.Mono)})
+	}
+
+	value := strconv.Quote(v.String())
+
+	if k, ok := keys[keyVersion]; ok {
+		edits = append(edits, manifestEdit{value: k.value, replacement: value})
+
+		return applyEdits(data, edits), nil
+	}
+
+	if isBlank(data) {
+		return []byte(keyVersion + " = " + value + "\n"), nil
+	}
+
+	lines := strings.Split(string(applyEdits(data, edits)), "\n")
+
+	// NOTE: Top-level keys must precede all tables, so insert the key before
+	// the first table (or at the end of the file if there are none), above
+	// any blank lines and comments which precede it.
+	i := table
+	for i > 0 && isBlankOrComment(lines[i-1]) {
+		i--
+	}
+
+	lines = slices.Insert(lines, i, keyVersion+" = "+value)
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+/* ----------------------------- Method: Remove ----------------------------- */
+
+func (manifestFormat) Remove(data []byte) ([]byte, error) {
+	if _, err := parseManifest(data); err != nil {
+		return nil, err
+	}
+
+	keys, _, err := scanManifest(data)
+	if err != nil {
+		return nil, err
+	}
+
+	k, ok := keys[keyVersion]
+	if !ok {
+		return data, nil
+	}
+
+	lines := strings.Split(string(data), "\n")
+
+	out := []byte(strings.Join(slices.Delete(lines, k.start, k.end+1), "\n"))
+	if isBlank(out) {
+		return nil, nil
+	}
+
+	return out, nil
+}
+
+/* ------------------------- Function: parseManifest ------------------------ */
+
+// parseManifest parses a 'Manifest' from the contents of a 'gdenv.toml' file.
+func parseManifest(data []byte) (Manifest, error) {
+	var doc map[string]any
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		var errDecode *toml.DecodeError
+		if errors.As(err, &errDecode) {
+			line, _ := errDecode.Position()
+
+			return Manifest{}, fmt.Errorf("%w: line %d: %w", ErrInvalidManifest, line, err)
+		}
+
+		return Manifest{}, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	m := Manifest{Version: "", Mono: false, Templates: false, Platforms: nil}
+
+	for _, key := range []string{keyVersion, keyMono, keyTemplates, keyPlatforms} {
+		value, ok := doc[key]
+		if !ok {
+			continue
+		}
+
+		if err := m.set(key, value); err != nil {
+			return Manifest{}, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+		}
+	}
+
+	return m, nil
+}
+
+/* ------------------------------ Method: set ------------------------------- */
+
+// set updates the 'Manifest' field for the specified top-level key, ignoring
+// unrecognized keys.
+func (m *Manifest) set(key string, value any) error {
+	var err error
+
+	switch key {
+	case keyVersion:
+		m.Version, err = asString(value)
+	case keyMono:
+		m.Mono, err = asBool(value)
+	case keyTemplates:
+		m.Templates, err = asBool(value)
+	case keyPlatforms:
+		var names []string
+
+		names, err = asStrings(value)
+
+		for _, name := range names {
+			o, err := platform.ParseOS(name)
+			if err != nil {
+				return fmt.Errorf("'%s': %w", key, err)
+			}
+
+			m.Platforms = append(m.Platforms, o)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("'%s': %w", key, err)
+	}
+
+	return nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Struct: manifestKey                            */
+/* -------------------------------------------------------------------------- */
+
+// manifestKey describes where a top-level key is defined in a 'gdenv.toml'
+// file.
+type manifestKey struct {
+	// value is the range of bytes containing the key's value.
+	value unstable.Range
+	// start and end are the (inclusive, 0-indexed) lines defining the key.
+	start, end int
+}
+
+/* ------------------------- Function: scanManifest ------------------------- */
+
+// scanManifest returns where each top-level key with a string or boolean value
+// is defined in the contents of a 'gdenv.toml' file, along with the (0-indexed)
+// line declaring the first table. If there are no tables, then the number of
+// lines is returned instead.
+//
+// NOTE: The contents should be validated with 'parseManifest' first.
+func scanManifest(data []byte) (map[string]manifestKey, int, error) {
+	var p unstable.Parser
+
+	p.Reset(data)
+
+	keys := make(map[string]manifestKey)
+
+	for p.NextExpression() {
+		e := p.Expression()
+
+		switch e.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			it := e.Key()
+			it.Next()
+
+			return keys, p.Shape(it.Node().Raw).Start.Line - 1, nil
+		case unstable.KeyValue:
+			it := e.Key()
+			it.Next()
+
+			// Dotted keys define tables, so they never match a 'Manifest' key.
+			if !it.IsLast() {
+				continue
+			}
+
+			r, ok := valueRange(&p, e.Value())
+			if !ok {
+				continue
+			}
+
+			key := it.Node()
+
+			keys[string(key.Data)] = manifestKey{
+				value: r,
+				start: p.Shape(key.Raw).Start.Line - 1,
+				end:   p.Shape(r).End.Line - 1,
+			}
+		default:
+			// NOTE: Comments aren't reported since 'KeepComments' isn't set.
+		}
+	}
+
+	if err := p.Error(); err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidManifest, err)
+	}
+
+	return keys, strings.Count(string(data), "\n") + 1, nil
+}
+
+/* -------------------------- Function: valueRange -------------------------- */
+
+// valueRange returns the range of bytes containing a string or boolean value.
+// Returns 'false' if the value has another type.
+func valueRange(p *unstable.Parser, value *unstable.Node) (unstable.Range, bool) {
+	switch value.Kind {
+	case unstable.String:
+		return value.Raw, true
+	case unstable.Bool:
+		return p.Range(value.Data), true
+	default:
+		return unstable.Range{}, false
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Struct: manifestEdit                            */
+/* -------------------------------------------------------------------------- */
+
+// manifestEdit describes the replacement of a key's value in a 'gdenv.toml'
+// file.
+type manifestEdit struct {
+	value       unstable.Range
+	replacement string
+}
+
+/* -------------------------- Function: applyEdits -------------------------- */
+
+// applyEdits returns a copy of 'data' with each of the edits applied.
+func applyEdits(data []byte, edits []manifestEdit) []byte {
+	// Apply edits from the end of the file so that earlier offsets are valid.
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].value.Offset > edits[j].value.Offset
+	})
+
+	out := slices.Clone(data)
+
+	for _, e := range edits {
+		start, end := int(e.value.Offset), int(e.value.Offset+e.value.Length)
+		out = slices.Replace(out, start, end, []byte(e.replacement)...)
+	}
+
+	return out
+}
+
+/* ------------------------- Function: isMonoLabel -------------------------- */
+
+// isMonoLabel returns whether the version label denotes a "mono" build (e.g.
+// 'stable_mono' or 'beta2_mono').
+func isMonoLabel(label string) bool {
+	return strings.HasSuffix(label, "_"+version.Mono)
+}
+
+/* ----------------------- Function: isBlankOrComment ----------------------- */
+
+// isBlankOrComment returns whether a line of TOML contains only whitespace or
+// a comment.
+func isBlankOrComment(line string) bool {
+	line = strings.TrimSpace(line)
+
+	return line == "" || strings.HasPrefix(line, "#")
+}
+
+/* --------------------------- Function: asString --------------------------- */
+
+// asString returns the decoded TOML value as a string.
+func asString(value any) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string: %v", value)
+	}
+
+	return s, nil
+}
+
+/* ---------------------------- Function: asBool ---------------------------- */
+
+// asBool returns the decoded TOML value as a boolean.
+func asBool(value any) (bool, error) {
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a boolean: %v", value)
+	}
+
+	return b, nil
+}
+
+/* --------------------------- Function: asStrings -------------------------- */
+
+// asStrings returns the decoded TOML value as an array of strings.
+func asStrings(value any) ([]string, error) {
+	values, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an array: %v", value)
+	}
+
+	out := make([]string, 0, len(values))
+
+	for _, v := range values {
+		s, err := asString(v)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, s)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,300 @@
+package pin
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+const manifest = `# Godot settings for this project.
+version = "4.3" # keep in sync with CI
+mono = true
+templates = true
+platforms = [
+    "linux",
+    'macos', # no Windows builds yet
+]
+
+[tool.other]
+version = 1
+`
+
+/* --------------------------- Test: ParseManifest -------------------------- */
+
+func TestParseManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+
+		want Manifest
+		err  error
+	}{
+		{
+			name: "empty file has no settings",
+
+			want: Manifest{Version: "", Mono: false, Templates: false, Platforms: nil},
+		},
+		{
+			name:     "all settings are parsed",
+			contents: manifest,
+
+			want: Manifest{
+				Version:   "4.3",
+				Mono:      true,
+				Templates: true,
+				Platforms: []platform.OS{platform.Linux, platform.MacOS},
+			},
+		},
+		{
+			name:     "unrecognized keys are ignored",
+			contents: "name = \"example\"\nversion = '~4.2'\n",
+
+			want: Manifest{Version: "~4.2", Mono: false, Templates: false, Platforms: nil},
+		},
+		{
+			name: "valid TOML beyond strings and arrays is supported",
+			contents: "\"version\" = \"\"\"\n4.3\"\"\"\n" +
+				"tool.name = \"example\"\n" +
+				"settings = { mono = false, count = 2 }\n" +
+				"platforms = [\"linux\",\n  # comment\n  \"windows\"]\n" +
+				"templates = true\n\n" +
+				"[[tool.other]]\nmono = 1\n",
+
+			want: Manifest{
+				Version:   "4.3",
+				Mono:      false,
+				Templates: true,
+				Platforms: []platform.OS{platform.Linux, platform.Windows},
+			},
+		},
+		{
+			name:     "line without a key returns an error",
+			contents: "4.3\n",
+
+			err: ErrInvalidManifest,
+		},
+		{
+			name:     "duplicate key returns an error",
+			contents: "version = \"4.3\"\nversion = \"4.2\"\n",
+
+			err: ErrInvalidManifest,
+		},
+		{
+			name:     "invalid value type returns an error",
+			contents: "mono = \"yes\"\n",
+
+			err: ErrInvalidManifest,
+		},
+		{
+			name:     "invalid array value type returns an error",
+			contents: "platforms = [\"linux\", 1]\n",
+
+			err: ErrInvalidManifest,
+		},
+		{
+			name:     "dotted key defining a table returns an error",
+			contents: "version.name = \"4.3\"\n",
+
+			err: ErrInvalidManifest,
+		},
+		{
+			name:     "unrecognized platform returns an error",
+			contents: "platforms = [\"android\"]\n",
+
+			err: platform.ErrUnrecognizedOS,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: The manifest is parsed.
+			got, err := ParseManifest(strings.NewReader(tc.contents))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected manifest is returned.
+			if err == nil && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("output: got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ------------------------ Test: Manifest.Constraint ----------------------- */
+
+func TestManifestConstraint(t *testing.T) {
+	tests := []struct {
+		version string
+		mono    bool
+
+		want string
+		err  error
+	}{
+		{version: "", err: ErrMissingPin},
+		{version: "4.3", want: "4.3-stable"},
+		{version: "4.3", mono: true, want: "4.3-stable_mono"},
+		{version: "4.3-beta2", mono: true, want: "4.3-beta2_mono"},
+		{version: "4.3-latest", mono: true, want: "4.3-latest-stable_mono"},
+		{version: "4.3-stable_mono", mono: true, want: "4.3-stable_mono"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.version, func(t *testing.T) {
+			t.Setenv(version.EnvDefaultMono, "")
+
+			m := Manifest{Version: tc.version, Mono: tc.mono, Templates: false, Platforms: nil}
+
+			// When: The manifest's version constraint is determined.
+			got, err := m.Constraint()
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			// Then: The expected constraint is returned.
+			if want := version.MustParseConstraint(tc.want); got.String() != want.String() {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+/* ----------------------- Test: manifestFormat.Update ---------------------- */
+
+func TestManifestFormatUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		version  string
+
+		want string
+		err  error
+	}{
+		{
+			name:    "new file contains only the version",
+			version: "4.3",
+
+			want: "version = \"v4.3-stable\"\n",
+		},
+		{
+			name:     "existing version is replaced in place",
+			contents: "version = \"4.2\" # pinned\ntemplates = true\n",
+			version:  "4.3",
+
+			want: "version = \"v4.3-stable\" # pinned\ntemplates = true\n",
+		},
+		{
+			name:     "version is added after other top-level keys",
+			contents: "# Settings\ntemplates = true\n\n[tool]\nname = \"x\"\n",
+			version:  "4.3",
+
+			want: "# Settings\ntemplates = true\nversion = \"v4.3-stable\"\n\n[tool]\nname = \"x\"\n",
+		},
+		{
+			name:     "version is added before the first table",
+			contents: "platforms = [\n  \"linux\",\n]\n\n# Tool settings\n[tool]\nname = \"x\"\n",
+			version:  "4.3",
+
+			want: "platforms = [\n  \"linux\",\n]\nversion = \"v4.3-stable\"\n\n# Tool settings\n[tool]\nname = \"x\"\n",
+		},
+		{
+			name:     "quoted and multi-line version is replaced in place",
+			contents: "'version' = \"\"\"\n4.2\"\"\" # pinned\n[tool]\nversion = \"1\"\n",
+			version:  "4.3",
+
+			want: "'version' = \"v4.3-stable\" # pinned\n[tool]\nversion = \"1\"\n",
+		},
+		{
+			name:     "existing 'mono' key is updated to match the version",
+			contents: "version = \"4.2\"\nmono = true\n",
+			version:  "4.3",
+
+			want: "version = \"v4.3-stable\"\nmono = false\n",
+		},
+		{
+			name:     "invalid file returns an error",
+			contents: "version\n",
+			version:  "4.3",
+
+			err: ErrInvalidManifest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(version.EnvDefaultMono, "")
+
+			// When: The file contents are updated with the version.
+			got, err := manifestFormat{}.Update([]byte(tc.contents), version.MustParse(tc.version))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected file contents are returned.
+			if string(got) != tc.want {
+				t.Errorf("output: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ----------------------- Test: manifestFormat.Remove ---------------------- */
+
+func TestManifestFormatRemove(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+
+		want string
+	}{
+		{
+			name:     "version is removed from other settings",
+			contents: "version = \"4.3\"\ntemplates = true\n",
+
+			want: "templates = true\n",
+		},
+		{
+			name:     "multi-line version is removed entirely",
+			contents: "templates = true\nversion = \"\"\"\n4.3\"\"\" # pinned\n\n[tool]\nversion = \"1\"\n",
+
+			want: "templates = true\n\n[tool]\nversion = \"1\"\n",
+		},
+		{
+			name:     "file with only a version is emptied",
+			contents: "version = \"4.3\"\n",
+
+			want: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: The version is removed from the file contents.
+			got, err := manifestFormat{}.Remove([]byte(tc.contents))
+
+			// Then: No error is returned.
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Then: The expected file contents are returned.
+			if string(got) != tc.want {
+				t.Errorf("output: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,9 @@ package pin
 
 import (
 	"errors"
+	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
 )
 
@@ -16,7 +19,9 @@ var (
 /*                               Function: clean                              */
 /* -------------------------------------------------------------------------- */
 
-// Returns a "cleaned" version of the specified pin file path.
+// Returns a "cleaned" version of the specified pin file path. If the path
+// doesn't name a supported pin file (see 'formats'), then it's treated as a
+// directory containing a '.godot-version' file.
 func clean(path string) (string, error) {
 	if path == "" {
 		return path, ErrMissingPath
@@ -27,9 +32,102 @@ func clean(path string) (string, error) {
 		return path, errors.Join(ErrInvalidPath, err)
 	}
 
-	if filepath.Base(path) != pinFilename {
+	if _, ok := formatOf(path); !ok {
 		path = filepath.Join(path, pinFilename)
 	}
 
 	return path, nil
 }
+
+/* ------------------------------ Function: find ---------------------------- */
+
+// find returns the path, format, and contents of the pin file which applies to
+// the specified path. If 'path' names a pin file then only that file is read;
+// otherwise, 'path' is treated as a directory (see 'findIn').
+func find(path string) (string, format, []byte, error) {
+	if _, ok := formatOf(path); !ok {
+		if path == "" {
+			return "", nil, nil, ErrMissingPath
+		}
+
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return "", nil, nil, errors.Join(ErrInvalidPath, err)
+		}
+
+		return findIn(path)
+	}
+
+	path, err := clean(path)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	f, _ := formatOf(path)
+
+	data, err := readPin(path, f)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return path, f, data, nil
+}
+
+/* ----------------------------- Function: findIn --------------------------- */
+
+// findIn returns the path, format, and contents of the pin file within the
+// specified directory. Pin files are checked in order of precedence (see
+// 'formats'), skipping those which don't pin a version of Godot (e.g. a
+// '.tool-versions' file without a 'godot' entry). If no pin file applies, then
+// 'ErrMissingPin' is returned.
+func findIn(dir string) (string, format, []byte, error) {
+	for _, f := range formats() {
+		path := filepath.Join(dir, f.Filename())
+
+		data, err := readPin(path, f)
+		if err != nil {
+			if errors.Is(err, ErrMissingPin) {
+				continue
+			}
+
+			return "", nil, nil, err
+		}
+
+		return path, f, data, nil
+	}
+
+	return "", nil, nil, fmt.Errorf("%w: '%s'", ErrMissingPin, dir)
+}
+
+/* ---------------------------- Function: readPin --------------------------- */
+
+// readPin reads the contents of the pin file at 'path'. If the file doesn't
+// exist or doesn't pin a version of Godot, then 'ErrMissingPin' is returned.
+// Note that a pin file with an invalid version is still returned.
+func readPin(path string, f format) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%w: '%s'", ErrMissingPin, path)
+	}
+
+	// Validate that the file is a regular file; this catches cases where
+	// there's a directory named after a pin file.
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%w: '%s'", fs.ErrInvalid, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.Parse(data); errors.Is(err, ErrMissingPin) {
+		return nil, fmt.Errorf("%w: '%s'", err, path)
+	}
+
+	return data, nil
+}
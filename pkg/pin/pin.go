@@ -7,13 +7,23 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/coffeebeats/gdenv/internal/osutil"
+	"github.com/coffeebeats/gdenv/pkg/godot/project"
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
+	"github.com/coffeebeats/gdenv/pkg/store"
 )
 
+// EnvInferProject is an environment variable which, when set to a truthy
+// value, causes the Godot version declared by a 'project.godot' file to be used
+// when no local pin exists (see 'ConstraintAt').
+const EnvInferProject = "GDENV_INFER_PROJECT"
+
 var (
+	ErrInexactPin     = errors.New("pin is a version constraint")
 	ErrMissingPin     = errors.New("missing version pin")
+	ErrNoMatch        = errors.New("no installed version matches pin")
 	ErrUnexpectedFile = errors.New("unexpected file")
 )
 
@@ -21,46 +31,73 @@ var (
 /*                               Function: Read                               */
 /* -------------------------------------------------------------------------- */
 
-// Parses a 'Version' from the specified pin file.
+// Parses an exact 'Version' from the specified pin file. If the pin file
+// contains a version constraint then 'ErrInexactPin' is returned; use
+// 'ReadConstraint' and 'Resolve' to handle version constraints.
 func Read(path string) (version.Version, error) {
-	if path == "" {
-		return version.Version{}, ErrMissingPath
-	}
-
-	path, err := clean(path)
+	c, err := ReadConstraint(path)
 	if err != nil {
 		return version.Version{}, err
 	}
 
-	bytes, err := os.ReadFile(path)
-	if err != nil {
-		if !errors.Is(err, fs.ErrNotExist) {
-			return version.Version{}, err
-		}
+	v, ok := c.Exact()
+	if !ok {
+		return version.Version{}, fmt.Errorf("%w: %s", ErrInexactPin, c)
+	}
 
-		return version.Version{}, fmt.Errorf("%w: '%s'", ErrMissingPin, path)
+	return v, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Function: ReadConstraint                          */
+/* -------------------------------------------------------------------------- */
+
+// Parses a 'version.Constraint' from the specified pin file. If 'path' is a
+// directory, then the pin file within it which takes precedence is read (see
+// 'formats'). Note that a pin file containing an exact version will produce a
+// 'version.Constraint' which only matches that version.
+func ReadConstraint(path string) (version.Constraint, error) {
+	_, f, data, err := find(path)
+	if err != nil {
+		return version.Constraint{}, err
 	}
 
-	v, err := version.Parse(string(bytes))
+	c, err := f.Parse(data)
 	if err != nil {
-		return version.Version{}, err
+		return version.Constraint{}, err
 	}
 
-	return v, nil
+	return c, nil
 }
 
 /* -------------------------------------------------------------------------- */
 /*                              Function: Remove                              */
 /* -------------------------------------------------------------------------- */
 
-// Deletes the specified pin file if it exists.
+// Removes the pinned version from the specified pin file (or the pin file
+// within the specified directory) if it exists. Pin files which contain other
+// settings (e.g. a '.tool-versions' file pinning other tools) are updated;
+// otherwise, the pin file is deleted.
 func Remove(path string) error {
-	path, err := clean(path)
+	pinPath, f, data, err := find(path)
+	if err != nil {
+		if errors.Is(err, ErrMissingPin) {
+			return nil
+		}
+
+		return err
+	}
+
+	data, err = f.Remove(data)
 	if err != nil {
 		return err
 	}
 
-	if err := os.Remove(path); err != nil {
+	if len(data) > 0 {
+		return os.WriteFile(pinPath, data, osutil.ModeUserRW)
+	}
+
+	if err := os.Remove(pinPath); err != nil {
 		if !errors.Is(err, fs.ErrNotExist) {
 			return err
 		}
@@ -69,34 +106,149 @@ func Remove(path string) error {
 	return nil
 }
 
+/* -------------------------------------------------------------------------- */
+/*                              Function: Resolve                             */
+/* -------------------------------------------------------------------------- */
+
+// Resolves a 'version.Constraint' to the newest matching executable version
+// installed in the store (for any platform). Exact constraints are returned
+// as-is, without checking whether they're installed.
+func Resolve(ctx context.Context, storePath string, c version.Constraint) (version.Version, error) {
+	if v, ok := c.Exact(); ok {
+		return v, nil
+	}
+
+	executables, err := store.Executables(ctx, storePath)
+	if err != nil {
+		return version.Version{}, err
+	}
+
+	versions := make([]version.Version, 0, len(executables))
+	for _, ex := range executables {
+		versions = append(versions, ex.Artifact.Version())
+	}
+
+	v, ok := c.Select(versions)
+	if !ok {
+		return version.Version{}, fmt.Errorf("%w: %s", ErrNoMatch, c)
+	}
+
+	return v, nil
+}
+
 /* -------------------------------------------------------------------------- */
 /*                             Function: VersionAt                            */
 /* -------------------------------------------------------------------------- */
 
 // Resolves a version for the specified directory. This function starts by
 // looking for a pin file in the specified directory or any ancestor
-// directories. If none are found then globally-pinned version is checked.
+// directories. If none are found then the version declared by a Godot project
+// is used, if enabled (see 'EnvInferProject'), followed by the globally-pinned
+// version. If the pin is a version constraint, then it's resolved against the
+// installed executable versions (see 'Resolve').
 func VersionAt(ctx context.Context, storePath, path string) (version.Version, error) {
-	path, err := clean(path)
+	c, err := ConstraintAt(ctx, storePath, path)
 	if err != nil {
 		return version.Version{}, err
 	}
 
+	return Resolve(ctx, storePath, c)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: ConstraintAt                           */
+/* -------------------------------------------------------------------------- */
+
+// Reads the pinned 'version.Constraint' for the specified directory. The same
+// resolution strategy as 'VersionAt' is used, but the pinned constraint is
+// returned without resolving it to an installed version.
+func ConstraintAt(ctx context.Context, storePath, path string) (version.Constraint, error) {
+	pinPath, err := Locate(ctx, storePath, path)
+	if err != nil {
+		return version.Constraint{}, err
+	}
+
+	if pinPath == storePath && isInferProjectEnabled() {
+		c, err := ConstraintFromProject(ctx, path)
+		if err == nil {
+			return c, nil
+		}
+
+		if !errors.Is(err, project.ErrMissingProject) && !errors.Is(err, project.ErrMissingVersion) {
+			return version.Constraint{}, err
+		}
+	}
+
+	return ReadConstraint(pinPath)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                       Function: ConstraintFromProject                      */
+/* -------------------------------------------------------------------------- */
+
+// Returns the 'version.Constraint' declared by the Godot project containing the
+// specified directory (see 'project.Project.Constraint'). The specified
+// directory and its ancestors are searched for a 'project.godot' file.
+func ConstraintFromProject(ctx context.Context, path string) (version.Constraint, error) {
+	path, err := clean(path)
+	if err != nil {
+		return version.Constraint{}, err
+	}
+
+	projectPath, err := project.Locate(ctx, filepath.Dir(path))
+	if err != nil {
+		return version.Constraint{}, err
+	}
+
+	p, err := project.Read(projectPath)
+	if err != nil {
+		return version.Constraint{}, err
+	}
+
+	c, err := p.Constraint()
+	if err != nil {
+		return version.Constraint{}, fmt.Errorf("%w: '%s'", err, projectPath)
+	}
+
+	return c, nil
+}
+
+/* ---------------------- Function: isInferProjectEnabled ------------------- */
+
+// isInferProjectEnabled returns whether versions should be inferred from Godot
+// projects (see 'EnvInferProject').
+func isInferProjectEnabled() bool {
+	isEnabled, err := strconv.ParseBool(os.Getenv(EnvInferProject))
+
+	return err == nil && isEnabled
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Function: Locate                              */
+/* -------------------------------------------------------------------------- */
+
+// Locates the directory containing the pin file which applies to the specified
+// directory. The specified directory and its ancestors are checked in order;
+// if none contain a pin file (see 'formats'), then the store path is returned
+// (i.e. the global pin applies, which may or may not exist).
+func Locate(ctx context.Context, storePath, path string) (string, error) {
+	path, err := clean(path)
+	if err != nil {
+		return "", err
+	}
+
 	path = filepath.Dir(path)
 	root := filepath.VolumeName(path) + string(os.PathSeparator)
 
 	// Check if the specified path (or any ancestors) has a pin
 	for path != root {
 		if ctx.Err() != nil {
-			return version.Version{}, ctx.Err()
+			return "", ctx.Err()
 		}
 
-		pinPath := filepath.Join(path, pinFilename)
-
-		info, err := os.Stat(pinPath)
-		if err != nil {
-			if !errors.Is(err, fs.ErrNotExist) {
-				return version.Version{}, err
+		if _, _, _, err := findIn(path); err != nil {
+			if !errors.Is(err, ErrMissingPin) {
+				return "", err
 			}
 
 			path = filepath.Dir(path)
@@ -104,36 +256,64 @@ func VersionAt(ctx context.Context, storePath, path string) (version.Version, er
 			continue
 		}
 
-		// Validate that the file is a regular file; this catches cases where
-		// there's a directory named after 'pinFilename'.
-		if !info.Mode().IsRegular() {
-			return version.Version{}, fmt.Errorf("%w: '%s'", fs.ErrInvalid, pinPath)
-		}
-
-		break
+		return path, nil
 	}
 
-	// Try reading a global pin file if the specified directory and all
+	// Fall back to the global pin file if the specified directory and all
 	// ancestors were missing pin files.
-	if path == root {
-		path = storePath
-	}
-
-	return Read(path)
+	return storePath, nil
 }
 
 /* -------------------------------------------------------------------------- */
 /*                               Function: Write                              */
 /* -------------------------------------------------------------------------- */
 
-// Writes a 'Version' to the specified pin file path.
+// Writes a 'Version' to the specified pin file path. If 'path' is a directory,
+// then the existing pin file within it which takes precedence is updated in
+// its own format (see 'formats'), preserving any other contents. If there
+// isn't one, then a '.godot-version' file is created.
 //
 // NOTE: This function will fail if any directories along the path do not exist.
 func Write(v version.Version, path string) error {
-	path, err := clean(path)
+	pinPath, f, data, err := find(path)
+	if err != nil {
+		if !errors.Is(err, ErrMissingPin) {
+			return err
+		}
+
+		// The pin file may exist without pinning a version of Godot (e.g. a
+		// '.tool-versions' file for other tools), so update it if it exists.
+		if pinPath, err = clean(path); err != nil {
+			return err
+		}
+
+		f, _ = formatOf(pinPath)
+
+		if data, err = os.ReadFile(pinPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	data, err = f.Update(data, v)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(path, []byte(v.String()), osutil.ModeUserRW)
+	return os.WriteFile(pinPath, data, osutil.ModeUserRW)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Function: ManifestAt                            */
+/* -------------------------------------------------------------------------- */
+
+// Reads the 'Manifest' for the specified directory, which is the 'gdenv.toml'
+// file alongside the pin file which applies to the directory (see 'Locate').
+// If there isn't one, then 'ErrMissingManifest' is returned.
+func ManifestAt(ctx context.Context, storePath, path string) (Manifest, error) {
+	pinPath, err := Locate(ctx, storePath, path)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	return ReadManifest(pinPath)
 }
@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/coffeebeats/gdenv/internal/fstest"
@@ -43,6 +44,12 @@ func TestRead(t *testing.T) {
 			path: fstest.Relative(".godot-version"),
 			err:  ErrMissingPin,
 		},
+		{
+			name:  "version constraint returns an error",
+			path:  fstest.Absolute(".godot-version"),
+			files: []fstest.Writer{fstest.File{Path: ".godot-version", Contents: "~4.2"}},
+			err:   ErrInexactPin,
+		},
 
 		// Valid inputs
 		{
@@ -81,6 +88,186 @@ func TestRead(t *testing.T) {
 
 }
 
+/* -------------------------- Test: ReadConstraint -------------------------- */
+
+func TestReadConstraint(t *testing.T) {
+	tests := []struct {
+		name string
+
+		path  fstest.Filepath
+		files []fstest.Writer
+
+		want string
+		err  error
+	}{
+		// Invalid inputs
+		{
+			name: "missing path returns an error",
+			path: fstest.Exact(""),
+			err:  ErrMissingPath,
+		},
+		{
+			name:  "invalid pin file returns an error",
+			path:  fstest.Absolute(".godot-version"),
+			files: []fstest.Writer{fstest.File{Path: ".godot-version", Contents: ">=4.2 <4.3-beta1"}},
+			err:   version.ErrConflictingLabels,
+		},
+
+		// Valid inputs
+		{
+			name:  "exact version is read as a constraint",
+			path:  fstest.Absolute(".godot-version"),
+			files: []fstest.Writer{fstest.File{Path: ".godot-version", Contents: "v4.2.1-stable"}},
+			want:  "v4.2.1-stable",
+		},
+		{
+			name:  "version range is read as a constraint",
+			path:  fstest.Absolute(".godot-version"),
+			files: []fstest.Writer{fstest.File{Path: ".godot-version", Contents: "~4.2\n"}},
+			want:  ">=v4.2-stable <v4.3-stable",
+		},
+		{
+			name:  "'.tool-versions' entry is read",
+			path:  fstest.Absolute(""),
+			files: []fstest.Writer{fstest.File{Path: ".tool-versions", Contents: "nodejs 20\ngodot 4.2.1\n"}},
+			want:  "v4.2.1-stable",
+		},
+		{
+			name:  "'gdenv.toml' version is read",
+			path:  fstest.Absolute(""),
+			files: []fstest.Writer{fstest.File{Path: "gdenv.toml", Contents: "version = \"4.2.1\"\nmono = true\n"}},
+			want:  "v4.2.1-stable_mono",
+		},
+		{
+			name: "'gdenv.toml' takes precedence over '.tool-versions'",
+			path: fstest.Absolute(""),
+			files: []fstest.Writer{
+				fstest.File{Path: ".tool-versions", Contents: "godot 4.2\n"},
+				fstest.File{Path: "gdenv.toml", Contents: "version = \"4.3\"\n"},
+			},
+			want: "v4.3-stable",
+		},
+		{
+			name: "'.godot-version' takes precedence over other formats",
+			path: fstest.Absolute(""),
+			files: []fstest.Writer{
+				fstest.File{Path: ".godot-version", Contents: "4.1"},
+				fstest.File{Path: "gdenv.toml", Contents: "version = \"4.3\"\n"},
+			},
+			want: "v4.1-stable",
+		},
+		{
+			name: "'gdenv.toml' without a version is skipped",
+			path: fstest.Absolute(""),
+			files: []fstest.Writer{
+				fstest.File{Path: ".tool-versions", Contents: "godot 4.2\n"},
+				fstest.File{Path: "gdenv.toml", Contents: "templates = true\n"},
+			},
+			want: "v4.2-stable",
+		},
+		{
+			name:  "'.tool-versions' without a 'godot' entry is missing a pin",
+			path:  fstest.Absolute(""),
+			files: []fstest.Writer{fstest.File{Path: ".tool-versions", Contents: "nodejs 20\n"}},
+			err:   ErrMissingPin,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			t.Setenv(version.EnvDefaultMono, "")
+
+			// Given: The specified files exist on the file system.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The pin file is read.
+			got, err := ReadConstraint(tc.path.Resolve(t, tmp))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected constraint is returned.
+			if err == nil && got.String() != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ------------------------------ Test: Resolve ----------------------------- */
+
+func TestResolve(t *testing.T) {
+	installed := []fstest.Writer{
+		fstest.File{Path: ".gdenv/editor/v4.1.3-stable/linux.x86_64/Godot_v4.1.3-stable_linux.x86_64"},
+		fstest.File{Path: ".gdenv/editor/v4.2-stable/linux.x86_64/Godot_v4.2-stable_linux.x86_64"},
+		fstest.File{Path: ".gdenv/editor/v4.2.1-stable/linux.x86_64/Godot_v4.2.1-stable_linux.x86_64"},
+		fstest.File{Path: ".gdenv/editor/v4.3-beta1/linux.x86_64/Godot_v4.3-beta1_linux.x86_64"},
+	}
+
+	tests := []struct {
+		name       string
+		constraint string
+
+		want version.Version
+		err  error
+	}{
+		{
+			name:       "exact version is returned even if not installed",
+			constraint: "4.0",
+			want:       version.MustParse("v4.0-stable"),
+		},
+		{
+			name:       "newest matching installed version is returned",
+			constraint: "~4.2",
+			want:       version.MustParse("v4.2.1-stable"),
+		},
+		{
+			name:       "label must match the installed version",
+			constraint: "4-latest-beta1",
+			want:       version.MustParse("v4.3-beta1"),
+		},
+		{
+			name:       "no matching installed version returns an error",
+			constraint: ">=4.3",
+			err:        ErrNoMatch,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			// Given: The specified executables are installed.
+			for _, f := range installed {
+				f.Write(t, tmp)
+			}
+
+			// When: The constraint is resolved against the store.
+			got, err := Resolve(
+				context.Background(),
+				filepath.Join(tmp, ".gdenv"),
+				version.MustParseConstraint(tc.constraint),
+			)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected version is returned.
+			if got != tc.want {
+				t.Errorf("output: got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
 /* ----------------------------- Test: VersionAt ---------------------------- */
 
 func TestVersionAt(t *testing.T) {
@@ -132,6 +319,142 @@ func TestVersionAt(t *testing.T) {
 	}
 }
 
+/* --------------------------- Test: ConstraintAt --------------------------- */
+
+func TestConstraintAt(t *testing.T) {
+	features := "[application]\n\nconfig/features=PackedStringArray(\"4.2\", \"C#\")\n"
+
+	tests := []struct {
+		name  string
+		infer bool
+		files []fstest.Writer
+
+		want string
+		err  error
+	}{
+		{
+			name:  "project is ignored by default",
+			files: []fstest.Writer{fstest.File{Path: "a/project.godot", Contents: features}},
+
+			err: ErrMissingPin,
+		},
+		{
+			name:  "project version is used if enabled",
+			infer: true,
+			files: []fstest.Writer{fstest.File{Path: "a/project.godot", Contents: features}},
+
+			want: "4.2-latest-stable_mono",
+		},
+		{
+			name:  "local pin is preferred over project version",
+			infer: true,
+			files: []fstest.Writer{
+				fstest.File{Path: "a/project.godot", Contents: features},
+				fstest.File{Path: "a/.godot-version", Contents: "4.1"},
+			},
+
+			want: "4.1",
+		},
+		{
+			name: "ancestor pin is used if '.tool-versions' doesn't pin Godot",
+			files: []fstest.Writer{
+				fstest.File{Path: "a/b/.tool-versions", Contents: "nodejs 20\n"},
+				fstest.File{Path: "a/gdenv.toml", Contents: "version = \"4.2\"\n"},
+			},
+
+			want: "4.2",
+		},
+		{
+			name:  "global pin is used if project doesn't declare a version",
+			infer: true,
+			files: []fstest.Writer{
+				fstest.File{Path: "a/project.godot", Contents: "config_version=4\n"},
+				fstest.File{Path: ".gdenv/.godot-version", Contents: "3.5"},
+			},
+
+			want: "3.5",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			t.Setenv(EnvInferProject, fmt.Sprint(tc.infer))
+			t.Setenv(version.EnvDefaultMono, "")
+
+			// Given: The specified files exist on the file system.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The pinned constraint is read within the project.
+			storePath := filepath.Join(tmp, ".gdenv")
+			got, err := ConstraintAt(context.Background(), storePath, filepath.Join(tmp, "a", "b"))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			// Then: The expected constraint is returned.
+			if want := version.MustParseConstraint(tc.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+/* ------------------------------ Test: Locate ------------------------------ */
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		pin  string // where the pin file exists
+		path string // where to query
+
+		want string // result of the query
+	}{
+		{pin: "a", path: "", want: ".gdenv"},
+		{pin: "a", path: "a", want: "a"},
+		{pin: "a", path: "a/b/c", want: "a"},
+		{pin: "a/b", path: "a/c", want: ".gdenv"},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			tmp := t.TempDir()
+
+			pin, err := clean(filepath.Join(tmp, tc.pin))
+			if err != nil {
+				t.Fatalf("test setup: %v", err)
+			}
+
+			// Create the pin file
+			if err := os.MkdirAll(filepath.Dir(pin), osutil.ModeUserRWXGroupRX); err != nil {
+				t.Fatalf("test setup: %v", err)
+			}
+
+			if err := os.WriteFile(pin, []byte(version.Godot4().String()), osutil.ModeUserRW); err != nil {
+				t.Fatalf("test setup: %v", err)
+			}
+
+			storePath := filepath.Join(tmp, ".gdenv")
+			got, err := Locate(context.Background(), storePath, filepath.Join(tmp, tc.path))
+			if err != nil {
+				t.Errorf("err: got %v, want %v", err, nil)
+			}
+
+			if want := filepath.Join(tmp, tc.want); got != want {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 /* ------------------------------ Test: Remove ------------------------------ */
 
 func TestRemove(t *testing.T) {
@@ -229,3 +552,103 @@ func TestWrite(t *testing.T) {
 		})
 	}
 }
+
+/* ----------------------- Test: Write (existing pins) ---------------------- */
+
+func TestWriteExisting(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []fstest.Writer
+
+		want fstest.Asserter
+	}{
+		{
+			name:  "'.tool-versions' pin is updated in place",
+			files: []fstest.Writer{fstest.File{Path: ".tool-versions", Contents: "godot 4.2\nnodejs 20\n"}},
+
+			want: fstest.File{Path: ".tool-versions", Contents: "godot 4.3-stable\nnodejs 20\n"},
+		},
+		{
+			name:  "'gdenv.toml' pin is updated in place",
+			files: []fstest.Writer{fstest.File{Path: "gdenv.toml", Contents: "version = \"4.2\"\ntemplates = true\n"}},
+
+			want: fstest.File{Path: "gdenv.toml", Contents: "version = \"v4.3-stable\"\ntemplates = true\n"},
+		},
+		{
+			name:  "'.godot-version' is created if no file pins Godot",
+			files: []fstest.Writer{fstest.File{Path: ".tool-versions", Contents: "nodejs 20\n"}},
+
+			want: fstest.File{Path: pinFilename, Contents: "v4.3-stable"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			// Given: The specified files exist on the file system.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The version is pinned in the directory.
+			if err := Write(version.MustParse("4.3"), tmp); err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Then: The expected files exist on the file system.
+			tc.want.Assert(t, tmp)
+		})
+	}
+}
+
+/* ---------------------- Test: Remove (existing pins) ---------------------- */
+
+func TestRemoveExisting(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []fstest.Writer
+
+		want []fstest.Asserter
+	}{
+		{
+			name:  "'.tool-versions' entry is removed",
+			files: []fstest.Writer{fstest.File{Path: ".tool-versions", Contents: "godot 4.2\nnodejs 20\n"}},
+
+			want: []fstest.Asserter{fstest.File{Path: ".tool-versions", Contents: "nodejs 20\n"}},
+		},
+		{
+			name: "only the applicable pin is removed",
+			files: []fstest.Writer{
+				fstest.File{Path: "gdenv.toml", Contents: "version = \"4.2\"\n"},
+				fstest.File{Path: ".tool-versions", Contents: "godot 4.1\n"},
+			},
+
+			want: []fstest.Asserter{
+				fstest.Absent{Path: "gdenv.toml"},
+				fstest.File{Path: ".tool-versions", Contents: "godot 4.1\n"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			// Given: The specified files exist on the file system.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The pin is removed from the directory.
+			if err := Remove(tmp); err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Then: The expected files exist on the file system.
+			for _, a := range tc.want {
+				a.Assert(t, tmp)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package pin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+const (
+	// toolVersionsFilename is the name of 'asdf'/'mise'-style pin files, which
+	// pin versions of multiple tools (see https://asdf-vm.com/manage/configuration.html).
+	toolVersionsFilename = ".tool-versions"
+
+	// toolVersionsName is the name of the Godot tool in '.tool-versions' files.
+	toolVersionsName = "godot"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                            Struct: toolVersions                            */
+/* -------------------------------------------------------------------------- */
+
+// toolVersions is the '.tool-versions' pin file format used by 'asdf' and
+// 'mise'. Each line names a tool followed by one or more versions, of which
+// only the first is used. Other tools' lines and comments are preserved.
+type toolVersions struct{}
+
+// Validate at compile-time that 'toolVersions' implements 'format'.
+var _ format = toolVersions{}
+
+/* ---------------------------- Method: Filename ---------------------------- */
+
+func (toolVersions) Filename() string {
+	return toolVersionsFilename
+}
+
+/* ------------------------------ Method: Parse ----------------------------- */
+
+func (toolVersions) Parse(data []byte) (version.Constraint, error) {
+	lines := strings.Split(string(data), "\n")
+
+	i := findToolVersion(lines)
+	if i < 0 {
+		return version.Constraint{}, fmt.Errorf("%w: no '%s' entry", ErrMissingPin, toolVersionsName)
+	}
+
+	fields := strings.Fields(stripComment(lines[i]))
+	if len(fields) < 2 { //nolint:mnd
+		return version.Constraint{}, fmt.Errorf("%w: '%s'", version.ErrMissing, strings.TrimSpace(lines[i]))
+	}
+
+	return version.ParseConstraint(fields[1])
+}
+
+/* ----------------------------- Method: Update ----------------------------- */
+
+func (toolVersions) Update(data []byte, v version.Version) ([]byte, error) {
+	// NOTE: Other tools which read '.tool-versions' files don't expect the 'v'
+	// prefix used by 'gdenv' pin files.
+	entry := toolVersionsName + " " + strings.TrimPrefix(v.String(), version.Prefix)
+
+	lines := strings.Split(string(data), "\n")
+
+	i := findToolVersion(lines)
+	if i < 0 {
+		return []byte(appendLine(string(data), entry)), nil
+	}
+
+	if _, comment, ok := strings.Cut(lines[i], "#"); ok {
+		entry += " #" + comment
+	}
+
+	lines[i] = entry
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+/* ----------------------------- Method: Remove ----------------------------- */
+
+func (toolVersions) Remove(data []byte) ([]byte, error) {
+	lines := strings.Split(string(data), "\n")
+
+	i := findToolVersion(lines)
+	if i < 0 {
+		return data, nil
+	}
+
+	out := []byte(strings.Join(append(lines[:i], lines[i+1:]...), "\n"))
+	if isBlank(out) {
+		return nil, nil
+	}
+
+	return out, nil
+}
+
+/* ------------------------ Function: findToolVersion ----------------------- */
+
+// findToolVersion returns the index of the line pinning Godot within the lines
+// of a '.tool-versions' file, or '-1' if there isn't one.
+func findToolVersion(lines []string) int {
+	for i, line := range lines {
+		fields := strings.Fields(stripComment(line))
+		if len(fields) > 0 && fields[0] == toolVersionsName {
+			return i
+		}
+	}
+
+	return -1
+}
+
+/* -------------------------- Function: stripComment ------------------------ */
+
+// stripComment removes a trailing '#' comment from a '.tool-versions' line.
+func stripComment(line string) string {
+	line, _, _ = strings.Cut(line, "#")
+
+	return line
+}
+
+/* --------------------------- Function: appendLine ------------------------- */
+
+// appendLine appends a line to the contents of a file, adding a line break
+// between them if needed. The result always ends with a line break.
+func appendLine(contents, line string) string {
+	if contents != "" && !strings.HasSuffix(contents, "\n") {
+		contents += "\n"
+	}
+
+	return contents + line + "\n"
+}
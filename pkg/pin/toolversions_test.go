@@ -0,0 +1,164 @@
+package pin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* ------------------------ Test: toolVersions.Parse ------------------------ */
+
+func TestToolVersionsParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+
+		want string
+		err  error
+	}{
+		{
+			name: "empty file doesn't pin a version",
+
+			err: ErrMissingPin,
+		},
+		{
+			name:     "file without a 'godot' entry doesn't pin a version",
+			contents: "nodejs 20.11.0\n# godot 4.3\n",
+
+			err: ErrMissingPin,
+		},
+		{
+			name:     "'godot' entry without a version returns an error",
+			contents: "godot # TODO\n",
+
+			err: version.ErrMissing,
+		},
+		{
+			name:     "'godot' entry is parsed",
+			contents: "nodejs 20.11.0\ngodot 4.2.1-stable # pinned\n",
+
+			want: "v4.2.1-stable",
+		},
+		{
+			name:     "only the first version of a 'godot' entry is used",
+			contents: "godot 4.3-stable 4.2.1-stable\n",
+
+			want: "v4.3-stable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: The file contents are parsed.
+			got, err := toolVersions{}.Parse([]byte(tc.contents))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected constraint is returned.
+			if err == nil && got.String() != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ------------------------ Test: toolVersions.Update ----------------------- */
+
+func TestToolVersionsUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		version  string
+
+		want string
+	}{
+		{
+			name:    "new file contains only the 'godot' entry",
+			version: "4.3",
+
+			want: "godot 4.3-stable\n",
+		},
+		{
+			name:     "'godot' entry is appended to other tools",
+			contents: "nodejs 20.11.0",
+			version:  "4.3",
+
+			want: "nodejs 20.11.0\ngodot 4.3-stable\n",
+		},
+		{
+			name:     "existing 'godot' entry is replaced in place",
+			contents: "godot 4.2.1-stable # pinned\nnodejs 20.11.0\n",
+			version:  "4.3-stable_mono",
+
+			want: "godot 4.3-stable_mono # pinned\nnodejs 20.11.0\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: The file contents are updated with the version.
+			got, err := toolVersions{}.Update([]byte(tc.contents), version.MustParse(tc.version))
+
+			// Then: No error is returned.
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Then: The expected file contents are returned.
+			if string(got) != tc.want {
+				t.Errorf("output: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ------------------------ Test: toolVersions.Remove ----------------------- */
+
+func TestToolVersionsRemove(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+
+		want string
+	}{
+		{
+			name:     "file without a 'godot' entry is unchanged",
+			contents: "nodejs 20.11.0\n",
+
+			want: "nodejs 20.11.0\n",
+		},
+		{
+			name:     "'godot' entry is removed from other tools",
+			contents: "godot 4.3\nnodejs 20.11.0\n",
+
+			want: "nodejs 20.11.0\n",
+		},
+		{
+			name:     "file with only a 'godot' entry is emptied",
+			contents: "godot 4.3\n",
+
+			want: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: The 'godot' entry is removed from the file contents.
+			got, err := toolVersions{}.Remove([]byte(tc.contents))
+
+			// Then: No error is returned.
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Then: The expected file contents are returned.
+			if string(got) != tc.want {
+				t.Errorf("output: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package prune
+
+import (
+	"slices"
+	"time"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: Candidate                             */
+/* -------------------------------------------------------------------------- */
+
+// Candidate describes an installed artifact which may be removed.
+type Candidate struct {
+	// Artifact is the installed artifact.
+	Artifact artifact.Artifact
+	// Size is the total size in bytes of the artifact's installed files.
+	Size int64
+	// LastUsed is the time at which the artifact was last used or, if it hasn't
+	// been used, when it was installed. A zero value denotes an unknown time,
+	// which is treated as older than all other times.
+	LastUsed time.Time
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Struct: Policy                               */
+/* -------------------------------------------------------------------------- */
+
+// Policy determines which installed artifacts should be removed.
+//
+// Artifacts with a version in 'Keep' and the 'KeepRecent' most recently used
+// artifacts are never removed. Of the remaining artifacts, those unused for
+// longer than 'MaxUnused' are removed and then, least recently used first,
+// more are removed until the total size is at most 'MaxSize'. If neither
+// 'MaxUnused' nor 'MaxSize' is set, then all remaining artifacts are removed.
+type Policy struct {
+	// Keep is the list of versions which must not be removed (e.g. pinned
+	// versions).
+	Keep []version.Version
+	// KeepRecent is the number of most recently used artifacts to keep.
+	KeepRecent int
+	// MaxUnused is the maximum duration an artifact can go unused before it's
+	// removed; ignored if zero.
+	MaxUnused time.Duration
+	// MaxSize is the maximum total size in bytes of all artifacts; ignored if
+	// zero.
+	MaxSize int64
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Plan                               */
+/* -------------------------------------------------------------------------- */
+
+// Plan returns the subset of 'candidates' which should be removed according to
+// the 'Policy', ordered from least to most recently used. 'now' is the time
+// against which 'Policy.MaxUnused' is measured.
+func Plan(candidates []Candidate, p Policy, now time.Time) []Candidate {
+	// Order candidates from most to least recently used.
+	sorted := slices.Clone(candidates)
+	slices.SortStableFunc(sorted, func(a, b Candidate) int {
+		return b.LastUsed.Compare(a.LastUsed)
+	})
+
+	var total int64
+
+	eligible := make([]Candidate, 0, len(sorted))
+
+	for i, c := range sorted {
+		total += c.Size
+
+		if i < p.KeepRecent || slices.Contains(p.Keep, c.Artifact.Version()) {
+			continue
+		}
+
+		eligible = append(eligible, c)
+	}
+
+	// Consider the least recently used candidates first.
+	slices.Reverse(eligible)
+
+	if p.MaxUnused <= 0 && p.MaxSize <= 0 {
+		return eligible
+	}
+
+	out := make([]Candidate, 0, len(eligible))
+
+	for _, c := range eligible {
+		isUnused := p.MaxUnused > 0 && now.Sub(c.LastUsed) > p.MaxUnused
+		isOverSize := p.MaxSize > 0 && total > p.MaxSize
+
+		if !isUnused && !isOverSize {
+			continue
+		}
+
+		out = append(out, c)
+		total -= c.Size
+	}
+
+	return out
+}
@@ -0,0 +1,104 @@
+package prune
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* ------------------------------- Test: Plan ------------------------------- */
+
+func TestPlan(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	// NOTE: Candidates are listed from most to least recently used.
+	a := Candidate{Artifact: executable.MustParse("Godot_v4.3-stable_linux.x86_64"), Size: 10, LastUsed: now.Add(-day)}
+	b := Candidate{Artifact: executable.MustParse("Godot_v4.2-stable_linux.x86_64"), Size: 20, LastUsed: now.Add(-10 * day)}
+	c := Candidate{Artifact: source.New(version.MustParse("4.1")), Size: 30, LastUsed: now.Add(-30 * day)}
+	d := Candidate{Artifact: executable.MustParse("Godot_v4.0-stable_linux.x86_64"), Size: 40, LastUsed: time.Time{}}
+
+	tests := []struct {
+		name       string
+		candidates []Candidate
+		policy     Policy
+
+		want []Candidate
+	}{
+		{
+			name: "no candidates returns empty plan",
+
+			want: []Candidate{},
+		},
+		{
+			name:       "no policy removes all candidates from least recently used",
+			candidates: []Candidate{a, b, c, d},
+
+			want: []Candidate{d, c, b, a},
+		},
+		{
+			name:       "kept versions are never removed",
+			candidates: []Candidate{a, b, c, d},
+			policy: Policy{
+				Keep: []version.Version{d.Artifact.Version(), b.Artifact.Version()},
+			},
+
+			want: []Candidate{c, a},
+		},
+		{
+			name:       "most recently used candidates are kept",
+			candidates: []Candidate{d, c, b, a},
+			policy:     Policy{KeepRecent: 2},
+
+			want: []Candidate{d, c},
+		},
+		{
+			name:       "candidates unused for longer than the limit are removed",
+			candidates: []Candidate{a, b, c, d},
+			policy:     Policy{MaxUnused: 7 * day},
+
+			want: []Candidate{d, c, b},
+		},
+		{
+			name:       "least recently used candidates are removed to meet size limit",
+			candidates: []Candidate{a, b, c, d},
+			policy:     Policy{MaxSize: 35},
+
+			want: []Candidate{d, c},
+		},
+		{
+			name:       "size limit respects kept candidates",
+			candidates: []Candidate{a, b, c, d},
+			policy: Policy{
+				Keep:       []version.Version{d.Artifact.Version()},
+				KeepRecent: 1,
+				MaxSize:    50,
+			},
+
+			want: []Candidate{c, b},
+		},
+		{
+			name:       "unused and size limits are combined",
+			candidates: []Candidate{a, b, c, d},
+			policy:     Policy{MaxUnused: 20 * day, MaxSize: 90},
+
+			want: []Candidate{d, c},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: A removal plan is computed.
+			got := Plan(tc.candidates, tc.policy, now)
+
+			// Then: The expected candidates are removed.
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package selector
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+var ErrInvalidVersion = errors.New("invalid version selector")
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: Selector                              */
+/* -------------------------------------------------------------------------- */
+
+// Selector describes which installed artifacts to operate on (e.g. when
+// uninstalling). An artifact is selected if it satisfies every specified
+// criterion; the zero value selects all artifacts.
+type Selector struct {
+	// Version restricts the "normal version" of selected artifacts (see
+	// 'ParseVersion'); version labels are ignored. A 'nil' value matches all
+	// versions.
+	Version *version.Constraint
+	// Channels is the list of release channels (e.g. 'stable' or 'beta') to
+	// select. An empty list matches all channels.
+	Channels []string
+	// Mono selects only "mono" (i.e. C#) builds if 'true' or only non-"mono"
+	// builds if 'false'. A 'nil' value matches both.
+	Mono *bool
+	// Platforms is the list of executable platforms to select. If specified,
+	// then only executables are selected.
+	Platforms []platform.Platform
+}
+
+/* ----------------------------- Method: IsZero ----------------------------- */
+
+// IsZero returns whether the 'Selector' specifies no criteria, i.e. whether it
+// selects all artifacts.
+func (s Selector) IsZero() bool {
+	return s.Version == nil && len(s.Channels) == 0 && s.Mono == nil && len(s.Platforms) == 0
+}
+
+/* ----------------------------- Method: Matches ---------------------------- */
+
+// Matches returns whether the artifact satisfies all of the 'Selector's
+// criteria.
+func (s Selector) Matches(a artifact.Artifact) bool {
+	v := a.Version()
+
+	if s.Version != nil && !s.Version.MatchesNormal(v) {
+		return false
+	}
+
+	if len(s.Channels) > 0 && !slices.Contains(s.Channels, v.Channel()) {
+		return false
+	}
+
+	if s.Mono != nil && *s.Mono != strings.HasSuffix(v.Label(), "_"+version.Mono) {
+		return false
+	}
+
+	if len(s.Platforms) > 0 {
+		ex, ok := a.(executable.Executable)
+		if !ok || !slices.Contains(s.Platforms, ex.Platform()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: ParseVersion                           */
+/* -------------------------------------------------------------------------- */
+
+// ParseVersion parses a version selector, which is either a (possibly partial)
+// version prefix (e.g. '3' or '4.2') or a range of versions as supported by
+// 'version.Constraint' (e.g. '>=4.0 <4.2', '~4.2', or '^3'). Version labels
+// aren't allowed; select release channels using 'Selector.Channels' instead.
+func ParseVersion(input string) (version.Constraint, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return version.Constraint{}, version.ErrMissing
+	}
+
+	if strings.Contains(input, version.SeparatorPreReleaseVersion) {
+		return version.Constraint{}, fmt.Errorf("%w: unexpected version label: %s", ErrInvalidVersion, input)
+	}
+
+	// A version without any operators is treated as a prefix of all versions
+	// within its range (e.g. '4.2' selects '4.2', '4.2.1', etc.).
+	if _, err := version.Parse(input); err == nil {
+		input += version.SeparatorPreReleaseVersion + version.Latest
+	}
+
+	c, err := version.ParseConstraint(input)
+	if err != nil {
+		return version.Constraint{}, fmt.Errorf("%w: %w", ErrInvalidVersion, err)
+	}
+
+	return c, nil
+}
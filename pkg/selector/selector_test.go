@@ -0,0 +1,165 @@
+package selector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/platform"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* ---------------------------- Test: ParseVersion -------------------------- */
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input string
+		v     string
+
+		want bool
+		err  error
+	}{
+		// Invalid inputs
+		{input: "", err: version.ErrMissing},
+		{input: "abc", err: ErrInvalidVersion},
+		{input: "4.2-beta1", err: ErrInvalidVersion},
+		{input: ">=4.2-stable_mono", err: ErrInvalidVersion},
+
+		// Prefixes
+		{input: "3", v: "3.5.3", want: true},
+		{input: "3", v: "4.0", want: false},
+		{input: "4.2", v: "4.2.2-rc1_mono", want: true},
+		{input: "v4.2", v: "4.3", want: false},
+		{input: "4.2.1", v: "4.2.1", want: true},
+		{input: "4.2.1", v: "4.2.2", want: false},
+
+		// Ranges
+		{input: ">=4.0 <4.2", v: "4.1.3-beta1", want: true},
+		{input: ">=4.0 <4.2", v: "4.2", want: false},
+		{input: "~4.2", v: "4.2.2", want: true},
+		{input: "^3", v: "3.6", want: true},
+		{input: "^3", v: "4.0", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input+"/"+tc.v, func(t *testing.T) {
+			// When: The version selector is parsed.
+			got, err := ParseVersion(tc.input)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			// Then: The selector matches the expected versions.
+			if ok := got.MatchesNormal(version.MustParse(tc.v)); ok != tc.want {
+				t.Errorf("output: got %v, want %v", ok, tc.want)
+			}
+		})
+	}
+}
+
+/* ------------------------------ Test: Matches ----------------------------- */
+
+func TestMatches(t *testing.T) {
+	yes, no := true, false
+	v3 := version.MustParseConstraint("3-latest")
+
+	linux := executable.MustParse("Godot_v4.2-stable_linux.x86_64")
+	mono := executable.MustParse("Godot_v4.2-stable_mono_linux.x86_64")
+	beta := executable.MustParse("Godot_v4.3-beta2_win64.exe")
+	old := executable.MustParse("Godot_v3.5-stable_x11.64")
+	src := source.New(version.MustParse("4.2"))
+
+	all := []artifact.Artifact{linux, mono, beta, old, src}
+
+	tests := []struct {
+		name     string
+		selector Selector
+
+		want []artifact.Artifact
+	}{
+		{
+			name:     "zero value selects all artifacts",
+			selector: Selector{Version: nil, Channels: nil, Mono: nil, Platforms: nil},
+
+			want: all,
+		},
+		{
+			name:     "version range selects matching artifacts",
+			selector: Selector{Version: &v3, Channels: nil, Mono: nil, Platforms: nil},
+
+			want: []artifact.Artifact{old},
+		},
+		{
+			name:     "channels select matching artifacts",
+			selector: Selector{Version: nil, Channels: []string{"beta", "rc"}, Mono: nil, Platforms: nil},
+
+			want: []artifact.Artifact{beta},
+		},
+		{
+			name:     "mono selects mono artifacts",
+			selector: Selector{Version: nil, Channels: nil, Mono: &yes, Platforms: nil},
+
+			want: []artifact.Artifact{mono},
+		},
+		{
+			name:     "non-mono selects non-mono artifacts",
+			selector: Selector{Version: nil, Channels: nil, Mono: &no, Platforms: nil},
+
+			want: []artifact.Artifact{linux, beta, old, src},
+		},
+		{
+			name: "platforms select matching executables",
+			selector: Selector{
+				Version:   nil,
+				Channels:  nil,
+				Mono:      nil,
+				Platforms: []platform.Platform{platform.MustParse("linux.x86_64")},
+			},
+
+			want: []artifact.Artifact{linux, mono, old},
+		},
+		{
+			name: "all criteria must be satisfied",
+			selector: Selector{
+				Version:   nil,
+				Channels:  []string{version.LabelStable},
+				Mono:      &no,
+				Platforms: []platform.Platform{platform.MustParse("linux.x86_64")},
+			},
+
+			want: []artifact.Artifact{linux, old},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// When: The selector is applied to each artifact.
+			got := make([]artifact.Artifact, 0)
+
+			for _, a := range all {
+				if tc.selector.Matches(a) {
+					got = append(got, a)
+				}
+			}
+
+			// Then: The expected artifacts are selected.
+			if len(got) != len(tc.want) {
+				t.Fatalf("output: got %v, want %v", got, tc.want)
+			}
+
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("output: got %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package store
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/internal/osutil"
+)
+
+const (
+	// storeDirCache is the directory which contains downloaded archives, each
+	// stored at '<checksum>/<name>', so that artifacts can be reinstalled
+	// without downloading them again.
+	storeDirCache = "cache"
+
+	// storeFileCacheProvenance is the name of the file, written alongside each
+	// cached archive, which records where the archive was downloaded from.
+	storeFileCacheProvenance = ".gdenv-provenance.json"
+)
+
+var ErrInvalidCacheKey = errors.New("invalid cache key")
+
+/* -------------------------------------------------------------------------- */
+/*                             Struct: CacheEntry                             */
+/* -------------------------------------------------------------------------- */
+
+// CacheEntry describes a downloaded archive in the store's download cache.
+type CacheEntry struct {
+	// Name is the name of the archive file.
+	Name string
+	// Checksum is the hex-encoded checksum of the archive.
+	Checksum string
+	// Path is the full path to the cached archive.
+	Path string
+	// Size is the size of the archive in bytes.
+	Size int64
+	// LastUsed is the time at which the archive was last added or used.
+	LastUsed time.Time
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: CacheGet                             */
+/* -------------------------------------------------------------------------- */
+
+// CacheGet copies the cached archive with the specified name and checksum to
+// the path 'out'. Returns whether the archive was found along with the
+// 'Provenance' recorded when it was cached. A found archive is marked as the
+// most recently used.
+//
+// NOTE: Callers should validate the archive's checksum; a cache entry could
+// have been modified since it was added.
+func CacheGet(ctx context.Context, storePath, name, checksum, out string) (p Provenance, ok bool, err error) {
+	path, err := cachePath(storePath, name, checksum)
+	if err != nil {
+		return Provenance{}, false, err
+	}
+
+	unlock, err := lockCache(ctx, storePath)
+	if err != nil {
+		return Provenance{}, false, err
+	}
+
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	if _, err := os.Stat(path); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return Provenance{}, false, err
+		}
+
+		return Provenance{}, false, nil
+	}
+
+	if err := osutil.CopyFile(ctx, path, out); err != nil {
+		return Provenance{}, false, err
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		return Provenance{}, false, err
+	}
+
+	p, err = readCacheProvenance(filepath.Dir(path))
+	if err != nil {
+		return Provenance{}, false, err
+	}
+
+	return p, true, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: CachePut                             */
+/* -------------------------------------------------------------------------- */
+
+// CachePut copies the downloaded archive at 'path' into the download cache,
+// keyed by its name and checksum. Least recently used archives are then
+// evicted until the cache's total size is at most 'maxSize' bytes. An archive
+// larger than 'maxSize' isn't cached.
+func CachePut(ctx context.Context, storePath, path, checksum string, p Provenance, maxSize int64) (err error) {
+	name := filepath.Base(path)
+
+	pathCached, err := cachePath(storePath, name, checksum)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if info.Size() > maxSize {
+		log.Debugf("not caching archive larger than cache size limit: %s", name)
+
+		return nil
+	}
+
+	unlock, err := lockCache(ctx, storePath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	pathEntry := filepath.Dir(pathCached)
+	if err := os.MkdirAll(pathEntry, osutil.ModeUserRWXGroupRX); err != nil {
+		return err
+	}
+
+	bb, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(pathEntry, storeFileCacheProvenance), bb, osutil.ModeUserRW); err != nil {
+		return err
+	}
+
+	// Copy the archive into place with a rename so that a partially-copied
+	// archive is never observed.
+	f, err := os.CreateTemp(pathEntry, "."+name+".*")
+	if err != nil {
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+
+	if err := osutil.CopyFile(ctx, path, f.Name()); err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+
+	if err := os.Rename(f.Name(), pathCached); err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+
+	log.Debugf("cached downloaded archive: %s", pathCached)
+
+	return evictCache(storePath, maxSize)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Function: CacheRemove                           */
+/* -------------------------------------------------------------------------- */
+
+// CacheRemove removes the cached archive with the specified name and checksum
+// from the download cache (e.g. because it failed validation). No effect if the
+// archive isn't cached.
+func CacheRemove(ctx context.Context, storePath, name, checksum string) (err error) {
+	path, err := cachePath(storePath, name, checksum)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockCache(ctx, storePath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	log.Debugf("removing archive from download cache: %s", name)
+
+	return os.RemoveAll(filepath.Dir(path))
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: CacheEntries                           */
+/* -------------------------------------------------------------------------- */
+
+// CacheEntries returns the archives in the download cache, ordered from most
+// to least recently used.
+func CacheEntries(storePath string) ([]CacheEntry, error) {
+	if storePath == "" {
+		return nil, ErrMissingStore
+	}
+
+	matches, err := filepath.Glob(filepath.Join(storePath, storeDirCache, "*", "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]CacheEntry, 0, len(matches))
+
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue // Evicted by another process.
+			}
+
+			return nil, err
+		}
+
+		name, checksum := filepath.Base(path), filepath.Base(filepath.Dir(path))
+		if !info.Mode().IsRegular() || validateCacheKey(name, checksum) != nil {
+			continue
+		}
+
+		out = append(out, CacheEntry{
+			Name:     name,
+			Checksum: checksum,
+			Path:     path,
+			Size:     info.Size(),
+			LastUsed: info.ModTime(),
+		})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].LastUsed.After(out[j].LastUsed)
+	})
+
+	return out, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Function: ClearCache                            */
+/* -------------------------------------------------------------------------- */
+
+// ClearCache removes all archives from the download cache.
+func ClearCache(ctx context.Context, storePath string) (err error) {
+	if storePath == "" {
+		return ErrMissingStore
+	}
+
+	unlock, err := lockCache(ctx, storePath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	return os.RemoveAll(filepath.Join(storePath, storeDirCache))
+}
+
+/* --------------------------- Function: evictCache ------------------------- */
+
+// evictCache removes the least recently used archives from the download cache
+// until its total size is at most 'maxSize' bytes.
+//
+// NOTE: Callers should hold the cache lock (see 'lockCache').
+func evictCache(storePath string, maxSize int64) error {
+	entries, err := CacheEntries(storePath)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+
+	for i := len(entries) - 1; i >= 0 && total > maxSize; i-- {
+		log.Debugf("evicting archive from download cache: %s", entries[i].Name)
+
+		if err := os.RemoveAll(filepath.Dir(entries[i].Path)); err != nil {
+			return err
+		}
+
+		total -= entries[i].Size
+	}
+
+	return nil
+}
+
+/* --------------------------- Function: cachePath -------------------------- */
+
+// cachePath returns the path to the cached archive with the specified name and
+// checksum.
+//
+// NOTE: This does *not* mean the archive exists.
+func cachePath(storePath, name, checksum string) (string, error) {
+	if storePath == "" {
+		return "", ErrMissingStore
+	}
+
+	if err := validateCacheKey(name, checksum); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(storePath, storeDirCache, checksum, name), nil
+}
+
+/* ------------------------ Function: validateCacheKey ---------------------- */
+
+// validateCacheKey returns an error if the archive name or checksum can't be
+// used as a path component within the download cache.
+func validateCacheKey(name, checksum string) error {
+	if _, err := hex.DecodeString(checksum); err != nil || checksum == "" {
+		return fmt.Errorf("%w: expected hex-encoded checksum: %s", ErrInvalidCacheKey, checksum)
+	}
+
+	// NOTE: Names of hidden files are reserved for cache metadata.
+	if !filepath.IsLocal(name) || filepath.Base(name) != name || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("%w: invalid archive name: %s", ErrInvalidCacheKey, name)
+	}
+
+	return nil
+}
+
+/* --------------------------- Function: lockCache -------------------------- */
+
+// lockCache acquires a lock on the download cache. The returned function
+// releases the lock.
+func lockCache(ctx context.Context, storePath string) (func() error, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultLockTimeout)
+	defer cancel()
+
+	return acquire(ctx, filepath.Join(storePath, storeDirCache+lockFileExt))
+}
+
+/* ---------------------- Function: readCacheProvenance --------------------- */
+
+// readCacheProvenance reads the provenance recorded within the cache entry
+// directory 'root'. A missing record results in an empty 'Provenance'.
+func readCacheProvenance(root string) (Provenance, error) {
+	var p Provenance
+
+	bb, err := os.ReadFile(filepath.Join(root, storeFileCacheProvenance))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return p, nil
+		}
+
+		return p, err
+	}
+
+	if err := json.Unmarshal(bb, &p); err != nil {
+		return Provenance{}, fmt.Errorf("%w: %s: %w", ErrUnexpectedLayout, storeFileCacheProvenance, err)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+)
+
+/* ------------------------------- Test: Cache ------------------------------ */
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+	storePath := filepath.Join(tmp, storeName)
+
+	const maxSize = 10
+
+	p := Provenance{Mirror: "GitHub", URL: "https://example.com/a.zip", Checksum: "aa", Size: 4}
+
+	// Given: Downloaded archives exist on the file system.
+	fstest.File{Path: "a.zip", Contents: "aaaa"}.Write(t, tmp)
+	fstest.File{Path: "b.zip", Contents: "bbbb"}.Write(t, tmp)
+	fstest.File{Path: "c.zip", Contents: "cccc"}.Write(t, tmp)
+	fstest.File{Path: "d.zip", Contents: "ddddddddddd"}.Write(t, tmp)
+
+	// Given: Two archives are cached, with 'a.zip' used least recently.
+	for i, key := range []struct{ name, checksum string }{{"a.zip", "aa"}, {"b.zip", "bb"}} {
+		if err := CachePut(ctx, storePath, filepath.Join(tmp, key.name), key.checksum, p, maxSize); err != nil {
+			t.Fatalf("test setup: %v", err)
+		}
+
+		path, err := cachePath(storePath, key.name, key.checksum)
+		if err != nil {
+			t.Fatalf("test setup: %v", err)
+		}
+
+		lastUsed := time.Now().Add(time.Duration(i-2) * time.Hour)
+		if err := os.Chtimes(path, lastUsed, lastUsed); err != nil {
+			t.Fatalf("test setup: %v", err)
+		}
+	}
+
+	// When: A cached archive is requested.
+	got, ok, err := CacheGet(ctx, storePath, "a.zip", "aa", filepath.Join(tmp, "out.zip"))
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: The archive is copied along with its provenance.
+	if !ok || got != p {
+		t.Errorf("output: got %v (%v), want %v", got, ok, p)
+	}
+
+	fstest.File{Path: "out.zip", Contents: "aaaa"}.Assert(t, tmp)
+
+	// When: An archive is requested with a different checksum.
+	_, ok, err = CacheGet(ctx, storePath, "a.zip", "ab", filepath.Join(tmp, "missing.zip"))
+
+	// Then: The archive isn't found.
+	if err != nil || ok {
+		t.Errorf("output: got %v (%v), want %v", ok, err, false)
+	}
+
+	fstest.Absent{Path: "missing.zip"}.Assert(t, tmp)
+
+	// When: Another archive is cached, exceeding the size limit.
+	if err := CachePut(ctx, storePath, filepath.Join(tmp, "c.zip"), "cc", p, maxSize); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// When: An archive larger than the size limit is cached.
+	if err := CachePut(ctx, storePath, filepath.Join(tmp, "d.zip"), "dd", p, maxSize); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	entries, err := CacheEntries(storePath)
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+
+	// Then: The least recently used archive was evicted and the oversized
+	// archive wasn't cached.
+	if want := []string{"c.zip", "a.zip"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("output: got %v, want %v", names, want)
+	}
+
+	// When: A cached archive is removed, twice.
+	for range 2 {
+		if err := CacheRemove(ctx, storePath, "a.zip", "aa"); err != nil {
+			t.Fatalf("err: got %v, want %v", err, nil)
+		}
+	}
+
+	// Then: Only the removed archive is gone.
+	if entries, err := CacheEntries(storePath); err != nil || len(entries) != 1 || entries[0].Name != "c.zip" {
+		t.Errorf("output: got %v (%v), want %v", entries, err, []string{"c.zip"})
+	}
+
+	fstest.Absent{Path: filepath.Join(storeName, storeDirCache, "aa")}.Assert(t, tmp)
+
+	// When: The cache is cleared.
+	if err := ClearCache(ctx, storePath); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: No archives remain.
+	if entries, err := CacheEntries(storePath); err != nil || len(entries) != 0 {
+		t.Errorf("output: got %v (%v), want %v", entries, err, nil)
+	}
+}
+
+/* --------------------------- Test: CacheInvalidKey ------------------------ */
+
+func TestCacheInvalidKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		archive  string
+		checksum string
+	}{
+		{name: "empty checksum", archive: "a.zip", checksum: ""},
+		{name: "non-hex checksum", archive: "a.zip", checksum: "../aa"},
+		{name: "nested archive name", archive: "a/b.zip", checksum: "aa"},
+		{name: "relative archive name", archive: "..", checksum: "aa"},
+		{name: "hidden archive name", archive: storeFileCacheProvenance, checksum: "aa"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			// When: A cached archive is requested with the invalid key.
+			_, _, err := CacheGet(context.Background(), tmp, tc.archive, tc.checksum, filepath.Join(tmp, "out"))
+
+			// Then: An error is returned.
+			if !errors.Is(err, ErrInvalidCacheKey) {
+				t.Errorf("err: got %v, want %v", err, ErrInvalidCacheKey)
+			}
+
+			// When: A cached archive is removed with the invalid key.
+			err = CacheRemove(context.Background(), tmp, tc.archive, tc.checksum)
+
+			// Then: An error is returned.
+			if !errors.Is(err, ErrInvalidCacheKey) {
+				t.Errorf("err: got %v, want %v", err, ErrInvalidCacheKey)
+			}
+		})
+	}
+}
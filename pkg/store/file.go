@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/coffeebeats/gdenv/internal/osutil"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                            Function: updateFile                            */
+/* -------------------------------------------------------------------------- */
+
+// updateFile replaces the contents of the file 'name' within the store with the
+// result of 'fn', which is called while holding a lock on the file. The new
+// contents are written to a temporary file and then renamed into place so that
+// readers never observe a partially-written file.
+func updateFile(ctx context.Context, storePath, name string, fn func() ([]byte, error)) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultLockTimeout)
+	defer cancel()
+
+	unlock, err := acquire(ctx, filepath.Join(storePath, name+lockFileExt))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	bb, err := fn()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(storePath, name+".*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(bb); err != nil {
+		return errors.Join(err, f.Close(), os.Remove(f.Name()))
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+
+	if err := os.Chmod(f.Name(), osutil.ModeUserRW); err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+
+	if err := os.Rename(f.Name(), filepath.Join(storePath, name)); err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+
+	return nil
+}
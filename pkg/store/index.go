@@ -0,0 +1,285 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+)
+
+const storeFileIndex = "index.json"
+
+var ErrMissingMetadata = errors.New("missing metadata")
+
+type provenanceKey struct{}
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: Provenance                            */
+/* -------------------------------------------------------------------------- */
+
+// Provenance describes where an artifact added to the store was downloaded
+// from.
+type Provenance struct {
+	// Mirror is the display name of the mirror which hosted the artifact.
+	Mirror string `json:"mirror,omitempty"`
+	// URL is the location from which the artifact was downloaded.
+	URL string `json:"url,omitempty"`
+	// Checksum is the hex-encoded SHA-512 checksum of the downloaded archive.
+	Checksum string `json:"checksum,omitempty"`
+	// Size is the size of the downloaded archive in bytes.
+	Size int64 `json:"size,omitempty"`
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Struct: Metadata                             */
+/* -------------------------------------------------------------------------- */
+
+// Metadata describes an artifact installed in the store. It's recorded in the
+// store's index when the artifact is added.
+type Metadata struct {
+	Provenance
+
+	// Installed is the time at which the artifact was added to the store.
+	Installed time.Time `json:"installed,omitzero"`
+	// LastUsed is the time at which the artifact was last used (see
+	// 'MarkUsed'); zero if it hasn't been used.
+	LastUsed time.Time `json:"last_used,omitzero"`
+	// DiskSize is the total size in bytes of the artifact's installed files.
+	DiskSize int64 `json:"disk_size,omitempty"`
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: WithProvenance                         */
+/* -------------------------------------------------------------------------- */
+
+// WithProvenance creates a sub-context which carries the 'Provenance' of the
+// artifacts being installed. The result can be passed to 'Add' to record the
+// provenance in the store's index.
+func WithProvenance(ctx context.Context, p Provenance) context.Context {
+	return context.WithValue(ctx, provenanceKey{}, p)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: MetadataOf                           */
+/* -------------------------------------------------------------------------- */
+
+// MetadataOf returns the recorded metadata for the specified artifact. Returns
+// 'ErrMissingMetadata' if no metadata was recorded (e.g. the artifact was
+// installed by an older version of 'gdenv'). The metadata is read from the
+// store in which the artifact is installed (see 'Layer').
+func MetadataOf(storePath string, a artifact.Artifact) (Metadata, error) {
+	layer, err := Layer(storePath, a)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	key, err := indexKey(layer, a)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	index, err := readIndex(layer)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	m, ok := index[key]
+	if !ok {
+		return Metadata{}, fmt.Errorf("%w: %s", ErrMissingMetadata, key)
+	}
+
+	return m, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Function: MarkUsed                            */
+/* -------------------------------------------------------------------------- */
+
+// MarkUsed records that the specified artifact was just used. This is a no-op
+// if the artifact isn't installed in the specified store (e.g. it's installed
+// in a read-only store; see 'Layer').
+func MarkUsed(ctx context.Context, storePath string, a artifact.Artifact) error {
+	if storePath == "" {
+		return ErrMissingStore
+	}
+
+	key, err := indexKey(storePath, a)
+	if err != nil {
+		return err
+	}
+
+	ok, err := hasIn(storePath, a)
+	if err != nil || !ok {
+		return err
+	}
+
+	return updateIndex(ctx, storePath, func(index map[string]Metadata) {
+		m := index[key]
+		m.LastUsed = time.Now().UTC()
+		index[key] = m
+	})
+}
+
+/* -------------------------- Function: recordAdded ------------------------- */
+
+// recordAdded records metadata for each of the newly-added store entries, each
+// of which is described by its manifest.
+func recordAdded(ctx context.Context, storePath string, manifests map[string]Manifest) error {
+	p, _ := ctx.Value(provenanceKey{}).(Provenance)
+	now := time.Now().UTC()
+
+	keys := make(map[string]Metadata, len(manifests))
+
+	for pathEntry, manifest := range manifests {
+		key, err := filepath.Rel(storePath, pathEntry)
+		if err != nil {
+			return err
+		}
+
+		var size int64
+		for _, f := range manifest.Files {
+			size += f.Size
+		}
+
+		keys[filepath.ToSlash(key)] = Metadata{
+			Provenance: p,
+			Installed:  now,
+			LastUsed:   time.Time{},
+			DiskSize:   size,
+		}
+	}
+
+	return updateIndex(ctx, storePath, func(index map[string]Metadata) {
+		for key, m := range keys {
+			index[key] = m
+		}
+	})
+}
+
+/* ------------------------- Function: recordRemoved ------------------------ */
+
+// recordRemoved removes the metadata for the specified artifact.
+func recordRemoved(ctx context.Context, storePath string, a artifact.Artifact) error {
+	key, err := indexKey(storePath, a)
+	if err != nil {
+		return err
+	}
+
+	// Avoid acquiring the index lock if there's nothing to remove.
+	index, err := readIndex(storePath)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := index[key]; !ok {
+		return nil
+	}
+
+	return updateIndex(ctx, storePath, func(index map[string]Metadata) {
+		delete(index, key)
+	})
+}
+
+/* ------------------------- Function: recordCleared ------------------------ */
+
+// recordCleared removes the metadata for all artifacts within the store's cache
+// directory 'name' (e.g. 'editor').
+func recordCleared(ctx context.Context, storePath, name string) error {
+	prefix := name + "/"
+
+	// Avoid acquiring the index lock if there's nothing to remove.
+	index, err := readIndex(storePath)
+	if err != nil {
+		return err
+	}
+
+	isCleared := func(key string, _ Metadata) bool {
+		return strings.HasPrefix(key, prefix)
+	}
+
+	found := false
+
+	for key, m := range index {
+		if found = isCleared(key, m); found {
+			break
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	return updateIndex(ctx, storePath, func(index map[string]Metadata) {
+		maps.DeleteFunc(index, isCleared)
+	})
+}
+
+/* --------------------------- Function: indexKey --------------------------- */
+
+// indexKey returns the key under which the artifact's metadata is recorded.
+// This is the slash-separated path to its store entry, relative to the store.
+func indexKey(storePath string, a artifact.Artifact) (string, error) {
+	path, err := artifactPath(storePath, a)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(storePath, filepath.Dir(path))
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+/* -------------------------- Function: updateIndex ------------------------- */
+
+// updateIndex applies 'fn' to the store's index while holding the index lock
+// (see 'updateFile').
+func updateIndex(ctx context.Context, storePath string, fn func(map[string]Metadata)) error {
+	return updateFile(ctx, storePath, storeFileIndex, func() ([]byte, error) {
+		index, err := readIndex(storePath)
+		if err != nil {
+			return nil, err
+		}
+
+		fn(index)
+
+		return json.MarshalIndent(index, "", "  ")
+	})
+}
+
+/* --------------------------- Function: readIndex -------------------------- */
+
+// readIndex reads the store's index, mapping store entries to their metadata.
+// A missing index is treated as empty.
+func readIndex(storePath string) (map[string]Metadata, error) {
+	index := make(map[string]Metadata)
+
+	bb, err := os.ReadFile(filepath.Join(storePath, storeFileIndex))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return index, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bb, &index); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnexpectedLayout, err)
+	}
+
+	if index == nil {
+		index = make(map[string]Metadata)
+	}
+
+	return index, nil
+}
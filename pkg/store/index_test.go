@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+)
+
+/* ---------------------------- Test: MetadataOf ---------------------------- */
+
+func TestMetadataOf(t *testing.T) {
+	ex := executable.MustParse("Godot_v4.0-stable_linux.x86_64")
+
+	provenance := Provenance{
+		Mirror:   "mirror",
+		URL:      "https://example.com/" + ex.Name() + ".zip",
+		Checksum: "abc",
+		Size:     3,
+	}
+
+	tests := []struct {
+		name     string
+		add      bool
+		markUsed bool
+		remove   bool
+
+		want Provenance
+		used bool
+		err  error
+	}{
+		{
+			name: "missing artifact has no metadata",
+
+			err: ErrMissingMetadata,
+		},
+		{
+			name: "added artifact has recorded provenance",
+			add:  true,
+
+			want: provenance,
+		},
+		{
+			name:     "used artifact has last-used time",
+			add:      true,
+			markUsed: true,
+
+			want: provenance,
+			used: true,
+		},
+		{
+			name:     "marking a missing artifact as used is a no-op",
+			markUsed: true,
+
+			err: ErrMissingMetadata,
+		},
+		{
+			name:   "removed artifact has no metadata",
+			add:    true,
+			remove: true,
+
+			err: ErrMissingMetadata,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tmp := t.TempDir()
+			storePath := filepath.Join(tmp, storeName)
+
+			// Given: A store exists.
+			if err := Touch(context.Background(), storePath); err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Given: The artifact is added to the store.
+			if tc.add {
+				fstest.File{Path: ex.Path(), Contents: "godot"}.Write(t, tmp)
+
+				local := artifact.Local[artifact.Artifact]{Artifact: ex, Path: filepath.Join(tmp, ex.Path())}
+				if err := Add(WithProvenance(ctx, provenance), storePath, local); err != nil {
+					t.Fatalf("err: got %v, want %v", err, nil)
+				}
+			}
+
+			// Given: The artifact is marked as used.
+			if tc.markUsed {
+				if err := MarkUsed(ctx, storePath, ex); err != nil {
+					t.Fatalf("err: got %v, want %v", err, nil)
+				}
+			}
+
+			// Given: The artifact is removed from the store.
+			if tc.remove {
+				if err := Remove(storePath, ex); err != nil {
+					t.Fatalf("err: got %v, want %v", err, nil)
+				}
+			}
+
+			// When: The artifact's metadata is read.
+			got, err := MetadataOf(storePath, ex)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("err: got %v, want %v", err, tc.err)
+			}
+
+			if tc.err != nil {
+				return
+			}
+
+			// Then: The recorded metadata matches expectations.
+			if got.Provenance != tc.want {
+				t.Errorf("provenance: got %v, want %v", got.Provenance, tc.want)
+			}
+
+			if got.Installed.IsZero() {
+				t.Errorf("installed: got %v, want non-zero", got.Installed)
+			}
+
+			if got, want := got.DiskSize, int64(len("godot")); got != want {
+				t.Errorf("disk size: got %v, want %v", got, want)
+			}
+
+			if got := !got.LastUsed.IsZero(); got != tc.used {
+				t.Errorf("used: got %v, want %v", got, tc.used)
+			}
+		})
+	}
+}
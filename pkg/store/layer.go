@@ -0,0 +1,173 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+)
+
+// envPath is an environment variable which lists additional stores to search
+// for installed artifacts, separated by the OS path list separator. Stores are
+// searched in the listed order; 'GDENV_HOME' is searched last, unless listed.
+const envPath = "GDENV_PATH"
+
+var ErrNoWritableStore = errors.New("no writable store")
+
+/* -------------------------------------------------------------------------- */
+/*                              Function: Layers                              */
+/* -------------------------------------------------------------------------- */
+
+// Layers returns the user-configured, ordered list of store paths to search for
+// installed artifacts. These are the stores listed in 'GDENV_PATH', followed by
+// 'GDENV_HOME' if it isn't already listed. Writes only go to the first writable
+// store (see 'Path').
+func Layers() ([]string, error) {
+	out := make([]string, 0)
+
+	for _, path := range filepath.SplitList(os.Getenv(envPath)) {
+		if path == "" {
+			continue
+		}
+
+		if !filepath.IsAbs(path) {
+			return nil, fmt.Errorf("%w; expected absolute path in %s: %s", ErrInvalidPath, envPath, path)
+		}
+
+		if path = filepath.Clean(path); !slices.Contains(out, path) {
+			out = append(out, path)
+		}
+	}
+
+	home, err := homePath()
+	if err != nil {
+		// 'GDENV_HOME' is optional if other stores are configured.
+		if len(out) > 0 && errors.Is(err, ErrMissingEnvVar) {
+			return out, nil
+		}
+
+		return nil, err
+	}
+
+	if !slices.Contains(out, home) {
+		out = append(out, home)
+	}
+
+	return out, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Layer                              */
+/* -------------------------------------------------------------------------- */
+
+// Layer returns the path of the store in which the specified artifact is
+// installed. Stores are searched in order (see 'Layers'), always including
+// 'storePath'. If the artifact isn't installed, then 'storePath' is returned.
+func Layer(storePath string, a artifact.Artifact) (string, error) {
+	if storePath == "" {
+		return "", ErrMissingStore
+	}
+
+	for _, layer := range layers(storePath) {
+		ok, err := hasIn(layer, a)
+		if err != nil {
+			return "", err
+		}
+
+		if ok {
+			return layer, nil
+		}
+	}
+
+	return storePath, nil
+}
+
+/* ---------------------------- Function: layers ---------------------------- */
+
+// layers returns the ordered list of stores to search for installed artifacts.
+// The specified store is always included; it's searched first if it's not one
+// of the configured stores (see 'Layers').
+func layers(storePath string) []string {
+	out, err := Layers()
+	if err != nil || !slices.Contains(out, storePath) {
+		return append([]string{storePath}, out...)
+	}
+
+	return out
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: Writable                             */
+/* -------------------------------------------------------------------------- */
+
+// Writable returns an error wrapping 'ErrNoWritableStore' if the store at the
+// specified path can't be created or modified. Stores which are only read from
+// don't need to be writable (see 'Path').
+func Writable(storePath string) error {
+	if storePath == "" {
+		return ErrMissingStore
+	}
+
+	if !isWritable(storePath) {
+		return fmt.Errorf("%w: %s", ErrNoWritableStore, storePath)
+	}
+
+	return nil
+}
+
+/* --------------------------- Function: isWritable ------------------------- */
+
+// isWritable returns whether a store can be created or modified at the
+// specified path. If the store doesn't exist, then its nearest existing parent
+// directory must be writable.
+//
+// NOTE: This doesn't modify the file system, so it's cheap enough to call each
+// time the store path is determined.
+func isWritable(path string) bool {
+	for {
+		info, err := os.Stat(path)
+		if err == nil {
+			return info.IsDir() && canWrite(path, info)
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return false
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return false
+		}
+
+		path = parent
+	}
+}
+
+/* ----------------------------- Function: hasIn ---------------------------- */
+
+// hasIn returns whether the specified artifact is installed in the store at
+// 'layer', ignoring any other stores.
+func hasIn(layer string, a artifact.Artifact) (bool, error) {
+	path, err := artifactPath(layer, a)
+	if err != nil {
+		if !errors.Is(err, ErrUnsupportedArtifact) {
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,223 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+)
+
+/* ------------------------------ Test: Layers ------------------------------ */
+
+func TestLayers(t *testing.T) {
+	tests := []struct {
+		name string
+		home string
+		path []string
+
+		want []string
+		err  error
+	}{
+		{
+			name: "missing configuration returns an error",
+
+			err: ErrMissingEnvVar,
+		},
+		{
+			name: "home store is used without a search path",
+			home: "/home",
+
+			want: []string{"/home"},
+		},
+		{
+			name: "home store is searched last",
+			home: "/home",
+			path: []string{"/a", "/b"},
+
+			want: []string{"/a", "/b", "/home"},
+		},
+		{
+			name: "home store position is respected if listed",
+			home: "/home",
+			path: []string{"/home", "/a", "/home/"},
+
+			want: []string{"/home", "/a"},
+		},
+		{
+			name: "home store is optional with a search path",
+			path: []string{"/a", ""},
+
+			want: []string{"/a"},
+		},
+		{
+			name: "relative path returns an error",
+			home: "/home",
+			path: []string{"a"},
+
+			err: ErrInvalidPath,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given: The specified stores are configured.
+			t.Setenv(envStore, tc.home)
+			t.Setenv(envPath, strings.Join(tc.path, string(os.PathListSeparator)))
+
+			// When: The list of stores is determined.
+			got, err := Layers()
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			want := make([]string, 0, len(tc.want))
+			for _, p := range tc.want {
+				want = append(want, filepath.FromSlash(p))
+			}
+
+			if tc.err != nil {
+				want = nil
+			}
+
+			// Then: The expected stores are returned.
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+/* ------------------------ Test: Path (with layers) ------------------------ */
+
+func TestPathWithLayers(t *testing.T) {
+	tmp := t.TempDir()
+
+	// Given: A store which can't be created, since its parent is a file.
+	fstest.File{Path: "file"}.Write(t, tmp)
+	readOnly := filepath.Join(tmp, "file", storeName)
+
+	home := filepath.Join(tmp, "home")
+
+	// Given: The read-only store is searched first.
+	t.Setenv(envStore, home)
+	t.Setenv(envPath, readOnly)
+
+	// When: The store to write to is determined.
+	got, err := Path()
+
+	// Then: There's no error.
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: The first writable store is returned.
+	if got != home {
+		t.Errorf("output: got %v, want %v", got, home)
+	}
+
+	// Given: There's no writable store.
+	t.Setenv(envStore, "")
+
+	// When: The store to write to is determined.
+	got, err = Path()
+
+	// Then: There's no error.
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: The read-only store is returned so it can still be read from.
+	if got != readOnly {
+		t.Errorf("output: got %v, want %v", got, readOnly)
+	}
+
+	// When: The store is checked for writes.
+	err = Writable(got)
+
+	// Then: An error is returned.
+	if !errors.Is(err, ErrNoWritableStore) {
+		t.Errorf("err: got %v, want %v", err, ErrNoWritableStore)
+	}
+}
+
+/* ------------------------------- Test: Layer ------------------------------ */
+
+func TestLayer(t *testing.T) {
+	tmp := t.TempDir()
+
+	shared, home := filepath.Join(tmp, "shared"), filepath.Join(tmp, "home")
+
+	t.Setenv(envStore, home)
+	t.Setenv(envPath, shared)
+
+	a := executable.MustParse("Godot_v4.0-stable_linux.x86_64")
+	b := executable.MustParse("Godot_v4.1-stable_linux.x86_64")
+	c := executable.MustParse("Godot_v4.2-stable_linux.x86_64")
+
+	// Given: 'a' is installed in both stores, 'b' only in the shared store.
+	for _, install := range []struct {
+		layer string
+		ex    executable.Executable
+	}{{shared, a}, {home, a}, {shared, b}} {
+		path, err := executableDir(install.layer, install.ex)
+		if err != nil {
+			t.Fatalf("test setup: %v", err)
+		}
+
+		fstest.File{Path: filepath.Join(path, install.ex.Path())}.Write(t, tmp)
+	}
+
+	tests := []struct {
+		ex executable.Executable
+
+		want string
+		has  bool
+	}{
+		{ex: a, want: shared, has: true},
+		{ex: b, want: shared, has: true},
+		{ex: c, want: home, has: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ex.String(), func(t *testing.T) {
+			// When: The store containing the executable is determined.
+			got, err := Layer(home, tc.ex)
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Then: The first store containing the executable is returned.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+
+			// Then: All stores are searched for the executable.
+			if ok, err := Has(home, tc.ex); err != nil || ok != tc.has {
+				t.Errorf("output: got %v (%v), want %v", ok, err, tc.has)
+			}
+		})
+	}
+
+	// When: The installed executables are listed.
+	got, err := Executables(t.Context(), home)
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: Executables in any store are listed once.
+	want := []LocalEx{
+		{Artifact: a, Path: filepath.Join(shared, storeDirEx, "v4.0-stable", "linux.x86_64", a.Path())},
+		{Artifact: b, Path: filepath.Join(shared, storeDirEx, "v4.1-stable", "linux.x86_64", b.Path())},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("output: got %v, want %v", got, want)
+	}
+}
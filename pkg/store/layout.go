@@ -0,0 +1,297 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/internal/osutil"
+)
+
+const (
+	// layoutVersion is the newest store layout understood by this version of
+	// 'gdenv'. Stores with an older layout are migrated (see 'Touch').
+	layoutVersion = 1
+
+	// storeFileLayoutPrefix is the prefix of the empty marker file which
+	// records the store's layout version (e.g. 'layout.v1').
+	storeFileLayoutPrefix = "layout.v"
+)
+
+// migration upgrades a store from one layout version to the next.
+//
+// NOTE: Migrations must be idempotent; if 'gdenv' exits before a migration
+// completes, the migration is run again from the start.
+type migration struct {
+	description string
+	apply       func(ctx context.Context, storePath string) error
+}
+
+// migrations is the ordered list of store migrations; the migration at index
+// 'i' upgrades a store from layout version 'i' to 'i+1'.
+//
+//nolint:gochecknoglobals
+var migrations = []migration{
+	{description: "record manifests and metadata of installed artifacts", apply: migrateRecordMetadata},
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Function: Layout                              */
+/* -------------------------------------------------------------------------- */
+
+// Layout returns the layout version of the store at the specified path. A store
+// without a layout marker is assumed to have the original layout (version 0).
+func Layout(storePath string) (int, error) {
+	if storePath == "" {
+		return 0, ErrMissingStore
+	}
+
+	entries, err := os.ReadDir(storePath)
+	if err != nil {
+		return 0, err
+	}
+
+	var layout int
+
+	for _, e := range entries {
+		name, ok := strings.CutPrefix(e.Name(), storeFileLayoutPrefix)
+		if !ok || e.IsDir() {
+			continue
+		}
+
+		v, err := strconv.Atoi(name)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("%w: invalid layout marker: %s", ErrUnexpectedLayout, e.Name())
+		}
+
+		// NOTE: A migration writes the new marker before removing the old
+		// one, so the newest marker is authoritative.
+		layout = max(layout, v)
+	}
+
+	return layout, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: CheckLayout                            */
+/* -------------------------------------------------------------------------- */
+
+// CheckLayout verifies that the store at the specified path, and any other
+// store which is searched (see 'Layers'), can be read without being modified.
+// Unlike 'Touch', the store isn't created or migrated. Stores with an older
+// layout can be read as-is, so only a layout which is newer than this version
+// of 'gdenv' understands returns 'ErrUnexpectedLayout'. Stores which don't
+// exist are ignored.
+func CheckLayout(storePath string) error {
+	if storePath == "" {
+		return ErrMissingStore
+	}
+
+	for _, layer := range layers(storePath) {
+		if _, err := checkLayout(layer); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/* ---------------------------- Function: migrate --------------------------- */
+
+// migrate upgrades the store to the current layout version while holding the
+// store lock. Returns 'ErrUnexpectedLayout' if the store's layout is newer than
+// this version of 'gdenv' understands.
+func migrate(ctx context.Context, storePath string) (err error) {
+	layout, err := checkLayout(storePath)
+	if err != nil || layout == layoutVersion {
+		return err
+	}
+
+	unlock, err := Lock(ctx, storePath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	// Another process may have migrated the store while waiting on the lock.
+	layout, err = checkLayout(storePath)
+	if err != nil {
+		return err
+	}
+
+	for ; layout < layoutVersion; layout++ {
+		m := migrations[layout]
+
+		log.Debugf("migrating store layout from v%d to v%d: %s", layout, layout+1, m.description)
+
+		if err := m.apply(ctx, storePath); err != nil {
+			return fmt.Errorf("failed to migrate store layout to v%d: %w", layout+1, err)
+		}
+
+		if err := writeLayout(storePath, layout+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/* -------------------------- Function: checkLayout ------------------------- */
+
+// checkLayout returns the store's layout version, failing if it's newer than
+// this version of 'gdenv' understands.
+func checkLayout(storePath string) (int, error) {
+	layout, err := Layout(storePath)
+	if err != nil {
+		return 0, err
+	}
+
+	if layout > layoutVersion {
+		return 0, fmt.Errorf(
+			"%w: store layout v%d is newer than supported layout v%d; try upgrading 'gdenv'",
+			ErrUnexpectedLayout,
+			layout,
+			layoutVersion,
+		)
+	}
+
+	return layout, nil
+}
+
+/* -------------------------- Function: writeLayout ------------------------- */
+
+// writeLayout records the store's layout version. The new marker is written
+// before older markers are removed so that an interrupted update never appears
+// to regress the store's layout.
+func writeLayout(storePath string, layout int) error {
+	path := filepath.Join(storePath, storeFileLayoutPrefix+strconv.Itoa(layout))
+	if err := os.WriteFile(path, nil, osutil.ModeUserRW); err != nil {
+		return err
+	}
+
+	for v := range layout {
+		path := filepath.Join(storePath, storeFileLayoutPrefix+strconv.Itoa(v))
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Migration: v0 -> v1                             */
+/* -------------------------------------------------------------------------- */
+
+// migrateRecordMetadata records a manifest (see 'Verify') and index metadata
+// (see 'MetadataOf') for each store entry added by an older version of 'gdenv'.
+// An entry's install time is approximated by its modification time and its
+// current contents are trusted as-is.
+func migrateRecordMetadata(ctx context.Context, storePath string) error {
+	index, err := readIndex(storePath)
+	if err != nil {
+		return err
+	}
+
+	var entries []string
+
+	for _, pattern := range []string{
+		filepath.Join(storePath, storeDirEx, "*", "*"),
+		filepath.Join(storePath, storeDirSrc, "*"),
+		filepath.Join(storePath, storeDirTpl, "*"),
+	} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, matches...)
+	}
+
+	if len(entries) > 0 {
+		log.Infof("upgrading gdenv store; recording metadata of %d installed artifact(s)", len(entries))
+	}
+
+	missing := make(map[string]Metadata)
+
+	for _, pathEntry := range entries {
+		key, m, err := recordEntryMetadata(ctx, storePath, pathEntry)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := index[key]; key != "" && !ok {
+			missing[key] = m
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return updateIndex(ctx, storePath, func(index map[string]Metadata) {
+		for key, m := range missing {
+			if _, ok := index[key]; !ok {
+				index[key] = m
+			}
+		}
+	})
+}
+
+/* ---------------------- Function: recordEntryMetadata --------------------- */
+
+// recordEntryMetadata writes a manifest for the store entry directory if one
+// is missing and returns the entry's index key and metadata. An empty key is
+// returned if the path isn't a directory.
+func recordEntryMetadata(ctx context.Context, storePath, pathEntry string) (string, Metadata, error) {
+	info, err := os.Stat(pathEntry)
+	if err != nil {
+		return "", Metadata{}, err
+	}
+
+	if !info.IsDir() {
+		return "", Metadata{}, nil
+	}
+
+	m, err := readManifest(pathEntry)
+	if err != nil {
+		if !errors.Is(err, ErrMissingManifest) {
+			return "", Metadata{}, err
+		}
+
+		log.Debugf("recording manifest for store entry: %s", pathEntry)
+
+		m, err = writeManifest(ctx, pathEntry)
+		if err != nil {
+			return "", Metadata{}, err
+		}
+	}
+
+	key, err := filepath.Rel(storePath, pathEntry)
+	if err != nil {
+		return "", Metadata{}, err
+	}
+
+	var size int64
+	for _, f := range m.Files {
+		size += f.Size
+	}
+
+	return filepath.ToSlash(key), Metadata{
+		Provenance: Provenance{Mirror: "", URL: "", Checksum: "", Size: 0},
+		Installed:  info.ModTime().UTC(),
+		LastUsed:   time.Time{},
+		DiskSize:   size,
+	}, nil
+}
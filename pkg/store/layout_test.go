@@ -0,0 +1,211 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+)
+
+/* ------------------------------ Test: Layout ------------------------------ */
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []fstest.Writer
+
+		want int
+		err  error
+	}{
+		{
+			name: "store without a marker has the original layout",
+
+			want: 0,
+		},
+		{
+			name: "marker determines the layout",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
+			},
+
+			want: 1,
+		},
+		{
+			name: "newest marker determines the layout",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"0")},
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"3")},
+			},
+
+			want: 3,
+		},
+		{
+			name: "malformed marker returns an error",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"-1")},
+			},
+
+			err: ErrUnexpectedLayout,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			// Given: The specified files exist on the file system.
+			fstest.Dir{Path: storeName}.Write(t, tmp)
+
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The store's layout is determined.
+			got, err := Layout(filepath.Join(tmp, storeName))
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected layout is returned.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+/* ---------------------------- Test: CheckLayout --------------------------- */
+
+func TestCheckLayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []fstest.Writer
+
+		err error
+	}{
+		{
+			name: "missing store is ignored",
+		},
+		{
+			name: "current layout is supported",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
+			},
+		},
+		{
+			name: "original layout is supported",
+			files: []fstest.Writer{
+				fstest.Dir{Path: filepath.Join(storeName, storeDirEx)},
+			},
+		},
+		{
+			name: "newer layout returns an error",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"2")},
+			},
+
+			err: ErrUnexpectedLayout,
+		},
+		{
+			name: "other store with the original layout is supported",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
+				fstest.Dir{Path: filepath.Join("shared", storeDirEx)},
+			},
+		},
+		{
+			name: "other store with a newer layout returns an error",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
+				fstest.File{Path: filepath.Join("shared", storeFileLayoutPrefix+"2")},
+			},
+
+			err: ErrUnexpectedLayout,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			storePath := filepath.Join(tmp, storeName)
+
+			// Given: Another store is searched after this one.
+			t.Setenv(envStore, storePath)
+			t.Setenv(envPath, filepath.Join(tmp, "shared"))
+
+			// Given: The specified files exist on the file system.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The store's layout is checked.
+			err := CheckLayout(storePath)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The store isn't created.
+			if tc.files == nil {
+				fstest.Absent{Path: storeName}.Assert(t, tmp)
+			}
+		})
+	}
+}
+
+/* ---------------------- Test: migrateRecordMetadata ----------------------- */
+
+func TestMigrateRecordMetadata(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+	storePath := filepath.Join(tmp, storeName)
+
+	ex := executable.MustParse("Godot_v4.0-stable_linux.x86_64")
+
+	pathEx, err := Executable(storePath, ex)
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	// Given: A store with the original layout and an installed executable.
+	fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"0")}.Write(t, tmp)
+	fstest.File{Path: pathEx, Contents: "godot"}.Write(t, tmp)
+
+	// When: The store is migrated (twice, to verify idempotence).
+	for range 2 {
+		if err := Touch(ctx, storePath); err != nil {
+			t.Fatalf("err: got %v, want %v", err, nil)
+		}
+	}
+
+	// Then: The store has the current layout.
+	if got, err := Layout(storePath); err != nil || got != layoutVersion {
+		t.Errorf("output: got %v (%v), want %v", got, err, layoutVersion)
+	}
+
+	// Then: The executable's metadata was recorded.
+	m, err := MetadataOf(storePath, ex)
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	if m.DiskSize != int64(len("godot")) || m.Installed.IsZero() {
+		t.Errorf("output: got %#v", m)
+	}
+
+	// Then: The executable's manifest was recorded.
+	drift, err := Verify(ctx, storePath, ex)
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	if len(drift) != 0 {
+		t.Errorf("output: got %v, want %v", drift, nil)
+	}
+}
@@ -0,0 +1,444 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/coffeebeats/gdenv/internal/osutil"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+)
+
+const (
+	// DefaultLockTimeout is the maximum duration to wait for a lock before
+	// failing.
+	DefaultLockTimeout = 10 * time.Minute
+
+	// lockHeartbeatInterval is the interval at which a held lock's modification
+	// time is updated. This allows other processes to detect abandoned locks.
+	lockHeartbeatInterval = 5 * time.Second
+
+	// lockPollInterval is the duration to wait between attempts to acquire a
+	// lock.
+	lockPollInterval = 100 * time.Millisecond
+
+	// lockStaleAfter is the duration after which a lock which hasn't been
+	// updated by its owner is considered abandoned.
+	lockStaleAfter = 12 * lockHeartbeatInterval
+
+	// lockFileExt is the file extension used for lock files.
+	lockFileExt = ".lock"
+)
+
+var ErrLockTimeout = errors.New("timed out waiting for lock")
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Lock                               */
+/* -------------------------------------------------------------------------- */
+
+// Lock acquires an exclusive, inter-process lock on the entire store. This
+// blocks until the lock is acquired, the context is canceled, or the
+// 'DefaultLockTimeout' elapses. Once the store lock is acquired, this also
+// waits for all artifact locks (see 'LockArtifact') to be released. The
+// returned function must be called to release the lock.
+//
+// NOTE: Locks are advisory and not reentrant; acquiring a lock again before
+// releasing it (even within the same process) will block.
+func Lock(ctx context.Context, storePath string) (func() error, error) {
+	if storePath == "" {
+		return nil, ErrMissingStore
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultLockTimeout)
+	defer cancel()
+
+	unlock, err := acquire(ctx, filepath.Join(storePath, storeFileLock))
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait for in-progress artifact operations to complete. New artifact
+	// locks will back off while the store lock is held.
+	if err := poll(ctx, func() (bool, error) {
+		return isUnlocked(filepath.Join(storePath, storeDirLocks))
+	}); err != nil {
+		return nil, errors.Join(err, unlock())
+	}
+
+	return unlock, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: LockArtifact                           */
+/* -------------------------------------------------------------------------- */
+
+// LockArtifact acquires an exclusive, inter-process lock on the store entry for
+// the specified artifact. This blocks until the lock is acquired, the context
+// is canceled, or the 'DefaultLockTimeout' elapses. Artifact locks can't be
+// acquired while the store lock is held (see 'Lock'). The returned function
+// must be called to release the lock.
+//
+// NOTE: Locks are advisory and not reentrant; acquiring a lock again before
+// releasing it (even within the same process) will block.
+func LockArtifact(ctx context.Context, storePath string, a artifact.Artifact) (func() error, error) {
+	if storePath == "" {
+		return nil, ErrMissingStore
+	}
+
+	path, err := artifactLockPath(storePath, a)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultLockTimeout)
+	defer cancel()
+
+	var unlock func() error
+
+	if err := poll(ctx, func() (bool, error) {
+		// Acquire the artifact lock *before* checking the store lock so that
+		// 'Lock' is guaranteed to observe it.
+		release, err := acquire(ctx, path)
+		if err != nil {
+			return false, err
+		}
+
+		ok, err := isUnlocked(filepath.Join(storePath, storeFileLock))
+		if err != nil || !ok {
+			// Back off so that the store lock owner can make progress.
+			return false, errors.Join(err, release())
+		}
+
+		unlock = release
+
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return unlock, nil
+}
+
+/* ------------------------ Function: artifactLockPath ---------------------- */
+
+// artifactLockPath returns the path to the lock file for the store entry of the
+// specified artifact.
+func artifactLockPath(storePath string, a artifact.Artifact) (string, error) {
+	path, err := artifactPath(storePath, a)
+	if err != nil {
+		return "", err
+	}
+
+	// NOTE: An artifact's store entry is the directory containing it.
+	rel, err := filepath.Rel(storePath, filepath.Dir(path))
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.ReplaceAll(filepath.ToSlash(rel), "/", "_") + lockFileExt
+
+	return filepath.Join(storePath, storeDirLocks, name), nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Struct: lockOwner                             */
+/* -------------------------------------------------------------------------- */
+
+// lockOwner describes the process which holds a lock. It's written to the lock
+// file so that abandoned locks can be detected and reported.
+type lockOwner struct {
+	PID      int       `json:"pid"`
+	Host     string    `json:"host"`
+	Acquired time.Time `json:"acquired"`
+}
+
+/* ----------------------------- Method: String ----------------------------- */
+
+func (o lockOwner) String() string {
+	if o.PID == 0 {
+		return "unknown owner"
+	}
+
+	return fmt.Sprintf("pid %d on '%s' since %s", o.PID, o.Host, o.Acquired.Format(time.RFC3339))
+}
+
+/* ---------------------------- Function: acquire --------------------------- */
+
+// acquire creates the lock file at 'path', blocking until it's available or the
+// context is done. Abandoned lock files are removed. While the lock is held its
+// modification time is periodically updated.
+func acquire(ctx context.Context, path string) (func() error, error) {
+	if err := os.MkdirAll(filepath.Dir(path), osutil.ModeUserRWXGroupRX); err != nil {
+		return nil, err
+	}
+
+	logged := false
+
+	for {
+		ok, err := tryAcquire(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			break
+		}
+
+		owner, info, err := readLock(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue // Lock was just released; try again.
+			}
+
+			return nil, err
+		}
+
+		if isStale(owner, info) {
+			log.Warnf("removing abandoned lock (%s): %s", owner, path)
+
+			if err := removeIfSame(path, info); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if !logged {
+			logged = true
+
+			log.Infof("waiting for another 'gdenv' process to release lock (%s): %s", owner, path)
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("%w: held by %s: %s", ErrLockTimeout, owner, path)
+			}
+
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+
+	log.Debugf("acquired lock: %s", path)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		heartbeat(path, done)
+	}()
+
+	return func() error {
+		close(done)
+		<-stopped
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		log.Debugf("released lock: %s", path)
+
+		return nil
+	}, nil
+}
+
+/* -------------------------- Function: tryAcquire -------------------------- */
+
+// tryAcquire attempts to exclusively create the lock file at 'path'. Returns
+// whether the lock file was created.
+func tryAcquire(path string) (bool, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, osutil.ModeUserRW)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = ""
+	}
+
+	owner := lockOwner{PID: os.Getpid(), Host: host, Acquired: time.Now().UTC()}
+
+	if err := json.NewEncoder(f).Encode(owner); err != nil {
+		return false, errors.Join(err, f.Close(), os.Remove(path))
+	}
+
+	if err := f.Close(); err != nil {
+		return false, errors.Join(err, os.Remove(path))
+	}
+
+	return true, nil
+}
+
+/* --------------------------- Function: heartbeat -------------------------- */
+
+// heartbeat periodically updates the modification time of the lock file at
+// 'path' until 'done' is closed.
+func heartbeat(path string, done <-chan struct{}) {
+	ticker := time.NewTicker(lockHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			if err := os.Chtimes(path, now, now); err != nil {
+				log.Debugf("failed to update lock: %s: %s", path, err)
+			}
+		}
+	}
+}
+
+/* --------------------------- Function: readLock --------------------------- */
+
+// readLock reads the owner of the lock file at 'path'. If the lock file was
+// only partially written, then an empty 'lockOwner' is returned.
+func readLock(path string) (lockOwner, fs.FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return lockOwner{}, nil, err
+	}
+
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		return lockOwner{}, nil, err
+	}
+
+	var owner lockOwner
+	if err := json.Unmarshal(bb, &owner); err != nil {
+		return lockOwner{}, info, nil //nolint:nilerr
+	}
+
+	return owner, info, nil
+}
+
+/* --------------------------- Function: isStale ---------------------------- */
+
+// isStale returns whether the lock has been abandoned. This is the case if its
+// owner is a process on this host which is no longer running or if its owner
+// has stopped updating it.
+func isStale(owner lockOwner, info fs.FileInfo) bool {
+	if time.Since(info.ModTime()) > lockStaleAfter {
+		return true
+	}
+
+	host, err := os.Hostname()
+	if err != nil || owner.PID == 0 || owner.Host != host {
+		return false
+	}
+
+	return !isProcessAlive(owner.PID)
+}
+
+/* ------------------------- Function: removeIfSame ------------------------- */
+
+// removeIfSame removes the file at 'path', but only if it's the same file as
+// described by 'info'. This reduces the chance of removing a lock which was
+// acquired by another process after 'info' was read.
+func removeIfSame(path string, info fs.FileInfo) error {
+	current, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !os.SameFile(info, current) || !info.ModTime().Equal(current.ModTime()) {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+/* -------------------------- Function: isUnlocked -------------------------- */
+
+// isUnlocked returns whether no active lock exists at 'path', which may be a
+// lock file or a directory of lock files. Abandoned locks are ignored.
+func isUnlocked(path string) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	paths := []string{path}
+
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return false, err
+		}
+
+		paths = paths[:0]
+
+		for _, e := range entries {
+			if filepath.Ext(e.Name()) == lockFileExt {
+				paths = append(paths, filepath.Join(path, e.Name()))
+			}
+		}
+	}
+
+	for _, p := range paths {
+		owner, info, err := readLock(p)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return false, err
+		}
+
+		if !isStale(owner, info) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+/* ----------------------------- Function: poll ----------------------------- */
+
+// poll calls 'fn' until it returns 'true', an error, or the context is done.
+func poll(ctx context.Context, fn func() (bool, error)) error {
+	for {
+		ok, err := fn()
+		if err != nil || ok {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ErrLockTimeout
+			}
+
+			return ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
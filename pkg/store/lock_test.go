@@ -0,0 +1,178 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/version"
+)
+
+/* ------------------------------- Test: Lock ------------------------------- */
+
+func TestLock(t *testing.T) {
+	storePath := t.TempDir()
+
+	// Given: The store lock has been acquired.
+	unlock, err := Lock(context.Background(), storePath)
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	// When: The store lock is acquired again.
+	_, err = Lock(mustTimeout(t, 3*lockPollInterval), storePath)
+
+	// Then: The lock can't be acquired before the context is done.
+	if !errors.Is(err, ErrLockTimeout) {
+		t.Fatalf("err: got %v, want %v", err, ErrLockTimeout)
+	}
+
+	// When: The store lock is released.
+	if err := unlock(); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: The store lock can be acquired again.
+	unlock, err = Lock(mustTimeout(t, time.Second), storePath)
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	if err := unlock(); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: The lock file is removed.
+	fstest.Absent{Path: storeFileLock}.Assert(t, storePath)
+}
+
+/* ---------------------------- Test: LockArtifact -------------------------- */
+
+func TestLockArtifact(t *testing.T) {
+	ex := executable.MustParse("Godot_v4.0-stable_linux.x86_64")
+	src := source.New(version.MustParse("4.0-stable"))
+
+	storePath := t.TempDir()
+
+	// Given: An artifact lock has been acquired.
+	unlockEx, err := LockArtifact(context.Background(), storePath, ex)
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	// Then: The same artifact can't be locked again.
+	if _, err := LockArtifact(mustTimeout(t, 3*lockPollInterval), storePath, ex); !errors.Is(err, ErrLockTimeout) {
+		t.Fatalf("err: got %v, want %v", err, ErrLockTimeout)
+	}
+
+	// Then: A different artifact can be locked.
+	unlockSrc, err := LockArtifact(mustTimeout(t, time.Second), storePath, src)
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	if err := unlockSrc(); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: The store can't be locked while an artifact is locked.
+	if _, err := Lock(mustTimeout(t, 3*lockPollInterval), storePath); !errors.Is(err, ErrLockTimeout) {
+		t.Fatalf("err: got %v, want %v", err, ErrLockTimeout)
+	}
+
+	if err := unlockEx(); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Given: The store lock has been acquired.
+	unlock, err := Lock(mustTimeout(t, time.Second), storePath)
+	if err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+
+	// Then: No artifact can be locked while the store is locked.
+	if _, err := LockArtifact(mustTimeout(t, 3*lockPollInterval), storePath, src); !errors.Is(err, ErrLockTimeout) {
+		t.Fatalf("err: got %v, want %v", err, ErrLockTimeout)
+	}
+
+	if err := unlock(); err != nil {
+		t.Fatalf("err: got %v, want %v", err, nil)
+	}
+}
+
+/* ------------------------- Test: Lock (abandoned) ------------------------- */
+
+func TestLockAbandoned(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		age      time.Duration
+	}{
+		{
+			name:     "lock owned by an exited process is removed",
+			contents: `{"pid": -1, "host": "` + mustHostname(t) + `"}`,
+		},
+		{
+			name:     "lock which hasn't been updated is removed",
+			contents: `{"pid": 1, "host": "other"}`,
+			age:      2 * lockStaleAfter,
+		},
+		{
+			name: "partially-written lock which hasn't been updated is removed",
+			age:  2 * lockStaleAfter,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			storePath := t.TempDir()
+
+			// Given: An abandoned store lock exists.
+			path := filepath.Join(storePath, storeFileLock)
+			fstest.File{Path: storeFileLock, Contents: tc.contents}.Write(t, storePath)
+
+			modified := time.Now().Add(-tc.age)
+			if err := os.Chtimes(path, modified, modified); err != nil {
+				t.Fatalf("test setup: %v", err)
+			}
+
+			// When: The store lock is acquired.
+			unlock, err := Lock(mustTimeout(t, time.Second), storePath)
+
+			// Then: The abandoned lock is replaced.
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			if err := unlock(); err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+		})
+	}
+}
+
+/* ------------------------- Function: mustTimeout -------------------------- */
+
+func mustTimeout(t *testing.T, d time.Duration) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+/* ------------------------- Function: mustHostname ------------------------- */
+
+func mustHostname(t *testing.T) string {
+	host, err := os.Hostname()
+	if err != nil {
+		t.Fatalf("test setup: %v", err)
+	}
+
+	return host
+}
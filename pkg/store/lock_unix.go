@@ -0,0 +1,23 @@
+//go:build !windows
+
+package store
+
+import (
+	"errors"
+	"syscall"
+)
+
+/* ------------------------- Function: isProcessAlive ----------------------- */
+
+// isProcessAlive returns whether a process with the specified ID is running on
+// this host.
+func isProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+
+	// NOTE: Signal '0' performs error checking without sending a signal.
+	err := syscall.Kill(pid, 0)
+
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,36 @@
+//go:build windows
+
+package store
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// stillActive is the exit code reported for a process which hasn't exited.
+const stillActive = 259
+
+/* ------------------------- Function: isProcessAlive ----------------------- */
+
+// isProcessAlive returns whether a process with the specified ID is running on
+// this host.
+func isProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid)) //nolint:gosec
+	if err != nil {
+		// NOTE: Access may be denied for processes owned by other users, in
+		// which case the process must still exist.
+		return err == windows.ERROR_ACCESS_DENIED //nolint:errorlint
+	}
+
+	defer windows.CloseHandle(h) //nolint:errcheck
+
+	var code uint32
+	if err := windows.GetExitCodeProcess(h, &code); err != nil {
+		return true
+	}
+
+	return code == stillActive
+}
@@ -0,0 +1,330 @@
+package store
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/coffeebeats/gdenv/internal/ioutil"
+	"github.com/coffeebeats/gdenv/internal/osutil"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+)
+
+// storeFileManifest is the name of the file, written within each store entry,
+// which records the contents of the entry at the time it was added.
+const storeFileManifest = ".gdenv-manifest.json"
+
+var ErrMissingManifest = errors.New("missing manifest")
+
+/* -------------------------------------------------------------------------- */
+/*                               Struct: Manifest                             */
+/* -------------------------------------------------------------------------- */
+
+// Manifest describes the files contained within a store entry. It's recorded
+// when an artifact is added to the store so that the installed files can later
+// be checked for modifications (see 'Verify').
+type Manifest struct {
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes a single file within a store entry.
+type ManifestFile struct {
+	// Path is the slash-separated path to the file, relative to the store
+	// entry's directory.
+	Path string `json:"path"`
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+	// Mode contains the file's type and permission bits.
+	Mode fs.FileMode `json:"mode"`
+	// SHA512 is the hex-encoded SHA-512 checksum of a regular file's contents.
+	SHA512 string `json:"sha512,omitempty"`
+	// Link is the target of a symbolic link.
+	Link string `json:"link,omitempty"`
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                Struct: Drift                               */
+/* -------------------------------------------------------------------------- */
+
+// DriftKind describes how an installed file differs from its manifest entry.
+type DriftKind int
+
+const (
+	// DriftMissing denotes a file recorded in the manifest which no longer
+	// exists.
+	DriftMissing DriftKind = iota + 1
+	// DriftModified denotes a file whose contents (or link target) changed.
+	DriftModified
+	// DriftMode denotes a file whose type or permissions changed.
+	DriftMode
+	// DriftUnexpected denotes a file which isn't recorded in the manifest.
+	DriftUnexpected
+)
+
+/* ----------------------------- Method: String ----------------------------- */
+
+func (k DriftKind) String() string {
+	switch k {
+	case DriftMissing:
+		return "missing"
+	case DriftModified:
+		return "modified"
+	case DriftMode:
+		return "mode changed"
+	case DriftUnexpected:
+		return "unexpected"
+	default:
+		return "unknown"
+	}
+}
+
+// Drift describes a single difference between a store entry and its manifest.
+type Drift struct {
+	Kind DriftKind
+	Path string
+}
+
+/* ----------------------------- Method: String ----------------------------- */
+
+func (d Drift) String() string {
+	return fmt.Sprintf("%s: %s", d.Kind, d.Path)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Function: Verify                              */
+/* -------------------------------------------------------------------------- */
+
+// Verify re-hashes the installed files of the specified artifact and compares
+// them against the manifest recorded when the artifact was added to the store.
+// The returned list of differences is sorted by path and is empty if the store
+// entry is intact. Returns 'ErrMissingManifest' if no manifest was recorded
+// (e.g. the artifact was installed by an older version of 'gdenv'). The
+// artifact may be installed in another store (see 'Layer').
+//
+// NOTE: Callers should hold the artifact's lock (see 'LockArtifact').
+func Verify(ctx context.Context, storePath string, a artifact.Artifact) ([]Drift, error) {
+	pathEntry, err := Entry(storePath, a)
+	if err != nil {
+		return nil, err
+	}
+
+	want, err := readManifest(pathEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	return VerifyDir(ctx, pathEntry, want)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: VerifyDir                            */
+/* -------------------------------------------------------------------------- */
+
+// VerifyDir hashes the files within the directory 'root' and compares them
+// against the specified manifest. The returned list of differences is sorted by
+// path and is empty if the directory's contents match the manifest.
+func VerifyDir(ctx context.Context, root string, want Manifest) ([]Drift, error) {
+	got, err := newManifest(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareManifests(want, got), nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: ManifestOf                           */
+/* -------------------------------------------------------------------------- */
+
+// ManifestOf returns the manifest recorded when the specified artifact was
+// added to the store. Returns 'ErrMissingManifest' if no manifest was recorded.
+// The artifact may be installed in another store (see 'Layer').
+func ManifestOf(storePath string, a artifact.Artifact) (Manifest, error) {
+	pathEntry, err := Entry(storePath, a)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	return readManifest(pathEntry)
+}
+
+/* ------------------------ Function: compareManifests ---------------------- */
+
+// compareManifests returns the differences between the expected manifest and
+// the manifest computed from the installed files.
+func compareManifests(want, got Manifest) []Drift {
+	installed := make(map[string]ManifestFile, len(got.Files))
+	for _, f := range got.Files {
+		installed[f.Path] = f
+	}
+
+	out := make([]Drift, 0)
+
+	for _, w := range want.Files {
+		g, ok := installed[w.Path]
+		if !ok {
+			out = append(out, Drift{Kind: DriftMissing, Path: w.Path})
+
+			continue
+		}
+
+		delete(installed, w.Path)
+
+		switch {
+		case g.Mode.Type() != w.Mode.Type():
+			out = append(out, Drift{Kind: DriftMode, Path: w.Path})
+		case g.Size != w.Size || g.SHA512 != w.SHA512 || g.Link != w.Link:
+			out = append(out, Drift{Kind: DriftModified, Path: w.Path})
+		case g.Mode != w.Mode:
+			out = append(out, Drift{Kind: DriftMode, Path: w.Path})
+		}
+	}
+
+	for path := range installed {
+		out = append(out, Drift{Kind: DriftUnexpected, Path: path})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Path < out[j].Path
+	})
+
+	return out
+}
+
+/* -------------------------- Function: newManifest ------------------------- */
+
+// newManifest computes a 'Manifest' describing all files within the store
+// entry directory 'root'. The manifest file itself is excluded.
+func newManifest(ctx context.Context, root string) (Manifest, error) {
+	files := make([]ManifestFile, 0)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if path == root || d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		if rel == storeFileManifest {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		f := ManifestFile{
+			Path:   filepath.ToSlash(rel),
+			Size:   info.Size(),
+			Mode:   info.Mode(),
+			SHA512: "",
+			Link:   "",
+		}
+
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			f.Link, err = os.Readlink(path)
+		case info.Mode().IsRegular():
+			f.SHA512, err = hashFile(ctx, path)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		files = append(files, f)
+
+		return nil
+	})
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	return Manifest{Files: files}, nil
+}
+
+/* --------------------------- Function: hashFile --------------------------- */
+
+// hashFile returns the hex-encoded SHA-512 checksum of the file at 'path'.
+func hashFile(ctx context.Context, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	h := sha512.New()
+
+	if _, err := io.Copy(h, ioutil.NewReaderWithContext(ctx, f.Read)); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+/* ------------------------- Function: readManifest ------------------------- */
+
+// readManifest reads the manifest recorded within the store entry directory
+// 'root'.
+func readManifest(root string) (Manifest, error) {
+	path := filepath.Join(root, storeFileManifest)
+
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Manifest{}, fmt.Errorf("%w: %s", ErrMissingManifest, root)
+		}
+
+		return Manifest{}, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(bb, &m); err != nil {
+		return Manifest{}, fmt.Errorf("%w: %s: %w", ErrMissingManifest, path, err)
+	}
+
+	return m, nil
+}
+
+/* ------------------------- Function: writeManifest ------------------------ */
+
+// writeManifest computes and records a manifest of the files within the store
+// entry directory 'root'. The recorded manifest is returned.
+func writeManifest(ctx context.Context, root string) (Manifest, error) {
+	m, err := newManifest(ctx, root)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	bb, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	if err := os.WriteFile(filepath.Join(root, storeFileManifest), bb, osutil.ModeUserRW); err != nil {
+		return Manifest{}, err
+	}
+
+	return m, nil
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coffeebeats/gdenv/internal/fstest"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
+)
+
+/* ------------------------------ Test: Verify ------------------------------ */
+
+func TestVerify(t *testing.T) {
+	ex := executable.MustParse("Godot_v4.0-stable_linux.x86_64")
+
+	storePathToEx := filepath.Join(storeName, storeDirEx, "v4.0-stable/linux.x86_64")
+
+	tests := []struct {
+		name   string
+		modify func(t *testing.T, pathEntry string)
+
+		want []Drift
+		err  error
+	}{
+		{
+			name: "unmodified store entry has no drift",
+
+			want: []Drift{},
+		},
+		{
+			name: "modified file is reported",
+			modify: func(t *testing.T, pathEntry string) {
+				fstest.File{Path: "b/c", Contents: "tampered"}.Write(t, pathEntry)
+			},
+
+			want: []Drift{{Kind: DriftModified, Path: "b/c"}},
+		},
+		{
+			name: "missing file is reported",
+			modify: func(t *testing.T, pathEntry string) {
+				if err := os.Remove(filepath.Join(pathEntry, "b", "c")); err != nil {
+					t.Fatal(err)
+				}
+			},
+
+			want: []Drift{{Kind: DriftMissing, Path: "b/c"}},
+		},
+		{
+			name: "unexpected file is reported",
+			modify: func(t *testing.T, pathEntry string) {
+				fstest.File{Path: "d"}.Write(t, pathEntry)
+			},
+
+			want: []Drift{{Kind: DriftUnexpected, Path: "d"}},
+		},
+		{
+			name: "changed permissions are reported",
+			modify: func(t *testing.T, pathEntry string) {
+				if err := os.Chmod(filepath.Join(pathEntry, ex.Path()), 0o700); err != nil {
+					t.Fatal(err)
+				}
+			},
+
+			want: []Drift{{Kind: DriftMode, Path: ex.Path()}},
+		},
+		{
+			name: "missing manifest returns error",
+			modify: func(t *testing.T, pathEntry string) {
+				if err := os.Remove(filepath.Join(pathEntry, storeFileManifest)); err != nil {
+					t.Fatal(err)
+				}
+			},
+
+			err: ErrMissingManifest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			storePath := filepath.Join(tmp, storeName)
+
+			// Given: An executable store entry containing multiple files.
+			fstest.File{Path: filepath.Join("in", ex.Path()), Contents: "godot"}.Write(t, tmp)
+			fstest.File{Path: "in/b/c", Contents: "data"}.Write(t, tmp)
+
+			if err := Add(
+				context.Background(),
+				storePath,
+				artifact.Local[artifact.Artifact]{Artifact: ex, Path: filepath.Join(tmp, "in", ex.Path())},
+				artifact.Local[artifact.Artifact]{Artifact: ex, Path: filepath.Join(tmp, "in", "b")},
+			); err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Given: The store entry is modified.
+			if tc.modify != nil {
+				tc.modify(t, filepath.Join(tmp, storePathToEx))
+			}
+
+			// When: The store entry is verified.
+			got, err := Verify(context.Background(), storePath, ex)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected differences are returned.
+			if tc.err == nil && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("drift: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
 	"github.com/coffeebeats/gdenv/pkg/godot/platform"
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
 )
@@ -21,20 +23,53 @@ var (
 	ErrMissingEnvVar = errors.New("missing environment variable")
 )
 
+/* -------------------------------------------------------------------------- */
+/*                               Function: Entry                              */
+/* -------------------------------------------------------------------------- */
+
+// Entry returns the full path to the store entry directory which contains the
+// installed artifact's files. If the artifact is installed in another store
+// (see 'Layer'), then the path within that store is returned. Returns an error
+// wrapping 'fs.ErrNotExist' if the artifact isn't installed.
+func Entry(storePath string, a artifact.Artifact) (string, error) {
+	layer, err := Layer(storePath, a)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := artifactPath(layer, a)
+	if err != nil {
+		return "", err
+	}
+
+	ok, err := hasIn(layer, a)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		return "", fmt.Errorf("%w: %s", fs.ErrNotExist, path)
+	}
+
+	return filepath.Dir(path), nil
+}
+
 /* -------------------------------------------------------------------------- */
 /*                            Function: Executable                            */
 /* -------------------------------------------------------------------------- */
 
 // Returns the full path (starting with the store path) to the *executable* file
-// in the store.
+// in the store. If the executable is installed in another store (see 'Layer'),
+// then the path within that store is returned.
 //
 // NOTE: This does *not* mean the executable exists.
 func Executable(storePath string, ex executable.Executable) (string, error) {
-	if storePath == "" {
-		return "", ErrMissingStore
+	layer, err := Layer(storePath, ex)
+	if err != nil {
+		return "", err
 	}
 
-	pathExecutableDir, err := executableDir(storePath, ex)
+	pathExecutableDir, err := executableDir(layer, ex)
 	if err != nil {
 		return "", err
 	}
@@ -42,12 +77,53 @@ func Executable(storePath string, ex executable.Executable) (string, error) {
 	return filepath.Join(pathExecutableDir, ex.Path()), nil
 }
 
+/* -------------------------------------------------------------------------- */
+/*                              Function: Mirrors                             */
+/* -------------------------------------------------------------------------- */
+
+// Returns the full path (starting with the store path) to the user-defined
+// mirror configuration file.
+//
+// NOTE: This does *not* mean the configuration file exists.
+func Mirrors(storePath string) (string, error) {
+	if storePath == "" {
+		return "", ErrMissingStore
+	}
+
+	return filepath.Join(storePath, storeFileMirrors), nil
+}
+
 /* -------------------------------------------------------------------------- */
 /*                               Function: Path                               */
 /* -------------------------------------------------------------------------- */
 
-// Returns the user-configured path to the 'gdenv' store.
+// Returns the user-configured path to the 'gdenv' store which is written to.
+// If 'GDENV_PATH' is set, this is the first writable store (see 'Layers'), or
+// the first store if none are writable so that installed artifacts can still be
+// read; otherwise it's 'GDENV_HOME'. Use 'Writable' before modifying the store.
 func Path() (string, error) {
+	if os.Getenv(envPath) == "" {
+		return homePath()
+	}
+
+	paths, err := Layers()
+	if err != nil {
+		return "", err
+	}
+
+	for _, path := range paths {
+		if isWritable(path) {
+			return path, nil
+		}
+	}
+
+	return paths[0], nil
+}
+
+/* --------------------------- Function: homePath --------------------------- */
+
+// homePath returns the user-configured path to the 'GDENV_HOME' store.
+func homePath() (string, error) {
 	path := os.Getenv(envStore)
 	if path == "" {
 		return "", fmt.Errorf("%w: %s", ErrMissingEnvVar, envStore)
@@ -60,20 +136,56 @@ func Path() (string, error) {
 	return filepath.Clean(path), nil
 }
 
+/* -------------------------------------------------------------------------- */
+/*                             Function: Releases                             */
+/* -------------------------------------------------------------------------- */
+
+// Returns the full path (starting with the store path) to the cached listing of
+// available Godot releases.
+//
+// NOTE: This does *not* mean the cache file exists.
+func Releases(storePath string) (string, error) {
+	if storePath == "" {
+		return "", ErrMissingStore
+	}
+
+	return filepath.Join(storePath, storeFileReleases), nil
+}
+
 /* -------------------------------------------------------------------------- */
 /*                              Function: Source                              */
 /* -------------------------------------------------------------------------- */
 
 // Returns the full path (starting with the store path) to the Godot source
-// directory in the store.
+// directory in the store. If the source code is installed in another store (see
+// 'Layer'), then the path within that store is returned.
 //
 // NOTE: This does *not* mean the source folder exists.
 func Source(storePath string, src source.Source) (string, error) {
-	if storePath == "" {
-		return "", ErrMissingStore
+	layer, err := Layer(storePath, src)
+	if err != nil {
+		return "", err
 	}
 
-	return artifactPath(storePath, src)
+	return artifactPath(layer, src)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Function: Templates                            */
+/* -------------------------------------------------------------------------- */
+
+// Returns the full path (starting with the store path) to the Godot export
+// templates directory in the store. If the export templates are installed in
+// another store (see 'Layer'), then the path within that store is returned.
+//
+// NOTE: This does *not* mean the export templates folder exists.
+func Templates(storePath string, t templates.Templates) (string, error) {
+	layer, err := Layer(storePath, t)
+	if err != nil {
+		return "", err
+	}
+
+	return artifactPath(layer, t)
 }
 
 /* -------------------------------------------------------------------------- */
@@ -112,6 +224,13 @@ func artifactPath(storePath string, a artifact.Artifact) (string, error) {
 		}
 
 		return filepath.Join(pathSourceDir, source.Archive{Inner: a}.Name()), nil
+	case templates.Templates:
+		pathTemplatesDir, err := templatesDir(storePath, a.Version())
+		if err != nil {
+			return "", err
+		}
+
+		return filepath.Join(pathTemplatesDir, a.Path()), nil
 	}
 
 	return "", fmt.Errorf("%w: %T", ErrUnsupportedArtifact, a)
@@ -150,3 +269,15 @@ func sourceDir(storePath string, v version.Version) (string, error) {
 
 	return path, nil
 }
+
+/* ------------------------- Function: templatesDir ------------------------- */
+
+func templatesDir(storePath string, v version.Version) (string, error) {
+	if err := version.Validate(v); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(storePath, storeDirTpl, v.String())
+
+	return path, nil
+}
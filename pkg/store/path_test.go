@@ -8,6 +8,7 @@ import (
 
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/executable"
 	"github.com/coffeebeats/gdenv/pkg/godot/artifact/source"
+	"github.com/coffeebeats/gdenv/pkg/godot/artifact/templates"
 	"github.com/coffeebeats/gdenv/pkg/godot/version"
 )
 
@@ -42,6 +43,19 @@ func TestExecutable(t *testing.T) {
 				ex.Name(),
 			),
 		},
+
+		{
+			store: storeName,
+			ex:    executable.MustParse("Godot_v4.2-stable_linux.arm64"),
+
+			want: filepath.Join(
+				storeName,
+				storeDirEx,
+				"v4.2-stable",
+				"linux.arm64",
+				"Godot_v4.2-stable_linux.arm64",
+			),
+		},
 	}
 
 	for _, tc := range tests {
@@ -148,3 +162,53 @@ func TestSource(t *testing.T) {
 		})
 	}
 }
+
+/* ----------------------------- Test: Templates ---------------------------- */
+
+func TestTemplates(t *testing.T) {
+	tpl := templates.New(version.Godot4())
+
+	tests := []struct {
+		store string
+		tpl   templates.Templates
+
+		want string
+		err  error
+	}{
+		{
+			store: "",
+			tpl:   tpl,
+
+			err: ErrMissingStore,
+		},
+
+		{
+			store: storeName,
+			tpl:   tpl,
+
+			want: filepath.Join(
+				storeName,
+				storeDirTpl,
+				"v4.0-stable",
+				"templates",
+			),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s-%s", tc.store, tc.tpl.Name()), func(t *testing.T) {
+			// When: The path to the cached export templates directory is determined.
+			got, err := Templates(tc.store, tc.tpl)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %s, want: %v", err, tc.err)
+			}
+
+			// Then: The expected filepath is returned.
+			if got != tc.want {
+				t.Errorf("output: got %s, want: %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const storeFilePins = "pins.json"
+
+/* -------------------------------------------------------------------------- */
+/*                               Function: Pins                               */
+/* -------------------------------------------------------------------------- */
+
+// Pins returns the sorted list of registered directories which contain a pin
+// file (see 'RegisterPin'). Note that the pin files may have since been
+// removed; callers should check whether each entry is stale.
+func Pins(storePath string) ([]string, error) {
+	if storePath == "" {
+		return nil, ErrMissingStore
+	}
+
+	return readPins(storePath)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Function: RegisterPin                           */
+/* -------------------------------------------------------------------------- */
+
+// RegisterPin records that the specified directory contains a pin file. This
+// is a no-op if the directory is already registered.
+func RegisterPin(ctx context.Context, storePath, path string) error {
+	if storePath == "" {
+		return ErrMissingStore
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	// Avoid acquiring the registry lock if there's nothing to add.
+	pins, err := readPins(storePath)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := slices.BinarySearch(pins, path); ok {
+		return nil
+	}
+
+	return updatePins(ctx, storePath, func(pins []string) []string {
+		if i, ok := slices.BinarySearch(pins, path); !ok {
+			pins = slices.Insert(pins, i, path)
+		}
+
+		return pins
+	})
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: UnregisterPin                          */
+/* -------------------------------------------------------------------------- */
+
+// UnregisterPin removes the specified directory from the pin registry. This is
+// a no-op if the directory isn't registered.
+func UnregisterPin(ctx context.Context, storePath, path string) error {
+	if storePath == "" {
+		return ErrMissingStore
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	// Avoid acquiring the registry lock if there's nothing to remove.
+	pins, err := readPins(storePath)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := slices.BinarySearch(pins, path); !ok {
+		return nil
+	}
+
+	return updatePins(ctx, storePath, func(pins []string) []string {
+		if i, ok := slices.BinarySearch(pins, path); ok {
+			pins = slices.Delete(pins, i, i+1)
+		}
+
+		return pins
+	})
+}
+
+/* -------------------------- Function: updatePins -------------------------- */
+
+// updatePins applies 'fn' to the store's pin registry while holding the
+// registry lock (see 'updateFile').
+func updatePins(ctx context.Context, storePath string, fn func([]string) []string) error {
+	return updateFile(ctx, storePath, storeFilePins, func() ([]byte, error) {
+		pins, err := readPins(storePath)
+		if err != nil {
+			return nil, err
+		}
+
+		return json.MarshalIndent(fn(pins), "", "  ")
+	})
+}
+
+/* --------------------------- Function: readPins --------------------------- */
+
+// readPins reads the store's pin registry, sorted by path. A missing registry
+// is treated as empty.
+func readPins(storePath string) ([]string, error) {
+	bb, err := os.ReadFile(filepath.Join(storePath, storeFilePins))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []string{}, nil
+		}
+
+		return nil, err
+	}
+
+	var pins []string
+	if err := json.Unmarshal(bb, &pins); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnexpectedLayout, err)
+	}
+
+	slices.Sort(pins)
+
+	return slices.Compact(pins), nil
+}
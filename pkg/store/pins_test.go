@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+/* ------------------------------- Test: Pins ------------------------------- */
+
+func TestPins(t *testing.T) {
+	tests := []struct {
+		name       string
+		register   []string
+		unregister []string
+
+		want []string
+	}{
+		{
+			name: "empty registry has no pins",
+
+			want: []string{},
+		},
+		{
+			name:     "registered paths are sorted",
+			register: []string{"b", "a"},
+
+			want: []string{"a", "b"},
+		},
+		{
+			name:     "registering a path twice is a no-op",
+			register: []string{"a", "a"},
+
+			want: []string{"a"},
+		},
+		{
+			name:       "unregistered paths are removed",
+			register:   []string{"a", "b"},
+			unregister: []string{"a"},
+
+			want: []string{"b"},
+		},
+		{
+			name:       "unregistering a missing path is a no-op",
+			register:   []string{"a"},
+			unregister: []string{"b"},
+
+			want: []string{"a"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tmp := t.TempDir()
+			storePath := filepath.Join(tmp, storeName)
+
+			// Given: A store exists.
+			if err := Touch(context.Background(), storePath); err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			// Given: The specified paths are registered.
+			for _, p := range tc.register {
+				if err := RegisterPin(ctx, storePath, filepath.Join(tmp, p)); err != nil {
+					t.Fatalf("err: got %v, want %v", err, nil)
+				}
+			}
+
+			// Given: The specified paths are unregistered.
+			for _, p := range tc.unregister {
+				if err := UnregisterPin(ctx, storePath, filepath.Join(tmp, p)); err != nil {
+					t.Fatalf("err: got %v, want %v", err, nil)
+				}
+			}
+
+			// When: The registered pins are listed.
+			got, err := Pins(storePath)
+
+			// Then: There's no error.
+			if !errors.Is(err, nil) {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			want := make([]string, 0, len(tc.want))
+			for _, p := range tc.want {
+				want = append(want, filepath.Join(tmp, p))
+			}
+
+			// Then: The expected paths are returned.
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("output: got %v, want %v", got, want)
+			}
+		})
+	}
+}
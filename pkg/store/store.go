@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/charmbracelet/log"
 
@@ -18,10 +19,17 @@ import (
 )
 
 const (
-	storeDirBin     = "bin"
-	storeDirSrc     = "src"
-	storeDirEx      = "editor"
-	storeFileLayout = "layout.v0" // simplify migrating in the future
+	storeDirBin = "bin"
+	storeDirSrc = "src"
+	storeDirEx  = "editor"
+	storeDirTpl = "templates"
+
+	storeDirLocks   = "locks"
+	storeDirStaging = ".staging"
+
+	storeFileLock     = "gdenv.lock"
+	storeFileMirrors  = "mirrors.json"
+	storeFileReleases = "releases.json"
 )
 
 var (
@@ -38,10 +46,18 @@ type LocalSrc = artifact.Local[source.Archive]
 /*                                Function: Add                               */
 /* -------------------------------------------------------------------------- */
 
-// Add caches the specified locally-available artifacts in the store.
+// Add caches the specified locally-available artifacts in the store. Artifacts
+// are first copied into a staging directory and then each store entry is moved
+// into place with a rename, so 'Has' never observes a partially-added artifact.
+// Any existing store entry for an artifact is replaced. A manifest of each
+// store entry's files is recorded alongside them (see 'Verify') and each
+// entry's metadata, including any 'Provenance' carried by the context (see
+// 'WithProvenance'), is recorded in the store's index (see 'MetadataOf').
+//
+// NOTE: Callers should hold the artifact's lock (see 'LockArtifact').
 func Add(ctx context.Context, storePath string, localArtifacts ...artifact.Local[artifact.Artifact]) error {
-	if storePath == "" {
-		return ErrMissingStore
+	if err := Writable(storePath); err != nil {
+		return err
 	}
 
 	// Verify that the files-to-add exist.
@@ -51,7 +67,24 @@ func Add(ctx context.Context, storePath string, localArtifacts ...artifact.Local
 		}
 	}
 
-	// Add the specified artifacts to the store.
+	pathStaging := filepath.Join(storePath, storeDirStaging)
+	if err := os.MkdirAll(pathStaging, osutil.ModeUserRWXGroupRX); err != nil {
+		return err
+	}
+
+	// Map each store entry to the staging directory in which it's assembled.
+	staged := make(map[string]string, len(localArtifacts))
+	entries := make([]string, 0, len(localArtifacts))
+
+	defer func() {
+		for _, pathStaged := range staged {
+			if err := os.RemoveAll(pathStaged); err != nil {
+				log.Debugf("failed to remove staging directory: %s: %s", pathStaged, err)
+			}
+		}
+	}()
+
+	// Stage the specified artifacts.
 	for _, local := range localArtifacts {
 		// Determine the directory to place the files under.
 		pathArtifact, err := artifactPath(storePath, local.Artifact)
@@ -61,14 +94,24 @@ func Add(ctx context.Context, storePath string, localArtifacts ...artifact.Local
 
 		pathArtifactDir := filepath.Dir(pathArtifact)
 
-		// Create the required directories, if needed.
-		if err := os.MkdirAll(pathArtifactDir, osutil.ModeUserRWXGroupRX); err != nil {
-			return err
+		pathStaged, ok := staged[pathArtifactDir]
+		if !ok {
+			pathStaged, err = os.MkdirTemp(pathStaging, "add-*")
+			if err != nil {
+				return err
+			}
+
+			if err := os.Chmod(pathStaged, osutil.ModeUserRWXGroupRX); err != nil {
+				return err
+			}
+
+			staged[pathArtifactDir] = pathStaged
+			entries = append(entries, pathArtifactDir)
 		}
 
-		path := filepath.Join(pathArtifactDir, filepath.Base(local.Path))
+		path := filepath.Join(pathStaged, filepath.Base(local.Path))
 
-		log.Debugf("adding artifact to store: %s", path)
+		log.Debugf("staging artifact: %s", path)
 
 		info, err := os.Stat(local.Path)
 		if err != nil {
@@ -83,14 +126,64 @@ func Add(ctx context.Context, storePath string, localArtifacts ...artifact.Local
 			continue
 		}
 
-		// Remove the existing directory to make way for new artifact.
-		if err := os.RemoveAll(path); err != nil {
+		if err := osutil.CopyDir(ctx, local.Path, path); err != nil {
 			return err
 		}
+	}
 
-		if err := osutil.CopyDir(ctx, local.Path, path); err != nil {
+	manifests := make(map[string]Manifest, len(entries))
+
+	// Move each staged store entry into place.
+	for _, pathArtifactDir := range entries {
+		// Record the staged contents so that they can later be verified.
+		m, err := writeManifest(ctx, staged[pathArtifactDir])
+		if err != nil {
+			return err
+		}
+
+		log.Debugf("adding artifact to store: %s", pathArtifactDir)
+
+		if err := replaceDir(staged[pathArtifactDir], pathArtifactDir); err != nil {
+			return err
+		}
+
+		manifests[pathArtifactDir] = m
+	}
+
+	return recordAdded(ctx, storePath, manifests)
+}
+
+/* --------------------------- Function: replaceDir ------------------------- */
+
+// replaceDir moves the directory 'src' to 'dst', replacing any existing
+// directory at 'dst'. The existing directory is moved aside first so that 'dst'
+// never contains a mix of old and new contents.
+func replaceDir(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), osutil.ModeUserRWXGroupRX); err != nil {
+		return err
+	}
+
+	prev := src + ".prev"
+
+	if err := os.Rename(dst, prev); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
 			return err
 		}
+
+		prev = ""
+	}
+
+	if err := os.Rename(src, dst); err != nil {
+		// Try to restore the previous store entry.
+		if prev != "" {
+			err = errors.Join(err, os.Rename(prev, dst))
+		}
+
+		return err
+	}
+
+	if prev != "" {
+		return os.RemoveAll(prev)
 	}
 
 	return nil
@@ -101,35 +194,134 @@ func Add(ctx context.Context, storePath string, localArtifacts ...artifact.Local
 /* -------------------------------------------------------------------------- */
 
 // Removes all cached artifacts in the store.
+//
+// NOTE: Callers should hold the store lock (see 'Lock').
 func Clear(storePath string) error {
 	if storePath == "" {
 		return ErrMissingStore
 	}
 
-	// Clear the entire source cache directory.
-	if err := os.RemoveAll(filepath.Join(storePath, storeDirSrc)); err != nil {
+	// Clear any abandoned, partially-added artifacts.
+	if err := os.RemoveAll(filepath.Join(storePath, storeDirStaging)); err != nil {
+		return err
+	}
+
+	// Clear the metadata of all installed artifacts.
+	if err := os.Remove(filepath.Join(storePath, storeFileIndex)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if err := ClearSources(storePath); err != nil {
+		return err
+	}
+
+	if err := ClearExecutables(storePath); err != nil {
+		return err
+	}
+
+	return ClearTemplates(storePath)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                         Function: ClearExecutables                         */
+/* -------------------------------------------------------------------------- */
+
+// Removes all cached executables in the store, leaving other artifacts intact.
+//
+// NOTE: Callers should hold the store lock (see 'Lock').
+func ClearExecutables(storePath string) error {
+	return clearDir(storePath, storeDirEx)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Function: ClearSources                           */
+/* -------------------------------------------------------------------------- */
+
+// Removes all cached source code archives in the store, leaving other artifacts
+// intact.
+//
+// NOTE: Callers should hold the store lock (see 'Lock').
+func ClearSources(storePath string) error {
+	return clearDir(storePath, storeDirSrc)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Function: ClearTemplates                          */
+/* -------------------------------------------------------------------------- */
+
+// Removes all cached export templates in the store, leaving other artifacts
+// intact.
+//
+// NOTE: Callers should hold the store lock (see 'Lock').
+func ClearTemplates(storePath string) error {
+	return clearDir(storePath, storeDirTpl)
+}
+
+/* ---------------------------- Function: clearDir -------------------------- */
+
+// clearDir removes the entire artifact cache directory 'name' from the store,
+// along with the metadata of the artifacts within it, and then remakes it.
+func clearDir(storePath, name string) error {
+	if storePath == "" {
+		return ErrMissingStore
+	}
+
+	if err := os.RemoveAll(filepath.Join(storePath, name)); err != nil {
 		return err
 	}
 
-	// Clear the entire executable cache directory.
-	if err := os.RemoveAll(filepath.Join(storePath, storeDirEx)); err != nil {
+	if err := recordCleared(context.Background(), storePath, name); err != nil {
 		return err
 	}
 
-	// Remake the deleted directories.
-	return Touch(storePath)
+	// Remake the deleted directory.
+	return makeDirs(storePath)
 }
 
 /* -------------------------------------------------------------------------- */
 /*                            Function: Executables                           */
 /* -------------------------------------------------------------------------- */
 
-// Executables returns the list of installed Godot executables.
+// Executables returns the list of installed Godot executables, ordered by
+// version (see 'version.Compare'). Other stores are also searched (see
+// 'Layer'); if an executable is installed in multiple stores, then only the
+// first is included.
 func Executables(ctx context.Context, storePath string) ([]LocalEx, error) {
 	if storePath == "" {
 		return nil, ErrMissingStore
 	}
 
+	var out []LocalEx
+
+	for _, layer := range layers(storePath) {
+		ee, err := executablesIn(ctx, layer)
+		if err != nil {
+			return nil, err
+		}
+
+		if out == nil && ee != nil {
+			out = make([]LocalEx, 0, len(ee))
+		}
+
+		for _, ex := range ee {
+			if !slices.ContainsFunc(out, func(e LocalEx) bool { return e.Artifact == ex.Artifact }) {
+				out = append(out, ex)
+			}
+		}
+	}
+
+	// NOTE: Directory order doesn't match version order (e.g. 'v4.10' is read
+	// before 'v4.2'), so sort executables by version.
+	slices.SortStableFunc(out, func(a, b LocalEx) int {
+		return version.Compare(a.Artifact.Version(), b.Artifact.Version())
+	})
+
+	return out, nil
+}
+
+// Returns all cached executables in the specified store, ignoring other
+// stores.
+func executablesIn(ctx context.Context, storePath string) ([]LocalEx, error) {
 	out := make([]LocalEx, 0)
 
 	entries, err := os.ReadDir(filepath.Join(storePath, storeDirEx))
@@ -191,7 +383,7 @@ func collectExecutablesForVersion(
 
 		ex := executable.New(v, p)
 
-		ok, err := Has(storePath, ex)
+		ok, err := hasIn(storePath, ex)
 		if err != nil {
 			return nil, err
 		}
@@ -215,31 +407,21 @@ func collectExecutablesForVersion(
 /*                                Function: Has                               */
 /* -------------------------------------------------------------------------- */
 
-// Return whether the store has the specified version cached.
+// Return whether the store has the specified version cached. Other stores are
+// also searched (see 'Layer').
 func Has(storePath string, a artifact.Artifact) (bool, error) {
 	if storePath == "" {
 		return false, ErrMissingStore
 	}
 
-	path, err := artifactPath(storePath, a)
-	if err != nil {
-		if !errors.Is(err, ErrUnsupportedArtifact) {
-			return false, err
-		}
-
-		return false, nil
-	}
-
-	_, err = os.Stat(path)
-	if err != nil {
-		if !errors.Is(err, fs.ErrNotExist) {
-			return false, err
+	for _, layer := range layers(storePath) {
+		ok, err := hasIn(layer, a)
+		if err != nil || ok {
+			return ok, err
 		}
-
-		return false, nil
 	}
 
-	return true, nil
+	return false, nil
 }
 
 /* -------------------------------------------------------------------------- */
@@ -247,6 +429,8 @@ func Has(storePath string, a artifact.Artifact) (bool, error) {
 /* -------------------------------------------------------------------------- */
 
 // Removes the specified version from the store.
+//
+// NOTE: Callers should hold the artifact's lock (see 'LockArtifact').
 func Remove(storePath string, a artifact.Artifact) error {
 	if storePath == "" {
 		return ErrMissingStore
@@ -276,9 +460,37 @@ func Remove(storePath string, a artifact.Artifact) error {
 
 	log.Debugf("removed directory from store: %s", path)
 
+	if err := recordRemoved(context.Background(), storePath, a); err != nil {
+		return err
+	}
+
+	// Remove the store entry's manifest if no other artifacts remain.
+	if err := removeOrphanedManifest(filepath.Dir(path)); err != nil {
+		return err
+	}
+
 	return removeUnusedCacheDirectories(storePath, path)
 }
 
+// A utility method which removes the manifest within the specified store entry
+// directory if it's the only remaining file.
+func removeOrphanedManifest(path string) error {
+	files, err := os.ReadDir(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	if len(files) != 1 || files[0].Name() != storeFileManifest {
+		return nil
+	}
+
+	return os.Remove(filepath.Join(path, storeFileManifest))
+}
+
 // A utility method which cleans up unused directories from the specified path
 // up to the store's cache directories.
 func removeUnusedCacheDirectories(storePath, path string) error {
@@ -286,7 +498,9 @@ func removeUnusedCacheDirectories(storePath, path string) error {
 		path = filepath.Dir(path)
 
 		// Add a safeguard to not escape the store cache directories.
-		if path == filepath.Join(storePath, storeDirEx) || path == filepath.Join(storePath, storeDirSrc) {
+		if path == filepath.Join(storePath, storeDirEx) ||
+			path == filepath.Join(storePath, storeDirSrc) ||
+			path == filepath.Join(storePath, storeDirTpl) {
 			return nil
 		}
 
@@ -305,16 +519,101 @@ func removeUnusedCacheDirectories(storePath, path string) error {
 	}
 }
 
+/* -------------------------------------------------------------------------- */
+/*                               Function: Size                               */
+/* -------------------------------------------------------------------------- */
+
+// Size returns the total size in bytes of the files within the store entry of
+// the specified artifact, which may be installed in another store (see
+// 'Layer'). The entry's manifest (see 'Verify') isn't included.
+func Size(ctx context.Context, storePath string, a artifact.Artifact) (int64, error) {
+	layer, err := Layer(storePath, a)
+	if err != nil {
+		return 0, err
+	}
+
+	path, err := artifactPath(layer, a)
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+
+	root := filepath.Dir(path)
+	pathManifest := filepath.Join(root, storeFileManifest)
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if !d.Type().IsRegular() || path == pathManifest {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		size += info.Size()
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
 /* -------------------------------------------------------------------------- */
 /*                              Function: Sources                             */
 /* -------------------------------------------------------------------------- */
 
-// Sources returns the list of installed Godot source code versions.
+// Sources returns the list of installed Godot source code versions, ordered by
+// version (see 'version.Compare'). Other stores are also searched (see
+// 'Layer'); if a version is installed in multiple stores, then only the first
+// is included.
 func Sources(ctx context.Context, storePath string) ([]LocalSrc, error) {
 	if storePath == "" {
 		return nil, ErrMissingStore
 	}
 
+	var out []LocalSrc
+
+	for _, layer := range layers(storePath) {
+		ss, err := sourcesIn(ctx, layer)
+		if err != nil {
+			return nil, err
+		}
+
+		if out == nil && ss != nil {
+			out = make([]LocalSrc, 0, len(ss))
+		}
+
+		for _, src := range ss {
+			if !slices.ContainsFunc(out, func(s LocalSrc) bool { return s.Artifact == src.Artifact }) {
+				out = append(out, src)
+			}
+		}
+	}
+
+	// NOTE: Directory order doesn't match version order, so sort by version.
+	slices.SortStableFunc(out, func(a, b LocalSrc) int {
+		return version.Compare(a.Artifact.Version(), b.Artifact.Version())
+	})
+
+	return out, nil
+}
+
+// Returns all cached source code versions in the specified store, ignoring
+// other stores.
+func sourcesIn(ctx context.Context, storePath string) ([]LocalSrc, error) {
 	out := make([]LocalSrc, 0)
 
 	entries, err := os.ReadDir(filepath.Join(storePath, storeDirSrc))
@@ -338,7 +637,7 @@ func Sources(ctx context.Context, storePath string) ([]LocalSrc, error) {
 
 		src := source.New(v)
 
-		ok, err := Has(storePath, src)
+		ok, err := hasIn(storePath, src)
 		if err != nil {
 			return nil, err
 		}
@@ -365,33 +664,57 @@ func Sources(ctx context.Context, storePath string) ([]LocalSrc, error) {
 /*                               Function: Touch                              */
 /* -------------------------------------------------------------------------- */
 
-// Touch ensures a store is initialized at the specified path; no effect if it
-// exists already.
-func Touch(storePath string) error {
+// Touch ensures a store is initialized at the specified path and migrates it to
+// the current layout (see 'Layout'), if needed; no effect if it exists already.
+// Returns 'ErrUnexpectedLayout' if the layout of the store, or of any other
+// store which is searched (see 'Layers'), is newer than this version of 'gdenv'
+// understands.
+//
+// NOTE: Migrations acquire the store lock, so callers must not hold any locks.
+func Touch(ctx context.Context, storePath string) error {
 	if storePath == "" {
 		return ErrMissingStore
 	}
 
+	if err := makeDirs(storePath); err != nil {
+		return err
+	}
+
+	if err := migrate(ctx, storePath); err != nil {
+		return err
+	}
+
+	// Other stores aren't migrated, but they must have a supported layout.
+	for _, layer := range layers(storePath) {
+		if layer == storePath {
+			continue
+		}
+
+		if _, err := checkLayout(layer); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/* --------------------------- Function: makeDirs --------------------------- */
+
+// makeDirs creates the store directory and its required subdirectories, if
+// needed.
+func makeDirs(storePath string) error {
 	// Create the 'Store' directory, if needed.
 	if err := os.MkdirAll(storePath, osutil.ModeUserRWXGroupRX); err != nil {
 		return err
 	}
 
 	// Create the required subdirectories, if needed.
-	for _, d := range []string{storeDirBin, storeDirSrc, storeDirEx} {
+	for _, d := range []string{storeDirBin, storeDirSrc, storeDirEx, storeDirTpl} {
 		path := filepath.Join(storePath, d)
 		if err := os.MkdirAll(path, osutil.ModeUserRWXGroupRX); err != nil {
 			return err
 		}
 	}
 
-	// Create the required files, if needed.
-	for _, f := range []string{storeFileLayout} {
-		path := filepath.Join(storePath, f)
-		if err := os.WriteFile(path, nil, osutil.ModeUserRW); err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
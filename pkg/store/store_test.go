@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"io/fs"
+	"maps"
+	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/coffeebeats/gdenv/internal/fstest"
@@ -115,6 +118,21 @@ func TestAdd(t *testing.T) {
 				fstest.File{Path: storePathToEx + "/a", Contents: "next"},
 			},
 		},
+		{
+			name: "existing store entry is replaced",
+			add: []artifact.Local[artifact.Artifact]{
+				{Artifact: ex, Path: "a"},
+			},
+			files: []fstest.Writer{
+				fstest.File{Path: "a", Contents: "next"},
+				fstest.File{Path: storePathToEx + "/b", Contents: "prev"},
+			},
+
+			want: []fstest.Asserter{
+				fstest.File{Path: storePathToEx + "/a", Contents: "next"},
+				fstest.Absent{Path: storePathToEx + "/b"},
+			},
+		},
 		{
 			name: "a directory can be added into store",
 			add: []artifact.Local[artifact.Artifact]{
@@ -174,6 +192,16 @@ func TestAdd(t *testing.T) {
 			for _, f := range tc.want {
 				f.Assert(t, tmp)
 			}
+
+			// Then: No staged artifacts remain.
+			entries, err := os.ReadDir(filepath.Join(storePath, storeDirStaging))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			if len(entries) > 0 {
+				t.Errorf("staging: got %v, want %v", entries, nil)
+			}
 		})
 	}
 }
@@ -195,7 +223,7 @@ func TestClear(t *testing.T) {
 				fstest.Dir{Path: filepath.Join(storeName, storeDirBin)},
 				fstest.Dir{Path: filepath.Join(storeName, storeDirEx)},
 				fstest.Dir{Path: filepath.Join(storeName, storeDirSrc)},
-				fstest.File{Path: filepath.Join(storeName, storeFileLayout)},
+				fstest.Dir{Path: filepath.Join(storeName, storeDirTpl)},
 			},
 		},
 		{
@@ -214,6 +242,16 @@ func TestClear(t *testing.T) {
 				fstest.Absent{Path: filepath.Join(storeName, storeDirSrc, "a/b")},
 			},
 		},
+		{
+			name: "clearing removes staged artifacts",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeDirStaging, "add-1/a")},
+			},
+
+			want: []fstest.Asserter{
+				fstest.Absent{Path: filepath.Join(storeName, storeDirStaging)},
+			},
+		},
 		{
 			name: "clearing doesn't remove binary files",
 			files: []fstest.Writer{
@@ -250,6 +288,101 @@ func TestClear(t *testing.T) {
 	}
 }
 
+/* -------------------------- Test: ClearArtifacts -------------------------- */
+
+func TestClearArtifacts(t *testing.T) {
+	files := []fstest.Writer{
+		fstest.File{Path: filepath.Join(storeName, storeDirEx, "v4.0-stable/linux.x86_64/a")},
+		fstest.File{Path: filepath.Join(storeName, storeDirSrc, "v4.0-stable/b")},
+		fstest.File{Path: filepath.Join(storeName, storeDirTpl, "v4.0-stable/c")},
+		fstest.File{
+			Path: filepath.Join(storeName, storeFileIndex),
+			Contents: `{
+				"editor/v4.0-stable/linux.x86_64": {},
+				"src/v4.0-stable": {},
+				"templates/v4.0-stable": {}
+			}`,
+		},
+	}
+
+	tests := []struct {
+		name  string
+		clear func(string) error
+
+		want      []fstest.Asserter
+		wantIndex []string
+	}{
+		{
+			name:  "clearing executables keeps other artifacts",
+			clear: ClearExecutables,
+
+			want: []fstest.Asserter{
+				fstest.Dir{Path: filepath.Join(storeName, storeDirEx)},
+				fstest.Absent{Path: filepath.Join(storeName, storeDirEx, "v4.0-stable")},
+				fstest.File{Path: filepath.Join(storeName, storeDirSrc, "v4.0-stable/b")},
+				fstest.File{Path: filepath.Join(storeName, storeDirTpl, "v4.0-stable/c")},
+			},
+			wantIndex: []string{"src/v4.0-stable", "templates/v4.0-stable"},
+		},
+		{
+			name:  "clearing source code keeps other artifacts",
+			clear: ClearSources,
+
+			want: []fstest.Asserter{
+				fstest.File{Path: filepath.Join(storeName, storeDirEx, "v4.0-stable/linux.x86_64/a")},
+				fstest.Dir{Path: filepath.Join(storeName, storeDirSrc)},
+				fstest.Absent{Path: filepath.Join(storeName, storeDirSrc, "v4.0-stable")},
+				fstest.File{Path: filepath.Join(storeName, storeDirTpl, "v4.0-stable/c")},
+			},
+			wantIndex: []string{"editor/v4.0-stable/linux.x86_64", "templates/v4.0-stable"},
+		},
+		{
+			name:  "clearing export templates keeps other artifacts",
+			clear: ClearTemplates,
+
+			want: []fstest.Asserter{
+				fstest.File{Path: filepath.Join(storeName, storeDirEx, "v4.0-stable/linux.x86_64/a")},
+				fstest.File{Path: filepath.Join(storeName, storeDirSrc, "v4.0-stable/b")},
+				fstest.Dir{Path: filepath.Join(storeName, storeDirTpl)},
+				fstest.Absent{Path: filepath.Join(storeName, storeDirTpl, "v4.0-stable")},
+			},
+			wantIndex: []string{"editor/v4.0-stable/linux.x86_64", "src/v4.0-stable"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			// Given: Artifacts of each kind exist in the store.
+			for _, f := range files {
+				f.Write(t, tmp)
+			}
+
+			// When: The artifacts of one kind are cleared from the store.
+			storePath := filepath.Join(tmp, storeName)
+			if err := tc.clear(storePath); err != nil {
+				t.Errorf("err: got %v, want %v", err, nil)
+			}
+
+			// Then: The expected files exist on the file system.
+			for _, f := range tc.want {
+				f.Assert(t, tmp)
+			}
+
+			// Then: Only the metadata of the cleared artifacts is removed.
+			index, err := readIndex(storePath)
+			if err != nil {
+				t.Fatalf("err: got %v, want %v", err, nil)
+			}
+
+			if got := slices.Sorted(maps.Keys(index)); !reflect.DeepEqual(got, tc.wantIndex) {
+				t.Errorf("output: got %v, want %v", got, tc.wantIndex)
+			}
+		})
+	}
+}
+
 /* ---------------------------- Test: Executables --------------------------- */
 
 func TestExecutables(t *testing.T) {
@@ -282,7 +415,7 @@ func TestExecutables(t *testing.T) {
 			files: []fstest.Writer{
 				fstest.File{Path: filepath.Join(storeName, storeDirBin, "gdenv-cli")},
 				fstest.File{Path: filepath.Join(storeName, storeDirSrc, version.Godot3().String(), "godot")},
-				fstest.File{Path: filepath.Join(storeName, storeFileLayout)},
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
 				fstest.File{Path: filepath.Join(storeName, ".godot-version")},
 
 				fstest.Dir{Path: filepath.Join(storeName, storeDirEx)},
@@ -296,7 +429,7 @@ func TestExecutables(t *testing.T) {
 			files: []fstest.Writer{
 				fstest.File{Path: filepath.Join(storeName, storeDirBin, "gdenv-cli")},
 				fstest.File{Path: filepath.Join(storeName, storeDirSrc, version.Godot3().String(), "godot")},
-				fstest.File{Path: filepath.Join(storeName, storeFileLayout)},
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
 				fstest.File{Path: filepath.Join(storeName, ".godot-version")},
 
 				fstest.File{
@@ -606,6 +739,19 @@ func TestRemove(t *testing.T) {
 				fstest.File{Path: filepath.Join(storePathToSrc, srcArchive.Name())},
 			},
 
+			want: []fstest.Asserter{
+				fstest.Dir{Path: filepath.Join(storeName, storeDirSrc)},
+				fstest.Absent{Path: filepath.Join(storeName, storeDirSrc, srcArchive.Inner.Version().String())},
+			},
+		},
+		{
+			name:   "remove source cleans up orphaned manifest",
+			remove: srcArchive.Inner,
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storePathToSrc, srcArchive.Name())},
+				fstest.File{Path: filepath.Join(storePathToSrc, storeFileManifest)},
+			},
+
 			want: []fstest.Asserter{
 				fstest.Dir{Path: filepath.Join(storeName, storeDirSrc)},
 				fstest.Absent{Path: filepath.Join(storeName, storeDirSrc, srcArchive.Inner.Version().String())},
@@ -637,6 +783,72 @@ func TestRemove(t *testing.T) {
 	}
 }
 
+/* ------------------------------- Test: Size ------------------------------- */
+
+func TestSize(t *testing.T) {
+	ex := executable.MustParse("Godot_v4.0-stable_linux.x86_64")
+
+	storePathToEx := filepath.Join(storeName, storeDirEx, "v4.0-stable/linux.x86_64")
+
+	tests := []struct {
+		name  string
+		files []fstest.Writer
+
+		want int64
+		err  error
+	}{
+		{
+			name: "missing artifact returns error",
+
+			err: fs.ErrNotExist,
+		},
+		{
+			name: "size includes all files in the store entry",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storePathToEx, ex.Path()), Contents: "abc"},
+				fstest.File{Path: filepath.Join(storePathToEx, "a/b"), Contents: "de"},
+				fstest.File{Path: filepath.Join(filepath.Dir(storePathToEx), "other/c"), Contents: "f"},
+			},
+
+			want: 5,
+		},
+		{
+			name: "size excludes the store entry's manifest",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storePathToEx, ex.Path()), Contents: "abc"},
+				fstest.File{Path: filepath.Join(storePathToEx, storeFileManifest), Contents: `{"files":{}}`},
+				fstest.File{Path: filepath.Join(storePathToEx, "a", storeFileManifest), Contents: "de"},
+			},
+
+			want: 5,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+
+			// Given: The specified files exist on the file system.
+			for _, f := range tc.files {
+				f.Write(t, tmp)
+			}
+
+			// When: The size of the artifact is computed.
+			got, err := Size(context.Background(), filepath.Join(tmp, storeName), ex)
+
+			// Then: The expected error value is returned.
+			if !errors.Is(err, tc.err) {
+				t.Errorf("err: got %v, want %v", err, tc.err)
+			}
+
+			// Then: The expected size is returned.
+			if got != tc.want {
+				t.Errorf("output: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 /* ------------------------------ Test: Sources ----------------------------- */
 
 func TestSources(t *testing.T) {
@@ -668,7 +880,7 @@ func TestSources(t *testing.T) {
 			files: []fstest.Writer{
 				fstest.File{Path: filepath.Join(storeName, storeDirBin, "gdenv-cli")},
 				fstest.File{Path: filepath.Join(storeName, storeDirEx, version.Godot3().String(), "godot")},
-				fstest.File{Path: filepath.Join(storeName, storeFileLayout)},
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
 				fstest.File{Path: filepath.Join(storeName, ".godot-version")},
 
 				fstest.Dir{Path: filepath.Join(storeName, storeDirSrc)},
@@ -682,7 +894,7 @@ func TestSources(t *testing.T) {
 			files: []fstest.Writer{
 				fstest.File{Path: filepath.Join(storeName, storeDirBin, "gdenv-cli")},
 				fstest.File{Path: filepath.Join(storeName, storeDirEx, version.Godot3().String(), "godot")},
-				fstest.File{Path: filepath.Join(storeName, storeFileLayout)},
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
 				fstest.File{Path: filepath.Join(storeName, ".godot-version")},
 
 				fstest.File{
@@ -725,6 +937,29 @@ func TestSources(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "source code versions are ordered by version",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeDirSrc, "v4.10-stable", "godot-4.10-stable.tar.xz")},
+				fstest.File{Path: filepath.Join(storeName, storeDirSrc, "v4.3-rc1", "godot-4.3-rc1.tar.xz")},
+				fstest.File{Path: filepath.Join(storeName, storeDirSrc, "v4.3-stable", "godot-4.3-stable.tar.xz")},
+			},
+
+			want: []LocalSrc{
+				{
+					Artifact: source.Archive{Inner: source.New(version.MustParse("v4.3-rc1"))},
+					Path:     filepath.Join(storeName, storeDirSrc, "v4.3-rc1", "godot-4.3-rc1.tar.xz"),
+				},
+				{
+					Artifact: source.Archive{Inner: source.New(version.MustParse("v4.3-stable"))},
+					Path:     filepath.Join(storeName, storeDirSrc, "v4.3-stable", "godot-4.3-stable.tar.xz"),
+				},
+				{
+					Artifact: source.Archive{Inner: source.New(version.MustParse("v4.10-stable"))},
+					Path:     filepath.Join(storeName, storeDirSrc, "v4.10-stable", "godot-4.10-stable.tar.xz"),
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -774,7 +1009,8 @@ func TestTouch(t *testing.T) {
 				fstest.Dir{Path: filepath.Join(storeName, storeDirBin)},
 				fstest.Dir{Path: filepath.Join(storeName, storeDirEx)},
 				fstest.Dir{Path: filepath.Join(storeName, storeDirSrc)},
-				fstest.File{Path: filepath.Join(storeName, storeFileLayout)},
+				fstest.Dir{Path: filepath.Join(storeName, storeDirTpl)},
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
 			},
 		},
 		{
@@ -788,19 +1024,41 @@ func TestTouch(t *testing.T) {
 				fstest.File{Path: filepath.Join(storeName, storeDirBin, "a")},
 				fstest.File{Path: filepath.Join(storeName, storeDirEx, "a")},
 				fstest.Dir{Path: filepath.Join(storeName, storeDirSrc)},
-				fstest.File{Path: filepath.Join(storeName, storeFileLayout)},
+				fstest.Dir{Path: filepath.Join(storeName, storeDirTpl)},
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
 			},
 		},
 		{
-			name: "overwrites a malformed layout file",
+			name: "migrates a store with an older layout",
 			files: []fstest.Writer{
-				fstest.File{Path: filepath.Join(storeName, storeFileLayout), Contents: "invalid"},
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"0")},
 			},
 
 			want: []fstest.Asserter{
-				fstest.File{Path: filepath.Join(storeName, storeFileLayout), Contents: ""},
+				fstest.Absent{Path: filepath.Join(storeName, storeFileLayoutPrefix+"0")},
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
 			},
 		},
+		{
+			name: "fails on a store with a newer layout",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"2")},
+			},
+
+			want: []fstest.Asserter{
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"2")},
+				fstest.Absent{Path: filepath.Join(storeName, storeFileLayoutPrefix+"1")},
+			},
+			err: ErrUnexpectedLayout,
+		},
+		{
+			name: "fails on a malformed layout marker",
+			files: []fstest.Writer{
+				fstest.File{Path: filepath.Join(storeName, storeFileLayoutPrefix+"x")},
+			},
+
+			err: ErrUnexpectedLayout,
+		},
 		{
 			name: "doesn't overwrite a pin file in the store",
 			files: []fstest.Writer{
@@ -825,7 +1083,7 @@ func TestTouch(t *testing.T) {
 			// When: A store is initialized at the specified path.
 			// Then: The expected error value is returned.
 			storePath := filepath.Join(tmp, storeName)
-			if err := Touch(storePath); !errors.Is(err, tc.err) {
+			if err := Touch(context.Background(), storePath); !errors.Is(err, tc.err) {
 				t.Errorf("got: %v, want: %v", err, tc.err)
 			}
 
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package store
+
+import (
+	"io/fs"
+
+	"golang.org/x/sys/unix"
+)
+
+/* --------------------------- Function: canWrite --------------------------- */
+
+// canWrite returns whether the current user can create files in the existing
+// directory at the specified path.
+func canWrite(path string, _ fs.FileInfo) bool {
+	return unix.Access(path, unix.W_OK) == nil
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package store
+
+import (
+	"io/fs"
+)
+
+/* --------------------------- Function: canWrite --------------------------- */
+
+// canWrite returns whether the current user can create files in the existing
+// directory at the specified path.
+//
+// NOTE: Windows doesn't report access control lists via file modes, so only
+// the directory's read-only attribute is checked.
+func canWrite(_ string, info fs.FileInfo) bool {
+	return info.Mode().Perm()&0o200 != 0
+}